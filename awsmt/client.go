@@ -0,0 +1,76 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
+)
+
+// mediaTailorClient is the subset of the MediaTailor API used by the provider. It is implemented by *mediatailor.Client
+// and by the in-memory fake in the awsmt/fake package, which allows the resources to be tested without AWS credentials.
+type mediaTailorClient interface {
+	// channels
+	CreateChannel(ctx context.Context, params *mediatailor.CreateChannelInput, optFns ...func(*mediatailor.Options)) (*mediatailor.CreateChannelOutput, error)
+	DescribeChannel(ctx context.Context, params *mediatailor.DescribeChannelInput, optFns ...func(*mediatailor.Options)) (*mediatailor.DescribeChannelOutput, error)
+	UpdateChannel(ctx context.Context, params *mediatailor.UpdateChannelInput, optFns ...func(*mediatailor.Options)) (*mediatailor.UpdateChannelOutput, error)
+	DeleteChannel(ctx context.Context, params *mediatailor.DeleteChannelInput, optFns ...func(*mediatailor.Options)) (*mediatailor.DeleteChannelOutput, error)
+	ListChannels(ctx context.Context, params *mediatailor.ListChannelsInput, optFns ...func(*mediatailor.Options)) (*mediatailor.ListChannelsOutput, error)
+	StartChannel(ctx context.Context, params *mediatailor.StartChannelInput, optFns ...func(*mediatailor.Options)) (*mediatailor.StartChannelOutput, error)
+	StopChannel(ctx context.Context, params *mediatailor.StopChannelInput, optFns ...func(*mediatailor.Options)) (*mediatailor.StopChannelOutput, error)
+	ConfigureLogsForChannel(ctx context.Context, params *mediatailor.ConfigureLogsForChannelInput, optFns ...func(*mediatailor.Options)) (*mediatailor.ConfigureLogsForChannelOutput, error)
+
+	// channel policies
+	GetChannelPolicy(ctx context.Context, params *mediatailor.GetChannelPolicyInput, optFns ...func(*mediatailor.Options)) (*mediatailor.GetChannelPolicyOutput, error)
+	PutChannelPolicy(ctx context.Context, params *mediatailor.PutChannelPolicyInput, optFns ...func(*mediatailor.Options)) (*mediatailor.PutChannelPolicyOutput, error)
+	DeleteChannelPolicy(ctx context.Context, params *mediatailor.DeleteChannelPolicyInput, optFns ...func(*mediatailor.Options)) (*mediatailor.DeleteChannelPolicyOutput, error)
+
+	// source locations
+	CreateSourceLocation(ctx context.Context, params *mediatailor.CreateSourceLocationInput, optFns ...func(*mediatailor.Options)) (*mediatailor.CreateSourceLocationOutput, error)
+	DescribeSourceLocation(ctx context.Context, params *mediatailor.DescribeSourceLocationInput, optFns ...func(*mediatailor.Options)) (*mediatailor.DescribeSourceLocationOutput, error)
+	UpdateSourceLocation(ctx context.Context, params *mediatailor.UpdateSourceLocationInput, optFns ...func(*mediatailor.Options)) (*mediatailor.UpdateSourceLocationOutput, error)
+	DeleteSourceLocation(ctx context.Context, params *mediatailor.DeleteSourceLocationInput, optFns ...func(*mediatailor.Options)) (*mediatailor.DeleteSourceLocationOutput, error)
+	ListSourceLocations(ctx context.Context, params *mediatailor.ListSourceLocationsInput, optFns ...func(*mediatailor.Options)) (*mediatailor.ListSourceLocationsOutput, error)
+
+	// vod sources
+	CreateVodSource(ctx context.Context, params *mediatailor.CreateVodSourceInput, optFns ...func(*mediatailor.Options)) (*mediatailor.CreateVodSourceOutput, error)
+	DescribeVodSource(ctx context.Context, params *mediatailor.DescribeVodSourceInput, optFns ...func(*mediatailor.Options)) (*mediatailor.DescribeVodSourceOutput, error)
+	UpdateVodSource(ctx context.Context, params *mediatailor.UpdateVodSourceInput, optFns ...func(*mediatailor.Options)) (*mediatailor.UpdateVodSourceOutput, error)
+	DeleteVodSource(ctx context.Context, params *mediatailor.DeleteVodSourceInput, optFns ...func(*mediatailor.Options)) (*mediatailor.DeleteVodSourceOutput, error)
+	ListVodSources(ctx context.Context, params *mediatailor.ListVodSourcesInput, optFns ...func(*mediatailor.Options)) (*mediatailor.ListVodSourcesOutput, error)
+
+	// live sources
+	CreateLiveSource(ctx context.Context, params *mediatailor.CreateLiveSourceInput, optFns ...func(*mediatailor.Options)) (*mediatailor.CreateLiveSourceOutput, error)
+	DescribeLiveSource(ctx context.Context, params *mediatailor.DescribeLiveSourceInput, optFns ...func(*mediatailor.Options)) (*mediatailor.DescribeLiveSourceOutput, error)
+	UpdateLiveSource(ctx context.Context, params *mediatailor.UpdateLiveSourceInput, optFns ...func(*mediatailor.Options)) (*mediatailor.UpdateLiveSourceOutput, error)
+	DeleteLiveSource(ctx context.Context, params *mediatailor.DeleteLiveSourceInput, optFns ...func(*mediatailor.Options)) (*mediatailor.DeleteLiveSourceOutput, error)
+	ListLiveSources(ctx context.Context, params *mediatailor.ListLiveSourcesInput, optFns ...func(*mediatailor.Options)) (*mediatailor.ListLiveSourcesOutput, error)
+
+	// playback configurations
+	PutPlaybackConfiguration(ctx context.Context, params *mediatailor.PutPlaybackConfigurationInput, optFns ...func(*mediatailor.Options)) (*mediatailor.PutPlaybackConfigurationOutput, error)
+	GetPlaybackConfiguration(ctx context.Context, params *mediatailor.GetPlaybackConfigurationInput, optFns ...func(*mediatailor.Options)) (*mediatailor.GetPlaybackConfigurationOutput, error)
+	DeletePlaybackConfiguration(ctx context.Context, params *mediatailor.DeletePlaybackConfigurationInput, optFns ...func(*mediatailor.Options)) (*mediatailor.DeletePlaybackConfigurationOutput, error)
+	ListPlaybackConfigurations(ctx context.Context, params *mediatailor.ListPlaybackConfigurationsInput, optFns ...func(*mediatailor.Options)) (*mediatailor.ListPlaybackConfigurationsOutput, error)
+	ConfigureLogsForPlaybackConfiguration(ctx context.Context, params *mediatailor.ConfigureLogsForPlaybackConfigurationInput, optFns ...func(*mediatailor.Options)) (*mediatailor.ConfigureLogsForPlaybackConfigurationOutput, error)
+
+	// tags
+	TagResource(ctx context.Context, params *mediatailor.TagResourceInput, optFns ...func(*mediatailor.Options)) (*mediatailor.TagResourceOutput, error)
+	UntagResource(ctx context.Context, params *mediatailor.UntagResourceInput, optFns ...func(*mediatailor.Options)) (*mediatailor.UntagResourceOutput, error)
+	ListTagsForResource(ctx context.Context, params *mediatailor.ListTagsForResourceInput, optFns ...func(*mediatailor.Options)) (*mediatailor.ListTagsForResourceOutput, error)
+}
+
+var _ mediaTailorClient = &mediatailor.Client{}
+
+// providerData is passed by the provider to every resource and data source through their Configure method.
+type providerData struct {
+	client mediaTailorClient
+	// region is the region of the MediaTailor client, used to check the region of imported resource identities
+	region string
+	tags   tagsConfig
+	// cache is the read cache wrapping the client when it is enabled in the provider block
+	cache *readCache
+}
+
+// enableReadCache wraps the client in a read cache.
+func (d *providerData) enableReadCache() {
+	d.cache = newReadCache(d.client)
+	d.client = d.cache
+}
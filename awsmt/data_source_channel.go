@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"iter"
 	"strings"
 	"terraform-provider-mediatailor/awsmt/models"
 )
@@ -20,7 +21,8 @@ func DataSourceChannel() datasource.DataSource {
 }
 
 type dataSourceChannel struct {
-	client *mediatailor.Client
+	client mediaTailorClient
+	tags   tagsConfig
 }
 
 func (d *dataSourceChannel) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -31,10 +33,10 @@ func (d *dataSourceChannel) Schema(_ context.Context, _ datasource.SchemaRequest
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id":                 computedString,
-			"arn":                computedString,
-			"name":               requiredString,
+			"arn":                dataSourceLookupArn,
+			"name":               dataSourceLookupName("arn"),
 			"channel_state":      computedString,
-			"creation_time":      computedString,
+			"creation_time":      computedRFC3339,
 			"enable_as_run_logs": computedBool,
 			"filler_slate": schema.SingleNestedAttribute{
 				Computed: true,
@@ -43,7 +45,7 @@ func (d *dataSourceChannel) Schema(_ context.Context, _ datasource.SchemaRequest
 					"vod_source_name":      computedString,
 				},
 			},
-			"last_modified_time": computedString,
+			"last_modified_time": computedRFC3339,
 			"outputs": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -77,8 +79,9 @@ func (d *dataSourceChannel) Schema(_ context.Context, _ datasource.SchemaRequest
 				Computed:   true,
 				CustomType: jsontypes.NormalizedType{},
 			},
-			"tags": computedMap,
-			"tier": computedString,
+			"tags":     dataSourceLookupTags,
+			"tags_all": computedMap,
+			"tier":     computedString,
 		},
 	}
 }
@@ -88,16 +91,28 @@ func (d *dataSourceChannel) Configure(_ context.Context, req datasource.Configur
 		return
 	}
 
-	d.client = req.ProviderData.(*mediatailor.Client)
+	data := req.ProviderData.(*providerData)
+	d.client = data.client
+	d.tags = data.tags
 }
 
 func (d *dataSourceChannel) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data models.ChannelModel
+	var data models.ChannelDataSourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	if data.Name == nil {
+		names, err := lookupNames("channel", arnResourceChannel, data.Arn, data.Tags, func() iter.Seq2[taggedObject, error] {
+			return listTaggedChannels(ctx, d.client)
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Error while looking up the channel "+err.Error(), err.Error())
+			return
+		}
+		data.Name = &names[0]
+	}
 	channelName := data.Name
 
 	channel, err := d.client.DescribeChannel(ctx, &mediatailor.DescribeChannelInput{ChannelName: channelName})
@@ -124,7 +139,10 @@ func (d *dataSourceChannel) Read(ctx context.Context, req datasource.ReadRequest
 		data.ChannelState = &channelState
 	}
 
-	data = writeChannelToState(data, *channel)
+	lookupTags := data.Tags
+	data.ChannelModel = writeChannelToState(data.ChannelModel, *channel)
+	data.Outputs = readOutputs(channel.Outputs)
+	data.Tags, data.TagsAll = d.tags.lookupDataSourceTags(lookupTags, channel.Tags)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
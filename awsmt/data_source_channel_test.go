@@ -14,11 +14,11 @@ func TestAccChannelDataSourceBasic(t *testing.T) {
 			{
 				Config: basicChannelDSHLS(),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("data.awsmt_channel.test", "id", "test"),
+					resource.TestCheckResourceAttr("data.awsmt_channel.test", "id", "tf-acc-channel"),
 					resource.TestMatchResourceAttr("data.awsmt_channel.test", "arn", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:channel\/.*$`)),
-					resource.TestCheckResourceAttr("data.awsmt_channel.test", "name", "test"),
-					resource.TestMatchResourceAttr("data.awsmt_channel.test", "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
-					resource.TestMatchResourceAttr("data.awsmt_channel.test", "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
+					resource.TestCheckResourceAttr("data.awsmt_channel.test", "name", "tf-acc-channel"),
+					resource.TestMatchResourceAttr("data.awsmt_channel.test", "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`)),
+					resource.TestMatchResourceAttr("data.awsmt_channel.test", "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`)),
 					resource.TestCheckResourceAttr("data.awsmt_channel.test", "channel_state", "STOPPED"),
 					resource.TestCheckResourceAttr("data.awsmt_channel.test", "playback_mode", "LOOP"),
 					resource.TestCheckResourceAttr("data.awsmt_channel.test", "tier", "BASIC"),
@@ -41,16 +41,16 @@ func TestAccChannelDataSourceFillerSlateLinear(t *testing.T) {
 			{
 				Config: basicChannelDSHLSWithSlate(),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("data.awsmt_channel.test", "id", "test"),
+					resource.TestCheckResourceAttr("data.awsmt_channel.test", "id", "tf-acc-channel"),
 					resource.TestMatchResourceAttr("data.awsmt_channel.test", "arn", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:channel\/.*$`)),
-					resource.TestCheckResourceAttr("data.awsmt_channel.test", "name", "test"),
-					resource.TestMatchResourceAttr("data.awsmt_channel.test", "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
-					resource.TestMatchResourceAttr("data.awsmt_channel.test", "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
+					resource.TestCheckResourceAttr("data.awsmt_channel.test", "name", "tf-acc-channel"),
+					resource.TestMatchResourceAttr("data.awsmt_channel.test", "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`)),
+					resource.TestMatchResourceAttr("data.awsmt_channel.test", "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`)),
 					resource.TestCheckResourceAttr("data.awsmt_channel.test", "channel_state", "STOPPED"),
 					resource.TestCheckResourceAttr("data.awsmt_channel.test", "playback_mode", "LINEAR"),
-					resource.TestCheckResourceAttr("data.awsmt_channel.test", "filler_slate.source_location_name", "test_source_location"),
-					resource.TestCheckResourceAttr("data.awsmt_channel.test", "filler_slate.vod_source_name", "vod_source_example"),
-					resource.TestCheckResourceAttr("data.awsmt_channel.test", "tier", "BASIC"),
+					resource.TestCheckResourceAttr("data.awsmt_channel.test", "filler_slate.source_location_name", "tf-acc-source-location"),
+					resource.TestCheckResourceAttr("data.awsmt_channel.test", "filler_slate.vod_source_name", "tf-acc-vod-source-example"),
+					resource.TestCheckResourceAttr("data.awsmt_channel.test", "tier", "STANDARD"),
 					resource.TestCheckResourceAttr("data.awsmt_channel.test", "tags.Environment", "dev"),
 					resource.TestCheckResourceAttr("data.awsmt_channel.test", "outputs.0.manifest_name", "default"),
 					resource.TestCheckResourceAttr("data.awsmt_channel.test", "outputs.0.source_group", "default"),
@@ -78,18 +78,17 @@ func TestAccChannelDataSourceErrors(t *testing.T) {
 func basicChannelDSHLS() string {
 	return `
 				resource "awsmt_channel" "test"  {
-  					name = "test"
+  					name = "tf-acc-channel"
   					channel_state = "STOPPED"
-  					outputs = [{
-    					manifest_name                = "default"
+  					outputs = { default = {
 						source_group                 = "default"
     					hls_playlist_settings = {
 							ad_markup_type = ["DATERANGE"]
 							manifest_window_seconds = 30
 						}
-  					}]
+  					}}
   					playback_mode = "LOOP"
-  					policy = "{\"Version\": \"2012-10-17\", \"Statement\": [{\"Sid\": \"AllowAnonymous\", \"Effect\": \"Allow\", \"Principal\": \"*\", \"Action\": \"mediatailor:GetManifest\", \"Resource\": \"arn:aws:mediatailor:eu-central-1:985600762523:channel/test\"}]}"
+  					policy = "{\"Version\": \"2012-10-17\", \"Statement\": [{\"Sid\": \"AllowAnonymous\", \"Effect\": \"Allow\", \"Principal\": \"*\", \"Action\": \"mediatailor:GetManifest\", \"Resource\": \"arn:aws:mediatailor:eu-central-1:985600762523:channel/tf-acc-channel\"}]}"
   					tier = "BASIC"
 					tags = {"Environment": "dev"}
 				}
@@ -112,7 +111,7 @@ func basicChannelDSHLSWithSlate() string {
     					type = "HLS"
   					}]
   					source_location_name = awsmt_source_location.test_source_location.name
-  					name = "vod_source_example"
+  					name = "tf-acc-vod-source-example"
 					tags = {"Environment": "dev"}
 				}
 				data "awsmt_vod_source" "data_test" {
@@ -124,7 +123,7 @@ func basicChannelDSHLSWithSlate() string {
   					value = data.awsmt_vod_source.data_test
 				}
 				resource "awsmt_source_location" "test_source_location"{
-  					name = "test_source_location"
+  					name = "tf-acc-source-location"
   					http_configuration = {
     					base_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com/"
   					}
@@ -139,23 +138,22 @@ func basicChannelDSHLSWithSlate() string {
   					value = data.awsmt_source_location.test
 				}
 				resource "awsmt_channel" "test"  {
-  					name = "test"
+  					name = "tf-acc-channel"
   					channel_state = "STOPPED"
-  					outputs = [{
-    					manifest_name                = "default"
+  					outputs = { default = {
 						source_group                 = "default"
     					hls_playlist_settings = {
 							ad_markup_type = ["DATERANGE"]
 							manifest_window_seconds = 30
 						}
-  					}]
+  					}}
   					playback_mode = "LINEAR"
 					filler_slate = {
 						source_location_name = awsmt_source_location.test_source_location.name
 						vod_source_name = awsmt_vod_source.test.name
 					}
-  					policy = "{\"Version\": \"2012-10-17\", \"Statement\": [{\"Sid\": \"AllowAnonymous\", \"Effect\": \"Allow\", \"Principal\": \"*\", \"Action\": \"mediatailor:GetManifest\", \"Resource\": \"arn:aws:mediatailor:eu-central-1:985600762523:channel/test\"}]}"
-  					tier = "BASIC"
+  					policy = "{\"Version\": \"2012-10-17\", \"Statement\": [{\"Sid\": \"AllowAnonymous\", \"Effect\": \"Allow\", \"Principal\": \"*\", \"Action\": \"mediatailor:GetManifest\", \"Resource\": \"arn:aws:mediatailor:eu-central-1:985600762523:channel/tf-acc-channel\"}]}"
+  					tier = "STANDARD"
 					tags = {"Environment": "dev"}
 				}
 
@@ -171,24 +169,23 @@ func basicChannelDSHLSWithSlate() string {
 func channelErrorDS() string {
 	return `
 				resource "awsmt_channel" "test"  {
-  					name = "test"
+  					name = "tf-acc-channel"
   					channel_state = "STOPPED"
-  					outputs = [{
-    					manifest_name                = "default"
+  					outputs = { default = {
 						source_group                 = "default"
     					hls_playlist_settings = {
 							ad_markup_type = ["DATERANGE"]
 							manifest_window_seconds = 30
 						}
-  					}]
+  					}}
   					playback_mode = "LOOP"
-  					policy = "{\"Version\": \"2012-10-17\", \"Statement\": [{\"Sid\": \"AllowAnonymous\", \"Effect\": \"Allow\", \"Principal\": \"*\", \"Action\": \"mediatailor:GetManifest\", \"Resource\": \"arn:aws:mediatailor:eu-central-1:985600762523:channel/test\"}]}"
+  					policy = "{\"Version\": \"2012-10-17\", \"Statement\": [{\"Sid\": \"AllowAnonymous\", \"Effect\": \"Allow\", \"Principal\": \"*\", \"Action\": \"mediatailor:GetManifest\", \"Resource\": \"arn:aws:mediatailor:eu-central-1:985600762523:channel/tf-acc-channel\"}]}"
   					tier = "BASIC"
 					tags = {"Environment": "dev"}
 				}
 
 				data "awsmt_channel" "test" {
-  					name = "testingError"
+  					name = "tf-acc-missing"
 				}
 				output "channel_out" {
 					value = data.awsmt_channel.test
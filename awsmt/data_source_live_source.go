@@ -3,8 +3,12 @@ package awsmt
 import (
 	"context"
 	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"iter"
 	"terraform-provider-mediatailor/awsmt/models"
 )
 
@@ -18,7 +22,8 @@ func DataSourceLiveSource() datasource.DataSource {
 }
 
 type dataSourceLiveSource struct {
-	client *mediatailor.Client
+	client mediaTailorClient
+	tags   tagsConfig
 }
 
 func (d *dataSourceLiveSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -29,13 +34,14 @@ func (d *dataSourceLiveSource) Schema(_ context.Context, _ datasource.SchemaRequ
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id":                          computedString,
-			"arn":                         computedString,
-			"creation_time":               computedString,
+			"arn":                         dataSourceLookupArn,
+			"creation_time":               computedRFC3339,
 			"http_package_configurations": httpPackageConfigurationsDataSourceSchema,
-			"last_modified_time":          computedString,
-			"name":                        requiredString,
-			"source_location_name":        requiredString,
-			"tags":                        computedMap,
+			"last_modified_time":          computedRFC3339,
+			"name":                        dataSourceLookupName("arn", stringvalidator.AlsoRequires(path.MatchRoot("source_location_name"))),
+			"source_location_name":        dataSourceLookupSourceLocationName,
+			"tags":                        dataSourceLookupTags,
+			"tags_all":                    computedMap,
 		},
 	}
 }
@@ -45,7 +51,9 @@ func (d *dataSourceLiveSource) Configure(_ context.Context, req datasource.Confi
 		return
 	}
 
-	d.client = req.ProviderData.(*mediatailor.Client)
+	data := req.ProviderData.(*providerData)
+	d.client = data.client
+	d.tags = data.tags
 }
 
 func (d *dataSourceLiveSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -55,6 +63,16 @@ func (d *dataSourceLiveSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
+	if data.Name == nil {
+		names, err := lookupNames("live source", arnResourceLiveSource, data.Arn, data.Tags, func() iter.Seq2[taggedObject, error] {
+			return listTaggedLiveSources(ctx, d.client, types.StringPointerValue(data.SourceLocationName))
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Error while looking up the live source "+err.Error(), err.Error())
+			return
+		}
+		data.SourceLocationName, data.Name = &names[0], &names[1]
+	}
 	sourceLocationName := data.SourceLocationName
 	liveSourceName := data.Name
 
@@ -64,7 +82,9 @@ func (d *dataSourceLiveSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
+	lookupTags := data.Tags
 	data = readLiveSource(data, mediatailor.CreateLiveSourceOutput(*liveSource))
+	data.Tags, data.TagsAll = d.tags.lookupDataSourceTags(lookupTags, liveSource.Tags)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
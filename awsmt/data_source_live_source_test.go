@@ -14,15 +14,15 @@ func TestAccLiveSourceDataSourceBasic(t *testing.T) {
 			{
 				Config: liveSourceDS(),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("data.awsmt_live_source.data_test", "id", "test_source_location,live_source_example"),
+					resource.TestCheckResourceAttr("data.awsmt_live_source.data_test", "id", "tf-acc-source-location,tf-acc-live-source-example"),
 					resource.TestMatchResourceAttr("data.awsmt_live_source.data_test", "arn", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:liveSource\/.*$`)),
-					resource.TestMatchResourceAttr("data.awsmt_live_source.data_test", "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
+					resource.TestMatchResourceAttr("data.awsmt_live_source.data_test", "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`)),
 					resource.TestCheckResourceAttr("data.awsmt_live_source.data_test", "http_package_configurations.0.path", "/"),
 					resource.TestCheckResourceAttr("data.awsmt_live_source.data_test", "http_package_configurations.0.source_group", "default"),
 					resource.TestCheckResourceAttr("data.awsmt_live_source.data_test", "http_package_configurations.0.type", "HLS"),
-					resource.TestMatchResourceAttr("data.awsmt_live_source.data_test", "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
-					resource.TestCheckResourceAttr("data.awsmt_live_source.data_test", "name", "live_source_example"),
-					resource.TestCheckResourceAttr("data.awsmt_live_source.data_test", "source_location_name", "test_source_location"),
+					resource.TestMatchResourceAttr("data.awsmt_live_source.data_test", "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`)),
+					resource.TestCheckResourceAttr("data.awsmt_live_source.data_test", "name", "tf-acc-live-source-example"),
+					resource.TestCheckResourceAttr("data.awsmt_live_source.data_test", "source_location_name", "tf-acc-source-location"),
 					resource.TestCheckResourceAttr("data.awsmt_live_source.data_test", "tags.Environment", "dev"),
 				),
 			},
@@ -52,7 +52,7 @@ func liveSourceDS() string {
     					type = "HLS"
   					}]
   					source_location_name = awsmt_source_location.test_source_location.name
-  					name = "live_source_example"
+  					name = "tf-acc-live-source-example"
 					tags = {"Environment": "dev"}
 				}
 
@@ -65,7 +65,7 @@ func liveSourceDS() string {
   					value = data.awsmt_live_source.data_test
 				}
 			resource "awsmt_source_location" "test_source_location"{
-  				name = "test_source_location"
+  				name = "tf-acc-source-location"
   				http_configuration = {
     				base_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com/"
   				}
@@ -91,20 +91,20 @@ func liveSourceError() string {
     					type = "HLS"
   					}]
   					source_location_name = awsmt_source_location.test_source_location.name
-  					name = "live_source_example"
+  					name = "tf-acc-live-source-example"
 					tags = {"Environment": "dev"}
 				}
 
 				data "awsmt_live_source" "data_test" {
   					source_location_name = awsmt_source_location.test_source_location.name
-  					name = "testingError"
+  					name = "tf-acc-missing"
 				}
 
 				output "live_source_out" {
   					value = data.awsmt_live_source.data_test
 				}
 			resource "awsmt_source_location" "test_source_location"{
-  				name = "test_source_location"
+  				name = "tf-acc-source-location"
   				http_configuration = {
     				base_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com/"
   				}
@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"iter"
 	"terraform-provider-mediatailor/awsmt/models"
 )
 
@@ -19,7 +20,8 @@ func DataSourcePlaybackConfiguration() datasource.DataSource {
 }
 
 type dataSourcePlaybackConfiguration struct {
-	client *mediatailor.Client
+	client mediaTailorClient
+	tags   tagsConfig
 }
 
 func (d *dataSourcePlaybackConfiguration) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -67,7 +69,12 @@ func (d *dataSourcePlaybackConfiguration) Schema(_ context.Context, _ datasource
 					"origin_manifest_type":     computedString,
 				},
 			},
-			"hls_configuration_manifest_endpoint_prefix": computedString,
+			"hls_configuration": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"manifest_endpoint_prefix": computedString,
+				},
+			},
 			"live_pre_roll_configuration": schema.SingleNestedAttribute{
 				Computed: true,
 				Attributes: map[string]schema.Attribute{
@@ -92,13 +99,14 @@ func (d *dataSourcePlaybackConfiguration) Schema(_ context.Context, _ datasource
 					},
 				},
 			},
-			"name":                                   requiredString,
+			"name":                                   dataSourceLookupName("playback_configuration_arn"),
 			"personalization_threshold_seconds":      computedInt64,
-			"playback_configuration_arn":             computedString,
+			"playback_configuration_arn":             dataSourceLookupArn,
 			"playback_endpoint_prefix":               computedString,
 			"session_initialization_endpoint_prefix": computedString,
 			"slate_ad_url":                           computedString,
-			"tags":                                   computedMap,
+			"tags":                                   dataSourceLookupTags,
+			"tags_all":                               computedMap,
 			"transcode_profile_name":                 computedString,
 			"video_content_source_url":               computedString,
 		},
@@ -110,7 +118,9 @@ func (d *dataSourcePlaybackConfiguration) Configure(_ context.Context, req datas
 		return
 	}
 
-	d.client = req.ProviderData.(*mediatailor.Client)
+	data := req.ProviderData.(*providerData)
+	d.client = data.client
+	d.tags = data.tags
 }
 
 func (d *dataSourcePlaybackConfiguration) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -121,6 +131,16 @@ func (d *dataSourcePlaybackConfiguration) Read(ctx context.Context, req datasour
 		return
 	}
 
+	if data.Name == nil {
+		names, err := lookupNames("playback configuration", arnResourcePlaybackConfiguration, data.PlaybackConfigurationArn, data.Tags, func() iter.Seq2[taggedObject, error] {
+			return listTaggedPlaybackConfigurations(ctx, d.client)
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Error while looking up the playback configuration "+err.Error(), err.Error())
+			return
+		}
+		data.Name = &names[0]
+	}
 	name := data.Name
 
 	playbackConfiguration, err := d.client.GetPlaybackConfiguration(context.TODO(), &mediatailor.GetPlaybackConfigurationInput{Name: name})
@@ -132,7 +152,10 @@ func (d *dataSourcePlaybackConfiguration) Read(ctx context.Context, req datasour
 		return
 	}
 
+	lookupTags := data.Tags
 	m := putPlaybackConfigurationModelbuilder{model: &data, output: mediatailor.PutPlaybackConfigurationOutput(*playbackConfiguration), isResource: false}
+	model := m.getModel()
+	model.Tags, model.TagsAll = d.tags.lookupDataSourceTags(lookupTags, playbackConfiguration.Tags)
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, m.getModel())...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
 }
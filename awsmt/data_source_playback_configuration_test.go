@@ -15,7 +15,7 @@ func TestAccPlaybackConfigurationDataSourceBasic(t *testing.T) {
 			{
 				Config: playbackConfigDS(),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "id", "example-playback-configuration-awsmt"),
+					resource.TestCheckResourceAttr(resourceName, "id", "tf-acc-playback-configuration"),
 					resource.TestCheckResourceAttr(resourceName, "ad_decision_server_url", "https://exampleurl.com/"),
 					resource.TestCheckResourceAttr(resourceName, "avail_suppression.fill_policy", "FULL_AVAIL_ONLY"),
 					resource.TestCheckResourceAttr(resourceName, "avail_suppression.mode", "BEHIND_LIVE_EDGE"),
@@ -30,7 +30,7 @@ func TestAccPlaybackConfigurationDataSourceBasic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "dash_configuration.origin_manifest_type", "SINGLE_PERIOD"),
 					resource.TestCheckResourceAttr(resourceName, "live_pre_roll_configuration.ad_decision_server_url", "https://exampleurl.com/"),
 					resource.TestCheckResourceAttr(resourceName, "live_pre_roll_configuration.max_duration_seconds", "2"),
-					resource.TestCheckResourceAttr(resourceName, "name", "example-playback-configuration-awsmt"),
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-acc-playback-configuration"),
 					resource.TestCheckResourceAttr(resourceName, "personalization_threshold_seconds", "2"),
 					resource.TestCheckResourceAttr(resourceName, "slate_ad_url", "https://exampleurl.com/"),
 					resource.TestCheckResourceAttr(resourceName, "tags.Environment", "dev"),
@@ -91,7 +91,7 @@ func playbackConfigDS() string {
       								enabled = "false"
     							}
   							}
-  							name = "example-playback-configuration-awsmt"
+  							name = "tf-acc-playback-configuration"
   							personalization_threshold_seconds = 2
 							slate_ad_url = "https://exampleurl.com/"
   							tags = {"Environment": "dev"}
@@ -139,7 +139,7 @@ func playbackConfigDSError() string {
       								enabled = "false"
     							}
   							}
-  							name = "example-playback-configuration-awsmt"
+  							name = "tf-acc-playback-configuration"
   							personalization_threshold_seconds = 2
 							slate_ad_url = "https://exampleurl.com/"
   							tags = {"Environment": "dev"}
@@ -149,7 +149,7 @@ func playbackConfigDSError() string {
 						}
 
 						data "awsmt_playback_configuration" "test"{
-  							name = "testingErrors"
+  							name = "tf-acc-missing"
 						}
 
 						output "playback_configuration_out" {
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"iter"
 	"terraform-provider-mediatailor/awsmt/models"
 )
 
@@ -20,7 +21,8 @@ func DataSourceSourceLocation() datasource.DataSource {
 }
 
 type dataSourceSourceLocation struct {
-	client *mediatailor.Client
+	client mediaTailorClient
+	tags   tagsConfig
 }
 
 func (d *dataSourceSourceLocation) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -50,8 +52,8 @@ func (d *dataSourceSourceLocation) Schema(_ context.Context, _ datasource.Schema
 					},
 				},
 			},
-			"arn":           computedString,
-			"creation_time": computedString,
+			"arn":           dataSourceLookupArn,
+			"creation_time": computedRFC3339,
 			"default_segment_delivery_configuration": schema.SingleNestedAttribute{
 				Computed: true,
 				Attributes: map[string]schema.Attribute{
@@ -64,7 +66,7 @@ func (d *dataSourceSourceLocation) Schema(_ context.Context, _ datasource.Schema
 					"base_url": computedString,
 				},
 			},
-			"last_modified_time": computedString,
+			"last_modified_time": computedRFC3339,
 			"segment_delivery_configurations": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -74,8 +76,9 @@ func (d *dataSourceSourceLocation) Schema(_ context.Context, _ datasource.Schema
 					},
 				},
 			},
-			"name": requiredString,
-			"tags": computedMap,
+			"name":     dataSourceLookupName("arn"),
+			"tags":     dataSourceLookupTags,
+			"tags_all": computedMap,
 		},
 	}
 }
@@ -85,7 +88,9 @@ func (d *dataSourceSourceLocation) Configure(_ context.Context, req datasource.C
 		return
 	}
 
-	d.client = req.ProviderData.(*mediatailor.Client)
+	data := req.ProviderData.(*providerData)
+	d.client = data.client
+	d.tags = data.tags
 }
 
 func (d *dataSourceSourceLocation) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -96,6 +101,16 @@ func (d *dataSourceSourceLocation) Read(ctx context.Context, req datasource.Read
 		return
 	}
 
+	if data.Name == nil {
+		names, err := lookupNames("source location", arnResourceSourceLocation, data.Arn, data.Tags, func() iter.Seq2[taggedObject, error] {
+			return listTaggedSourceLocations(ctx, d.client)
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Error while looking up the source location "+err.Error(), err.Error())
+			return
+		}
+		data.Name = &names[0]
+	}
 	sourceLocationName := data.Name
 
 	sourceLocation, err := d.client.DescribeSourceLocation(ctx, &mediatailor.DescribeSourceLocationInput{SourceLocationName: sourceLocationName})
@@ -104,7 +119,10 @@ func (d *dataSourceSourceLocation) Read(ctx context.Context, req datasource.Read
 		return
 	}
 
+	// the read overwrites the tags, which are the lookup tags when they are configured
+	lookupTags := data.Tags
 	data = writeSourceLocationToPlan(data, mediatailor.CreateSourceLocationOutput(*sourceLocation))
+	data.Tags, data.TagsAll = d.tags.lookupDataSourceTags(lookupTags, sourceLocation.Tags)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
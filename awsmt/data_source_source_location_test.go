@@ -14,14 +14,14 @@ func TestAccSourceLocationDataSourceBasic(t *testing.T) {
 			{
 				Config: sourceLocationDS(),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("data.awsmt_source_location.read", "id", "test_source_location"),
+					resource.TestCheckResourceAttr("data.awsmt_source_location.read", "id", "tf-acc-source-location"),
 					resource.TestMatchResourceAttr("data.awsmt_source_location.read", "arn", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:sourceLocation\/.*$`)),
-					resource.TestMatchResourceAttr("data.awsmt_source_location.read", "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
+					resource.TestMatchResourceAttr("data.awsmt_source_location.read", "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`)),
 					resource.TestCheckResourceAttr("data.awsmt_source_location.read", "default_segment_delivery_configuration.base_url", "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com/test-img.jpeg"),
 					resource.TestCheckResourceAttr("data.awsmt_source_location.read", "http_configuration.base_url", "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"),
-					resource.TestMatchResourceAttr("data.awsmt_source_location.read", "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
+					resource.TestMatchResourceAttr("data.awsmt_source_location.read", "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`)),
 					resource.TestCheckResourceAttr("data.awsmt_source_location.read", "segment_delivery_configurations.0.base_url", "https://example.com/"),
-					resource.TestCheckResourceAttr("data.awsmt_source_location.read", "name", "test_source_location"),
+					resource.TestCheckResourceAttr("data.awsmt_source_location.read", "name", "tf-acc-source-location"),
 				),
 			},
 		},
@@ -43,7 +43,7 @@ func TestAccSourceLocationDataSourceErrors(t *testing.T) {
 
 func sourceLocationDS() string {
 	return `resource "awsmt_source_location" "test_source_location"{
-  							name = "test_source_location"
+  							name = "tf-acc-source-location"
   							http_configuration = {
     							base_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"
   							}
@@ -67,7 +67,7 @@ func sourceLocationDS() string {
 
 func sourceLocationDSError() string {
 	return `resource "awsmt_source_location" "test_source_location"{
-  							name = "test_source_location"
+  							name = "tf-acc-source-location"
   							http_configuration = {
     							base_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"
   							}
@@ -81,7 +81,7 @@ func sourceLocationDSError() string {
 							tags = {"Environment": "dev"}
 						}
 						data "awsmt_source_location" "read" {
-  							name = "testing_errors"
+  							name = "tf-acc-missing"
 						}
 						output "awsmt_source_location" {
   							value = data.awsmt_source_location.read
@@ -3,8 +3,12 @@ package awsmt
 import (
 	"context"
 	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"iter"
 	"terraform-provider-mediatailor/awsmt/models"
 )
 
@@ -18,7 +22,8 @@ func DataSourceVodSource() datasource.DataSource {
 }
 
 type dataSourceVodSource struct {
-	client *mediatailor.Client
+	client mediaTailorClient
+	tags   tagsConfig
 }
 
 func (d *dataSourceVodSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -29,13 +34,14 @@ func (d *dataSourceVodSource) Schema(_ context.Context, _ datasource.SchemaReque
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id":                                   computedString,
-			"source_location_name":                 requiredString,
+			"source_location_name":                 dataSourceLookupSourceLocationName,
 			"http_package_configurations":          httpPackageConfigurationsDataSourceSchema,
-			"creation_time":                        computedString,
-			"tags":                                 computedMap,
-			"last_modified_time":                   computedString,
-			"arn":                                  computedString,
-			"name":                                 requiredString,
+			"creation_time":                        computedRFC3339,
+			"tags":                                 dataSourceLookupTags,
+			"tags_all":                             computedMap,
+			"last_modified_time":                   computedRFC3339,
+			"arn":                                  dataSourceLookupArn,
+			"name":                                 dataSourceLookupName("arn", stringvalidator.AlsoRequires(path.MatchRoot("source_location_name"))),
 			"ad_break_opportunities_offset_millis": computedInt64List,
 		},
 	}
@@ -46,7 +52,9 @@ func (d *dataSourceVodSource) Configure(_ context.Context, req datasource.Config
 		return
 	}
 
-	d.client = req.ProviderData.(*mediatailor.Client)
+	data := req.ProviderData.(*providerData)
+	d.client = data.client
+	d.tags = data.tags
 }
 
 func (d *dataSourceVodSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -56,6 +64,16 @@ func (d *dataSourceVodSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
+	if data.Name == nil {
+		names, err := lookupNames("vod source", arnResourceVodSource, data.Arn, data.Tags, func() iter.Seq2[taggedObject, error] {
+			return listTaggedVodSources(ctx, d.client, types.StringPointerValue(data.SourceLocationName))
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Error while looking up the vod source "+err.Error(), err.Error())
+			return
+		}
+		data.SourceLocationName, data.Name = &names[0], &names[1]
+	}
 	sourceLocationName := data.SourceLocationName
 	vodSourceName := data.Name
 
@@ -65,7 +83,9 @@ func (d *dataSourceVodSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
+	lookupTags := data.Tags
 	data = readVodSourceToState(data, *vodSource)
+	data.Tags, data.TagsAll = d.tags.lookupDataSourceTags(lookupTags, vodSource.Tags)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
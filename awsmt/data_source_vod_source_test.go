@@ -14,15 +14,15 @@ func TestAccVodSourceDataSourceBasic(t *testing.T) {
 			{
 				Config: vodSourceDS(),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("data.awsmt_vod_source.data_test", "id", "test_source_location,vod_source_example"),
+					resource.TestCheckResourceAttr("data.awsmt_vod_source.data_test", "id", "tf-acc-source-location,tf-acc-vod-source-example"),
 					resource.TestMatchResourceAttr("data.awsmt_vod_source.data_test", "arn", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:vodSource\/.*$`)),
-					resource.TestMatchResourceAttr("data.awsmt_vod_source.data_test", "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
+					resource.TestMatchResourceAttr("data.awsmt_vod_source.data_test", "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`)),
 					resource.TestCheckResourceAttr("data.awsmt_vod_source.data_test", "http_package_configurations.0.path", "/"),
 					resource.TestCheckResourceAttr("data.awsmt_vod_source.data_test", "http_package_configurations.0.source_group", "default"),
 					resource.TestCheckResourceAttr("data.awsmt_vod_source.data_test", "http_package_configurations.0.type", "HLS"),
-					resource.TestMatchResourceAttr("data.awsmt_vod_source.data_test", "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
-					resource.TestCheckResourceAttr("data.awsmt_vod_source.data_test", "name", "vod_source_example"),
-					resource.TestCheckResourceAttr("data.awsmt_vod_source.data_test", "source_location_name", "test_source_location"),
+					resource.TestMatchResourceAttr("data.awsmt_vod_source.data_test", "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`)),
+					resource.TestCheckResourceAttr("data.awsmt_vod_source.data_test", "name", "tf-acc-vod-source-example"),
+					resource.TestCheckResourceAttr("data.awsmt_vod_source.data_test", "source_location_name", "tf-acc-source-location"),
 					resource.TestCheckResourceAttr("data.awsmt_vod_source.data_test", "tags.Environment", "dev"),
 				),
 			},
@@ -43,6 +43,25 @@ func TestAccVodSourceDataSourceErrors(t *testing.T) {
 	})
 }
 
+func TestAccVodSourceDataSourceLookup(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: vodSourceLookupDS(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.awsmt_vod_source.by_arn", "name", "tf-acc-vod-source-lookup"),
+					resource.TestCheckResourceAttr("data.awsmt_vod_source.by_arn", "source_location_name", "tf-acc-lookup-source-location"),
+					resource.TestCheckResourceAttr("data.awsmt_vod_source.by_tags", "name", "tf-acc-vod-source-lookup"),
+					resource.TestCheckResourceAttr("data.awsmt_vod_source.by_tags", "tags.%", "1"),
+					resource.TestCheckResourceAttr("data.awsmt_vod_source.by_tags", "tags_all.%", "2"),
+				),
+			},
+		},
+	})
+}
+
 func vodSourceDS() string {
 	return `
 				resource "awsmt_vod_source" "test" {
@@ -52,7 +71,7 @@ func vodSourceDS() string {
     					type = "HLS"
   					}]
   					source_location_name = awsmt_source_location.test_source_location.name
-  					name = "vod_source_example"
+  					name = "tf-acc-vod-source-example"
 					tags = {"Environment": "dev"}
 				}
 
@@ -65,7 +84,7 @@ func vodSourceDS() string {
   					value = data.awsmt_vod_source.data_test
 				}
 				resource "awsmt_source_location" "test_source_location"{
-  					name = "test_source_location"
+  					name = "tf-acc-source-location"
   					http_configuration = {
     					base_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com/"
   					}
@@ -91,20 +110,20 @@ func vodSourceDSError() string {
     					type = "HLS"
   					}]
   					source_location_name = awsmt_source_location.test_source_location.name
-  					name = "vod_source_example"
+  					name = "tf-acc-vod-source-example"
 					tags = {"Environment": "dev"}
 				}
 
 				data "awsmt_vod_source" "data_test" {
   					source_location_name = awsmt_source_location.test_source_location.name
-  					name = "testing_errors"
+  					name = "tf-acc-missing"
 				}
 
 				output "vod_source_out" {
   					value = data.awsmt_vod_source.data_test
 				}
 				resource "awsmt_source_location" "test_source_location"{
-  					name = "test_source_location"
+  					name = "tf-acc-source-location"
   					http_configuration = {
     					base_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com/"
   					}
@@ -120,3 +139,35 @@ func vodSourceDSError() string {
 				}
 				`
 }
+
+func vodSourceLookupDS() string {
+	return `
+				resource "awsmt_source_location" "lookup" {
+  					name = "tf-acc-lookup-source-location"
+  					http_configuration = {
+    					base_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com/"
+  					}
+				}
+
+				resource "awsmt_vod_source" "lookup" {
+  					http_package_configurations = [{
+						path = "/"
+						source_group = "default"
+    					type = "HLS"
+  					}]
+  					source_location_name = awsmt_source_location.lookup.name
+  					name = "tf-acc-vod-source-lookup"
+					tags = {"Environment": "dev", "Role": "lookup_slate"}
+				}
+
+				data "awsmt_vod_source" "by_arn" {
+  					arn = awsmt_vod_source.lookup.arn
+				}
+
+				data "awsmt_vod_source" "by_tags" {
+  					source_location_name = awsmt_source_location.lookup.name
+  					tags = {"Role": "lookup_slate"}
+					depends_on = [awsmt_vod_source.lookup]
+				}
+				`
+}
@@ -0,0 +1,411 @@
+package awsmt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+	"maps"
+	"math/big"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ExportOptions are the options of the export command of the provider binary.
+type ExportOptions struct {
+	// Region is the AWS region of the exported resources.
+	Region string
+	// Profile is the AWS profile used to list the resources, the default credentials being used when empty.
+	Profile string
+	// Out is the directory the configuration files are written to.
+	Out string
+}
+
+// exportedResources are the resources written by the export command, the source locations coming first so that the
+// vod and live sources can refer to them.
+var exportedResources = []struct {
+	list     func() list.ListResource
+	resource func() resource.Resource
+}{
+	{ListResourceSourceLocation, ResourceSourceLocation},
+	{ListResourceVodSource, ResourceVodSource},
+	{ListResourceLiveSource, ResourceLiveSource},
+	{ListResourceChannel, ResourceChannel},
+	{ListResourcePlaybackConfiguration, ResourcePlaybackConfiguration},
+}
+
+// Export writes the configuration of every MediaTailor resource of a region, with the import blocks adopting them.
+func Export(ctx context.Context, options ExportOptions) error {
+	data, err := configureExport(ctx, &awsmtProvider{}, options)
+	if err != nil {
+		return err
+	}
+	return exportResources(ctx, data, options.Out)
+}
+
+// configureExport configures the provider as Terraform does, so that the export uses the same clients and settings as
+// the resources.
+func configureExport(ctx context.Context, p *awsmtProvider, options ExportOptions) (*providerData, error) {
+	var schemaResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+
+	configType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range configType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	values["region"] = tftypes.NewValue(tftypes.String, options.Region)
+	values["max_retry_attempts"] = tftypes.NewValue(tftypes.Number, 10)
+	if options.Profile != "" {
+		values["profile"] = tftypes.NewValue(tftypes.String, options.Profile)
+	}
+
+	var resp provider.ConfigureResponse
+	p.Configure(ctx, provider.ConfigureRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(configType, values)}}, &resp)
+	if err := diagnosticsError(resp.Diagnostics); err != nil {
+		return nil, err
+	}
+	return resp.ResourceData.(*providerData), nil
+}
+
+// @ADR
+// Context: Hundreds of hand-made MediaTailor objects had to be adopted, and writing their configuration and import
+// blocks by hand is slow and error-prone.
+// Decision: The export command runs the list resources with their full resource objects, and writes the arguments of
+// the resource schemas with hclwrite, along with the import blocks identified by the display names of the results.
+// Consequences: The exported configuration is what the resources read, but the plan of the imports should still be
+// reviewed before applying it. A resource that cannot be read is reported without stopping the export of the others.
+func exportResources(ctx context.Context, data *providerData, out string) error {
+	if err := os.MkdirAll(out, 0o755); err != nil {
+		return err
+	}
+
+	var errs []error
+	// labels holds the labels of the exported resources by type and import ID, so that they can be referred to
+	labels := map[string]map[string]string{}
+	for _, exported := range exportedResources {
+		l, r := exported.list(), exported.resource()
+		var metadata resource.MetadataResponse
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "awsmt"}, &metadata)
+		typeName := metadata.TypeName
+		labels[typeName] = map[string]string{}
+
+		results, err := listAll(ctx, data, l, r)
+		if err != nil {
+			return fmt.Errorf("error while listing %s: %w", typeName, err)
+		}
+
+		file := hclwrite.NewEmptyFile()
+		used := map[string]bool{}
+		for _, result := range results {
+			if err := diagnosticsError(result.Diagnostics); err != nil {
+				errs = append(errs, fmt.Errorf("%s %s: %w", typeName, result.DisplayName, err))
+				continue
+			}
+			label := exportLabel(result.DisplayName, used)
+			labels[typeName][result.DisplayName] = label
+
+			if len(file.Body().Blocks()) > 0 {
+				file.Body().AppendNewline()
+			}
+			importBody := file.Body().AppendNewBlock("import", nil).Body()
+			importBody.SetAttributeTraversal("to", hcl.Traversal{hcl.TraverseRoot{Name: typeName}, hcl.TraverseAttr{Name: label}})
+			importBody.SetAttributeValue("id", cty.StringVal(result.DisplayName))
+			file.Body().AppendNewline()
+
+			resourceBody := file.Body().AppendNewBlock("resource", []string{typeName, label}).Body()
+			if err := writeExportedResource(resourceBody, result.Resource.Schema.(schema.Schema), result.Resource.Raw, labels); err != nil {
+				errs = append(errs, fmt.Errorf("%s %s: %w", typeName, result.DisplayName, err))
+			}
+		}
+		if len(results) == 0 {
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(out, typeName+".tf"), hclwrite.Format(file.Bytes()), 0o644); err != nil {
+			return err
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// listAll returns every result of a list resource, with the full resource objects.
+func listAll(ctx context.Context, data *providerData, l list.ListResource, r resource.Resource) ([]list.ListResult, error) {
+	l.(list.ListResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{ProviderData: data}, &resource.ConfigureResponse{})
+
+	var configSchema list.ListResourceSchemaResponse
+	l.ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, &configSchema)
+	var resourceSchema resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &resourceSchema)
+	var identitySchema resource.IdentitySchemaResponse
+	r.(resource.ResourceWithIdentity).IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &identitySchema)
+
+	// the filters of the list configuration are null, so that every resource is listed
+	configType := configSchema.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	filters := map[string]tftypes.Value{}
+	for name, attributeType := range configType.AttributeTypes {
+		filters[name] = tftypes.NewValue(attributeType, nil)
+	}
+
+	req := list.ListRequest{
+		Config:                 tfsdk.Config{Schema: configSchema.Schema, Raw: tftypes.NewValue(configType, filters)},
+		IncludeResource:        true,
+		ResourceSchema:         resourceSchema.Schema,
+		ResourceIdentitySchema: identitySchema.IdentitySchema,
+	}
+	var stream list.ListResultsStream
+	l.List(ctx, req, &stream)
+
+	var results []list.ListResult
+	for result := range stream.Results {
+		// a result without display name reports a failed List operation
+		if result.DisplayName == "" {
+			return nil, diagnosticsError(result.Diagnostics)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+var invalidLabelCharacters = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+
+// exportLabel returns a unique resource label from the import ID of a resource, the names of the vod and live sources
+// being prefixed with the name of their source location.
+func exportLabel(importId string, used map[string]bool) string {
+	label := invalidLabelCharacters.ReplaceAllString(strings.ReplaceAll(importId, ",", "_"), "_")
+	if label == "" || !(label[0] == '_' || (label[0] >= 'a' && label[0] <= 'z') || (label[0] >= 'A' && label[0] <= 'Z')) {
+		label = "_" + label
+	}
+	unique := label
+	for i := 2; used[unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", label, i)
+	}
+	used[unique] = true
+	return unique
+}
+
+// writeExportedResource writes the arguments of a resource, the source location names referring to the exported
+// source locations.
+func writeExportedResource(body *hclwrite.Body, s schema.Schema, value tftypes.Value, labels map[string]map[string]string) error {
+	var attributes map[string]tftypes.Value
+	if err := value.As(&attributes); err != nil {
+		return err
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(s.Attributes)) {
+		attribute, attributeValue := s.Attributes[name], attributes[name]
+		if !isArgument(attribute) || isUnsetValue(attribute, attributeValue) {
+			continue
+		}
+
+		if name == "source_location_name" {
+			var sourceLocationName string
+			_ = attributeValue.As(&sourceLocationName)
+			if label, ok := labels["awsmt_source_location"][sourceLocationName]; ok {
+				body.SetAttributeTraversal(name, hcl.Traversal{hcl.TraverseRoot{Name: "awsmt_source_location"}, hcl.TraverseAttr{Name: label}, hcl.TraverseAttr{Name: "name"}})
+				continue
+			}
+		}
+
+		if stringAttribute, ok := attribute.(schema.StringAttribute); ok && stringAttribute.CustomType == (jsontypes.NormalizedType{}) {
+			tokens, err := jsonencodeTokens(attributeValue)
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			body.SetAttributeRaw(name, tokens)
+			continue
+		}
+
+		converted, err := exportValue(attribute, attributeValue)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		body.SetAttributeValue(name, converted)
+	}
+	return nil
+}
+
+// isArgument returns whether an attribute can be configured, the computed-only attributes being read from MediaTailor.
+func isArgument(attribute schema.Attribute) bool {
+	return attribute.IsRequired() || attribute.IsOptional()
+}
+
+// isUnsetValue returns whether a value can be left out of the configuration: the null values, and the empty
+// collections of the computed attributes, which MediaTailor sets when they are not configured.
+func isUnsetValue(attribute schema.Attribute, value tftypes.Value) bool {
+	if value.IsNull() {
+		return true
+	}
+	if !attribute.IsComputed() || attribute.IsRequired() {
+		return false
+	}
+	var elements []tftypes.Value
+	if value.Type().Is(tftypes.List{}) || value.Type().Is(tftypes.Set{}) {
+		return value.As(&elements) == nil && len(elements) == 0
+	}
+	var entries map[string]tftypes.Value
+	if value.Type().Is(tftypes.Map{}) {
+		return value.As(&entries) == nil && len(entries) == 0
+	}
+	return false
+}
+
+// exportValue converts the value of an attribute to cty, leaving out the computed-only and the null attributes of the
+// nested objects.
+func exportValue(attribute schema.Attribute, value tftypes.Value) (cty.Value, error) {
+	var nested map[string]schema.Attribute
+	switch a := attribute.(type) {
+	case schema.SingleNestedAttribute:
+		return exportObject(a.Attributes, value)
+	case schema.ListNestedAttribute:
+		nested = a.NestedObject.Attributes
+	case schema.SetNestedAttribute:
+		nested = a.NestedObject.Attributes
+	case schema.MapNestedAttribute:
+		var elements map[string]tftypes.Value
+		if err := value.As(&elements); err != nil {
+			return cty.NilVal, err
+		}
+		converted := map[string]cty.Value{}
+		for key, element := range elements {
+			object, err := exportObject(a.NestedObject.Attributes, element)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			converted[key] = object
+		}
+		return cty.ObjectVal(converted), nil
+	default:
+		return ctyValue(value)
+	}
+
+	var elements []tftypes.Value
+	if err := value.As(&elements); err != nil {
+		return cty.NilVal, err
+	}
+	var converted []cty.Value
+	for _, element := range elements {
+		object, err := exportObject(nested, element)
+		if err != nil {
+			return cty.NilVal, err
+		}
+		converted = append(converted, object)
+	}
+	if len(converted) == 0 {
+		return cty.EmptyTupleVal, nil
+	}
+	return cty.TupleVal(converted), nil
+}
+
+func exportObject(attributes map[string]schema.Attribute, value tftypes.Value) (cty.Value, error) {
+	var values map[string]tftypes.Value
+	if err := value.As(&values); err != nil {
+		return cty.NilVal, err
+	}
+	converted := map[string]cty.Value{}
+	for name, attribute := range attributes {
+		if !isArgument(attribute) || isUnsetValue(attribute, values[name]) {
+			continue
+		}
+		v, err := exportValue(attribute, values[name])
+		if err != nil {
+			return cty.NilVal, fmt.Errorf("%s: %w", name, err)
+		}
+		converted[name] = v
+	}
+	return cty.ObjectVal(converted), nil
+}
+
+// ctyValue converts a Terraform value to cty, the collections being written as tuples and objects.
+func ctyValue(value tftypes.Value) (cty.Value, error) {
+	if value.IsNull() {
+		return cty.NullVal(cty.DynamicPseudoType), nil
+	}
+	switch {
+	case value.Type().Is(tftypes.String):
+		var s string
+		err := value.As(&s)
+		return cty.StringVal(s), err
+	case value.Type().Is(tftypes.Bool):
+		var b bool
+		err := value.As(&b)
+		return cty.BoolVal(b), err
+	case value.Type().Is(tftypes.Number):
+		var n big.Float
+		err := value.As(&n)
+		return cty.NumberVal(&n), err
+	case value.Type().Is(tftypes.Object{}), value.Type().Is(tftypes.Map{}):
+		var attributes map[string]tftypes.Value
+		if err := value.As(&attributes); err != nil {
+			return cty.NilVal, err
+		}
+		converted := map[string]cty.Value{}
+		for name, attribute := range attributes {
+			v, err := ctyValue(attribute)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			converted[name] = v
+		}
+		return cty.ObjectVal(converted), nil
+	case value.Type().Is(tftypes.List{}), value.Type().Is(tftypes.Tuple{}), value.Type().Is(tftypes.Set{}):
+		var elements []tftypes.Value
+		if err := value.As(&elements); err != nil {
+			return cty.NilVal, err
+		}
+		var converted []cty.Value
+		for _, element := range elements {
+			v, err := ctyValue(element)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			converted = append(converted, v)
+		}
+		if len(converted) == 0 {
+			return cty.EmptyTupleVal, nil
+		}
+		return cty.TupleVal(converted), nil
+	default:
+		return cty.NilVal, fmt.Errorf("unsupported value of type %s", value.Type())
+	}
+}
+
+// jsonencodeTokens writes a JSON document as a call to jsonencode, which is easier to review and edit than an escaped
+// string.
+func jsonencodeTokens(value tftypes.Value) (hclwrite.Tokens, error) {
+	var document string
+	if err := value.As(&document); err != nil {
+		return nil, err
+	}
+	impliedType, err := ctyjson.ImpliedType([]byte(document))
+	if err != nil {
+		return hclwrite.TokensForValue(cty.StringVal(document)), nil
+	}
+	decoded, err := ctyjson.Unmarshal([]byte(document), impliedType)
+	if err != nil {
+		return nil, err
+	}
+	return hclwrite.TokensForFunctionCall("jsonencode", hclwrite.TokensForValue(decoded)), nil
+}
+
+// diagnosticsError returns the errors of diagnostics as a single error.
+func diagnosticsError(diags diag.Diagnostics) error {
+	var errs []error
+	for _, d := range diags.Errors() {
+		errs = append(errs, fmt.Errorf("%s: %s", d.Summary(), d.Detail()))
+	}
+	return errors.Join(errs...)
+}
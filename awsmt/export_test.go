@@ -0,0 +1,96 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
+	awsTypes "github.com/aws/aws-sdk-go-v2/service/mediatailor/types"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExport(t *testing.T) {
+	ctx := context.Background()
+	client := newListTestClient(t)
+	if _, err := client.CreateChannel(ctx, &mediatailor.CreateChannelInput{
+		ChannelName:  aws.String("channel"),
+		PlaybackMode: awsTypes.PlaybackModeLoop,
+		Tier:         awsTypes.TierBasic,
+		Outputs: []awsTypes.RequestOutputItem{
+			{ManifestName: aws.String("index"), SourceGroup: aws.String("default"), HlsPlaylistSettings: &awsTypes.HlsPlaylistSettings{}},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.PutChannelPolicy(ctx, &mediatailor.PutChannelPolicyInput{
+		ChannelName: aws.String("channel"),
+		Policy:      aws.String(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"mediatailor:GetManifest","Resource":"arn:aws:mediatailor:eu-central-1:123456789012:channel/channel"}]}`),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.PutPlaybackConfiguration(ctx, &mediatailor.PutPlaybackConfigurationInput{
+		Name:                  aws.String("playback.v2"),
+		AdDecisionServerUrl:   aws.String("https://example.com/ads"),
+		VideoContentSourceUrl: aws.String("https://example.com/content"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := configureExport(ctx, &awsmtProvider{client: client}, ExportOptions{Region: "eu-central-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := t.TempDir()
+	if err := exportResources(ctx, data, out); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{}
+	for _, name := range []string{"awsmt_source_location", "awsmt_vod_source", "awsmt_live_source", "awsmt_channel", "awsmt_playback_configuration"} {
+		content, err := os.ReadFile(filepath.Join(out, name+".tf"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, diags := hclsyntax.ParseConfig(content, name+".tf", hcl.InitialPos); diags.HasErrors() {
+			t.Fatalf("invalid configuration %s: %s", name, diags)
+		}
+		files[name] = string(content)
+	}
+
+	for name, expected := range map[string][]string{
+		"awsmt_source_location":        {"to = awsmt_source_location.test_a", `id = "test_a"`, `base_url = "https://example.com"`},
+		"awsmt_vod_source":             {"to = awsmt_vod_source.test_a_vod", `id = "test_a,vod"`, "source_location_name = awsmt_source_location.test_a.name"},
+		"awsmt_live_source":            {`resource "awsmt_live_source" "other_live"`, "source_location_name = awsmt_source_location.other.name"},
+		"awsmt_channel":                {`resource "awsmt_channel" "channel"`, "policy = jsonencode({", `source_group = "default"`},
+		"awsmt_playback_configuration": {"to = awsmt_playback_configuration.playback_v2", `id = "playback.v2"`},
+	} {
+		for _, e := range expected {
+			if !strings.Contains(files[name], e) {
+				t.Errorf("expected %s to contain %q, got\n%s", name, e, files[name])
+			}
+		}
+	}
+	for name, content := range files {
+		if strings.Contains(content, "arn ") || strings.Contains(content, "ad_break_opportunities_offset_millis") {
+			t.Errorf("expected %s to leave out the computed attributes, got\n%s", name, content)
+		}
+	}
+}
+
+func TestExportLabel(t *testing.T) {
+	used := map[string]bool{}
+	for _, c := range []struct{ importId, expected string }{
+		{"movie", "movie"},
+		{"sl,movie", "sl_movie"},
+		{"sl_movie", "sl_movie_2"},
+		{"2024.news", "_2024_news"},
+	} {
+		if label := exportLabel(c.importId, used); label != c.expected {
+			t.Errorf("expected the label %s for %s, got %s", c.expected, c.importId, label)
+		}
+	}
+}
@@ -0,0 +1,335 @@
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
+	awsTypes "github.com/aws/aws-sdk-go-v2/service/mediatailor/types"
+	"slices"
+)
+
+func (c *Client) validateChannel(operation string, playbackMode string, fillerSlate *awsTypes.SlateSource, outputs []awsTypes.RequestOutputItem) error {
+	if playbackMode != string(awsTypes.PlaybackModeLinear) && playbackMode != string(awsTypes.PlaybackModeLoop) {
+		return badRequest(operation, "invalid playback mode %q", playbackMode)
+	}
+	if len(outputs) == 0 {
+		return badRequest(operation, "Outputs must contain at least one element")
+	}
+	manifestNames := map[string]bool{}
+	for _, output := range outputs {
+		if isEmpty(output.ManifestName) {
+			return badRequest(operation, "output manifest name is required")
+		}
+		if isEmpty(output.SourceGroup) {
+			return badRequest(operation, "output source group is required")
+		}
+		if manifestNames[*output.ManifestName] {
+			return badRequest(operation, "duplicate output manifest name %q", *output.ManifestName)
+		}
+		manifestNames[*output.ManifestName] = true
+		if (output.HlsPlaylistSettings == nil) == (output.DashPlaylistSettings == nil) {
+			return badRequest(operation, "output %q must have exactly one of HlsPlaylistSettings and DashPlaylistSettings", *output.ManifestName)
+		}
+		if output.HlsPlaylistSettings != nil {
+			for _, markup := range output.HlsPlaylistSettings.AdMarkupType {
+				if markup != awsTypes.AdMarkupTypeDaterange && markup != awsTypes.AdMarkupTypeScte35Enhanced {
+					return badRequest(operation, "invalid ad markup type %q", markup)
+				}
+			}
+		}
+	}
+	if fillerSlate == nil && playbackMode == string(awsTypes.PlaybackModeLinear) {
+		return badRequest(operation, "filler slate is required for LINEAR channels")
+	}
+	if fillerSlate != nil {
+		if playbackMode != string(awsTypes.PlaybackModeLinear) {
+			return badRequest(operation, "filler slate is only supported for LINEAR channels")
+		}
+		if isEmpty(fillerSlate.SourceLocationName) || isEmpty(fillerSlate.VodSourceName) {
+			return badRequest(operation, "filler slate requires a source location name and a vod source name")
+		}
+		sl, ok := c.sourceLocations[*fillerSlate.SourceLocationName]
+		if !ok {
+			return badRequest(operation, "source location %s does not exist", *fillerSlate.SourceLocationName)
+		}
+		if _, ok := sl.vodSources[*fillerSlate.VodSourceName]; !ok {
+			return badRequest(operation, "vod source %s does not exist in source location %s", *fillerSlate.VodSourceName, *fillerSlate.SourceLocationName)
+		}
+	}
+	return nil
+}
+
+func (c *Client) responseOutputs(channelName string, outputs []awsTypes.RequestOutputItem) []awsTypes.ResponseOutputItem {
+	var responseOutputs []awsTypes.ResponseOutputItem
+	for _, output := range outputs {
+		extension := "m3u8"
+		hls := output.HlsPlaylistSettings
+		if hls != nil {
+			hls = &awsTypes.HlsPlaylistSettings{
+				AdMarkupType:          slices.Clone(hls.AdMarkupType),
+				ManifestWindowSeconds: hls.ManifestWindowSeconds,
+			}
+			if len(hls.AdMarkupType) == 0 {
+				hls.AdMarkupType = []awsTypes.AdMarkupType{awsTypes.AdMarkupTypeDaterange}
+			}
+		} else {
+			extension = "mpd"
+		}
+		responseOutputs = append(responseOutputs, awsTypes.ResponseOutputItem{
+			DashPlaylistSettings: output.DashPlaylistSettings,
+			HlsPlaylistSettings:  hls,
+			ManifestName:         output.ManifestName,
+			PlaybackUrl:          aws.String(fmt.Sprintf("https://channel-assembly.mediatailor.%s.amazonaws.com/v1/channel/%s/%s.%s", c.region, channelName, *output.ManifestName, extension)),
+			SourceGroup:          output.SourceGroup,
+		})
+	}
+	return responseOutputs
+}
+
+func (c *Client) describeChannel(ch *channel) *mediatailor.DescribeChannelOutput {
+	output := ch.output
+	output.Tags = c.tagsOf(output.Arn)
+	output.Outputs = slices.Clone(output.Outputs)
+	output.LogConfiguration = &awsTypes.LogConfigurationForChannel{LogTypes: slices.Clone(ch.output.LogConfiguration.LogTypes)}
+	return &output
+}
+
+func (c *Client) CreateChannel(_ context.Context, params *mediatailor.CreateChannelInput, _ ...func(*mediatailor.Options)) (*mediatailor.CreateChannelOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if isEmpty(params.ChannelName) {
+		return nil, badRequest("CreateChannel", "ChannelName is required")
+	}
+	name := *params.ChannelName
+	if _, ok := c.channels[name]; ok {
+		return nil, badRequest("CreateChannel", "channel %s already exists", name)
+	}
+	if err := c.validateChannel("CreateChannel", string(params.PlaybackMode), params.FillerSlate, params.Outputs); err != nil {
+		return nil, err
+	}
+	tier := string(params.Tier)
+	if tier == "" {
+		tier = string(awsTypes.TierBasic)
+	}
+	if tier != string(awsTypes.TierBasic) && tier != string(awsTypes.TierStandard) {
+		return nil, badRequest("CreateChannel", "invalid tier %q", tier)
+	}
+
+	timestamp := now()
+	ch := &channel{output: mediatailor.DescribeChannelOutput{
+		Arn:              c.arn("channel/" + name),
+		ChannelName:      aws.String(name),
+		ChannelState:     awsTypes.ChannelStateStopped,
+		CreationTime:     timestamp,
+		FillerSlate:      params.FillerSlate,
+		LastModifiedTime: timestamp,
+		LogConfiguration: &awsTypes.LogConfigurationForChannel{LogTypes: []awsTypes.LogType{}},
+		Outputs:          c.responseOutputs(name, params.Outputs),
+		PlaybackMode:     aws.String(string(params.PlaybackMode)),
+		Tier:             aws.String(tier),
+	}}
+	c.channels[name] = ch
+	c.register(ch.output.Arn, params.Tags)
+
+	return channelOutput(c.describeChannel(ch)), nil
+}
+
+// channelOutput strips the fields that are only part of the DescribeChannel output, so that the result can be
+// converted to the outputs of the other operations.
+func channelOutput(output *mediatailor.DescribeChannelOutput) *mediatailor.CreateChannelOutput {
+	return &mediatailor.CreateChannelOutput{
+		Arn:                    output.Arn,
+		Audiences:              output.Audiences,
+		ChannelName:            output.ChannelName,
+		ChannelState:           output.ChannelState,
+		CreationTime:           output.CreationTime,
+		FillerSlate:            output.FillerSlate,
+		LastModifiedTime:       output.LastModifiedTime,
+		Outputs:                output.Outputs,
+		PlaybackMode:           output.PlaybackMode,
+		Tags:                   output.Tags,
+		Tier:                   output.Tier,
+		TimeShiftConfiguration: output.TimeShiftConfiguration,
+	}
+}
+
+func (c *Client) DescribeChannel(_ context.Context, params *mediatailor.DescribeChannelInput, _ ...func(*mediatailor.Options)) (*mediatailor.DescribeChannelOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch, err := c.channel("DescribeChannel", params.ChannelName)
+	if err != nil {
+		return nil, err
+	}
+	return c.describeChannel(ch), nil
+}
+
+func (c *Client) channel(operation string, name *string) (*channel, error) {
+	if isEmpty(name) {
+		return nil, badRequest(operation, "ChannelName is required")
+	}
+	ch, ok := c.channels[*name]
+	if !ok {
+		return nil, notFound(operation, "channel %s does not exist", *name)
+	}
+	return ch, nil
+}
+
+func (c *Client) UpdateChannel(_ context.Context, params *mediatailor.UpdateChannelInput, _ ...func(*mediatailor.Options)) (*mediatailor.UpdateChannelOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch, err := c.channel("UpdateChannel", params.ChannelName)
+	if err != nil {
+		return nil, err
+	}
+	if ch.output.ChannelState == awsTypes.ChannelStateRunning {
+		return nil, badRequest("UpdateChannel", "channel %s must be stopped before it can be updated", *params.ChannelName)
+	}
+	if err := c.validateChannel("UpdateChannel", *ch.output.PlaybackMode, params.FillerSlate, params.Outputs); err != nil {
+		return nil, err
+	}
+
+	ch.output.FillerSlate = params.FillerSlate
+	ch.output.Outputs = c.responseOutputs(*params.ChannelName, params.Outputs)
+	ch.output.LastModifiedTime = now()
+
+	return (*mediatailor.UpdateChannelOutput)(channelOutput(c.describeChannel(ch))), nil
+}
+
+func (c *Client) DeleteChannel(_ context.Context, params *mediatailor.DeleteChannelInput, _ ...func(*mediatailor.Options)) (*mediatailor.DeleteChannelOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch, err := c.channel("DeleteChannel", params.ChannelName)
+	if err != nil {
+		return nil, err
+	}
+	if ch.output.ChannelState == awsTypes.ChannelStateRunning {
+		return nil, badRequest("DeleteChannel", "channel %s must be stopped before it can be deleted", *params.ChannelName)
+	}
+	delete(c.tags, *ch.output.Arn)
+	delete(c.channels, *params.ChannelName)
+	return &mediatailor.DeleteChannelOutput{}, nil
+}
+
+func (c *Client) ListChannels(_ context.Context, params *mediatailor.ListChannelsInput, _ ...func(*mediatailor.Options)) (*mediatailor.ListChannelsOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	names, nextToken, err := page("ListChannels", c.channels, params.MaxResults, params.NextToken)
+	if err != nil {
+		return nil, err
+	}
+	output := &mediatailor.ListChannelsOutput{NextToken: nextToken}
+	for _, name := range names {
+		ch := c.describeChannel(c.channels[name])
+		output.Items = append(output.Items, awsTypes.Channel{
+			Arn:              ch.Arn,
+			ChannelName:      ch.ChannelName,
+			ChannelState:     aws.String(string(ch.ChannelState)),
+			CreationTime:     ch.CreationTime,
+			FillerSlate:      ch.FillerSlate,
+			LastModifiedTime: ch.LastModifiedTime,
+			LogConfiguration: ch.LogConfiguration,
+			Outputs:          ch.Outputs,
+			PlaybackMode:     ch.PlaybackMode,
+			Tags:             ch.Tags,
+			Tier:             ch.Tier,
+		})
+	}
+	return output, nil
+}
+
+func (c *Client) StartChannel(_ context.Context, params *mediatailor.StartChannelInput, _ ...func(*mediatailor.Options)) (*mediatailor.StartChannelOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch, err := c.channel("StartChannel", params.ChannelName)
+	if err != nil {
+		return nil, err
+	}
+	ch.output.ChannelState = awsTypes.ChannelStateRunning
+	return &mediatailor.StartChannelOutput{}, nil
+}
+
+func (c *Client) StopChannel(_ context.Context, params *mediatailor.StopChannelInput, _ ...func(*mediatailor.Options)) (*mediatailor.StopChannelOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch, err := c.channel("StopChannel", params.ChannelName)
+	if err != nil {
+		return nil, err
+	}
+	ch.output.ChannelState = awsTypes.ChannelStateStopped
+	return &mediatailor.StopChannelOutput{}, nil
+}
+
+func (c *Client) ConfigureLogsForChannel(_ context.Context, params *mediatailor.ConfigureLogsForChannelInput, _ ...func(*mediatailor.Options)) (*mediatailor.ConfigureLogsForChannelOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch, err := c.channel("ConfigureLogsForChannel", params.ChannelName)
+	if err != nil {
+		return nil, err
+	}
+	for _, logType := range params.LogTypes {
+		if logType != awsTypes.LogTypeAsRun {
+			return nil, badRequest("ConfigureLogsForChannel", "invalid log type %q", logType)
+		}
+	}
+	ch.output.LogConfiguration = &awsTypes.LogConfigurationForChannel{LogTypes: slices.Clone(params.LogTypes)}
+	return &mediatailor.ConfigureLogsForChannelOutput{ChannelName: params.ChannelName, LogTypes: slices.Clone(params.LogTypes)}, nil
+}
+
+// channel policies
+
+func (c *Client) GetChannelPolicy(_ context.Context, params *mediatailor.GetChannelPolicyInput, _ ...func(*mediatailor.Options)) (*mediatailor.GetChannelPolicyOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch, err := c.channel("GetChannelPolicy", params.ChannelName)
+	if err != nil {
+		return nil, err
+	}
+	if ch.policy == nil {
+		return nil, notFound("GetChannelPolicy", "channel %s has no policy", *params.ChannelName)
+	}
+	return &mediatailor.GetChannelPolicyOutput{Policy: aws.String(*ch.policy)}, nil
+}
+
+func (c *Client) PutChannelPolicy(_ context.Context, params *mediatailor.PutChannelPolicyInput, _ ...func(*mediatailor.Options)) (*mediatailor.PutChannelPolicyOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch, err := c.channel("PutChannelPolicy", params.ChannelName)
+	if err != nil {
+		return nil, err
+	}
+	if isEmpty(params.Policy) {
+		return nil, badRequest("PutChannelPolicy", "Policy is required")
+	}
+	var policy struct {
+		Statement json.RawMessage
+	}
+	if err := json.Unmarshal([]byte(*params.Policy), &policy); err != nil || len(policy.Statement) == 0 {
+		return nil, badRequest("PutChannelPolicy", "policy is not a valid IAM policy document")
+	}
+	ch.policy = aws.String(*params.Policy)
+	return &mediatailor.PutChannelPolicyOutput{}, nil
+}
+
+func (c *Client) DeleteChannelPolicy(_ context.Context, params *mediatailor.DeleteChannelPolicyInput, _ ...func(*mediatailor.Options)) (*mediatailor.DeleteChannelPolicyOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch, err := c.channel("DeleteChannelPolicy", params.ChannelName)
+	if err != nil {
+		return nil, err
+	}
+	ch.policy = nil
+	return &mediatailor.DeleteChannelPolicyOutput{}, nil
+}
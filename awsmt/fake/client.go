@@ -0,0 +1,220 @@
+// Package fake provides an in-memory implementation of the MediaTailor API used by the provider. It keeps channels,
+// source locations, vod and live sources, playback configurations, channel policies and tags in memory and validates
+// requests the way the MediaTailor API does, so that the provider can be tested without AWS credentials.
+package fake
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
+	awsTypes "github.com/aws/aws-sdk-go-v2/service/mediatailor/types"
+	"github.com/aws/smithy-go"
+	"maps"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultRegion    = "eu-central-1"
+	DefaultAccountID = "123456789012"
+)
+
+type Client struct {
+	mu sync.Mutex
+
+	region    string
+	accountID string
+
+	channels               map[string]*channel
+	sourceLocations        map[string]*sourceLocation
+	playbackConfigurations map[string]*mediatailor.GetPlaybackConfigurationOutput
+	// tags are stored by resource ARN, as the TagResource and UntagResource operations address them
+	tags map[string]map[string]string
+}
+
+type channel struct {
+	output mediatailor.DescribeChannelOutput
+	policy *string
+}
+
+type sourceLocation struct {
+	output      mediatailor.DescribeSourceLocationOutput
+	vodSources  map[string]*mediatailor.DescribeVodSourceOutput
+	liveSources map[string]*mediatailor.DescribeLiveSourceOutput
+}
+
+// NewClient returns an empty fake in the default region and account.
+func NewClient() *Client {
+	return NewClientForAccount(DefaultRegion, DefaultAccountID)
+}
+
+// NewClientForAccount returns an empty fake that builds ARNs and endpoints for the given region and account.
+func NewClientForAccount(region, accountID string) *Client {
+	return &Client{
+		region:                 region,
+		accountID:              accountID,
+		channels:               map[string]*channel{},
+		sourceLocations:        map[string]*sourceLocation{},
+		playbackConfigurations: map[string]*mediatailor.GetPlaybackConfigurationOutput{},
+		tags:                   map[string]map[string]string{},
+	}
+}
+
+// Reset removes every object stored in the fake.
+func (c *Client) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.channels = map[string]*channel{}
+	c.sourceLocations = map[string]*sourceLocation{}
+	c.playbackConfigurations = map[string]*mediatailor.GetPlaybackConfigurationOutput{}
+	c.tags = map[string]map[string]string{}
+}
+
+// errors
+
+func operationError(operation string, err error) error {
+	return &smithy.OperationError{ServiceID: mediatailor.ServiceID, OperationName: operation, Err: err}
+}
+
+func badRequest(operation string, format string, args ...any) error {
+	return operationError(operation, &awsTypes.BadRequestException{Message: aws.String(fmt.Sprintf(format, args...))})
+}
+
+func notFound(operation string, format string, args ...any) error {
+	return operationError(operation, &smithy.GenericAPIError{Code: "NotFoundException", Message: fmt.Sprintf(format, args...), Fault: smithy.FaultClient})
+}
+
+// helpers
+
+func (c *Client) arn(resourcePath string) *string {
+	return aws.String(fmt.Sprintf("arn:aws:mediatailor:%s:%s:%s", c.region, c.accountID, resourcePath))
+}
+
+func now() *time.Time {
+	t := time.Now().UTC().Truncate(time.Millisecond)
+	return &t
+}
+
+func isEmpty(s *string) bool {
+	return s == nil || *s == ""
+}
+
+func (c *Client) tagsOf(arn *string) map[string]string {
+	tags := c.tags[*arn]
+	if len(tags) == 0 {
+		return nil
+	}
+	return maps.Clone(tags)
+}
+
+func (c *Client) register(arn *string, tags map[string]string) {
+	c.tags[*arn] = map[string]string{}
+	maps.Copy(c.tags[*arn], tags)
+}
+
+// page returns the part of the sorted keys selected by the pagination parameters and the token of the next page.
+func page[T any](operation string, items map[string]T, maxResults *int32, nextToken *string) ([]string, *string, error) {
+	keys := slices.Sorted(maps.Keys(items))
+
+	start := 0
+	if !isEmpty(nextToken) {
+		var err error
+		if start, err = strconv.Atoi(*nextToken); err != nil || start < 0 || start > len(keys) {
+			return nil, nil, badRequest(operation, "invalid pagination token %q", *nextToken)
+		}
+	}
+
+	size := 100
+	if maxResults != nil {
+		if *maxResults < 1 || *maxResults > 100 {
+			return nil, nil, badRequest(operation, "MaxResults must be between 1 and 100")
+		}
+		size = int(*maxResults)
+	}
+
+	end := min(start+size, len(keys))
+	if end == len(keys) {
+		return keys[start:end], nil, nil
+	}
+	return keys[start:end], aws.String(strconv.Itoa(end)), nil
+}
+
+func validateHttpPackageConfigurations(operation string, configurations []awsTypes.HttpPackageConfiguration) error {
+	if len(configurations) == 0 {
+		return badRequest(operation, "HttpPackageConfigurations must contain at least one element")
+	}
+	for _, configuration := range configurations {
+		if isEmpty(configuration.Path) {
+			return badRequest(operation, "HttpPackageConfiguration path is required")
+		}
+		if isEmpty(configuration.SourceGroup) {
+			return badRequest(operation, "HttpPackageConfiguration source group is required")
+		}
+		if configuration.Type != awsTypes.TypeHls && configuration.Type != awsTypes.TypeDash {
+			return badRequest(operation, "invalid HttpPackageConfiguration type %q", configuration.Type)
+		}
+	}
+	return nil
+}
+
+// tags
+
+func (c *Client) TagResource(_ context.Context, params *mediatailor.TagResourceInput, _ ...func(*mediatailor.Options)) (*mediatailor.TagResourceOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if isEmpty(params.ResourceArn) {
+		return nil, badRequest("TagResource", "ResourceArn is required")
+	}
+	tags, ok := c.tags[*params.ResourceArn]
+	if !ok {
+		return nil, notFound("TagResource", "resource %s does not exist", *params.ResourceArn)
+	}
+	if len(params.Tags) == 0 {
+		return nil, badRequest("TagResource", "Tags must contain at least one element")
+	}
+	for k := range params.Tags {
+		if k == "" {
+			return nil, badRequest("TagResource", "tag keys must not be empty")
+		}
+	}
+	maps.Copy(tags, params.Tags)
+	return &mediatailor.TagResourceOutput{}, nil
+}
+
+func (c *Client) UntagResource(_ context.Context, params *mediatailor.UntagResourceInput, _ ...func(*mediatailor.Options)) (*mediatailor.UntagResourceOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if isEmpty(params.ResourceArn) {
+		return nil, badRequest("UntagResource", "ResourceArn is required")
+	}
+	tags, ok := c.tags[*params.ResourceArn]
+	if !ok {
+		return nil, notFound("UntagResource", "resource %s does not exist", *params.ResourceArn)
+	}
+	if len(params.TagKeys) == 0 {
+		return nil, badRequest("UntagResource", "TagKeys must contain at least one element")
+	}
+	for _, k := range params.TagKeys {
+		delete(tags, k)
+	}
+	return &mediatailor.UntagResourceOutput{}, nil
+}
+
+func (c *Client) ListTagsForResource(_ context.Context, params *mediatailor.ListTagsForResourceInput, _ ...func(*mediatailor.Options)) (*mediatailor.ListTagsForResourceOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if isEmpty(params.ResourceArn) {
+		return nil, badRequest("ListTagsForResource", "ResourceArn is required")
+	}
+	if _, ok := c.tags[*params.ResourceArn]; !ok {
+		return nil, notFound("ListTagsForResource", "resource %s does not exist", *params.ResourceArn)
+	}
+	return &mediatailor.ListTagsForResourceOutput{Tags: c.tagsOf(params.ResourceArn)}, nil
+}
@@ -0,0 +1,288 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
+	awsTypes "github.com/aws/aws-sdk-go-v2/service/mediatailor/types"
+	"github.com/aws/smithy-go"
+	"strings"
+	"testing"
+)
+
+func createVodSource(t *testing.T, c *Client, sourceLocationName, name string) {
+	t.Helper()
+	if _, err := c.CreateSourceLocation(context.TODO(), &mediatailor.CreateSourceLocationInput{
+		SourceLocationName: aws.String(sourceLocationName),
+		HttpConfiguration:  &awsTypes.HttpConfiguration{BaseUrl: aws.String("https://example.com")},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.CreateVodSource(context.TODO(), &mediatailor.CreateVodSourceInput{
+		SourceLocationName: aws.String(sourceLocationName),
+		VodSourceName:      aws.String(name),
+		HttpPackageConfigurations: []awsTypes.HttpPackageConfiguration{
+			{Path: aws.String("/"), SourceGroup: aws.String("default"), Type: awsTypes.TypeHls},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func linearChannelInput(name string) *mediatailor.CreateChannelInput {
+	return &mediatailor.CreateChannelInput{
+		ChannelName:  aws.String(name),
+		PlaybackMode: awsTypes.PlaybackModeLinear,
+		Tier:         awsTypes.TierStandard,
+		FillerSlate:  &awsTypes.SlateSource{SourceLocationName: aws.String("sl"), VodSourceName: aws.String("slate")},
+		Outputs: []awsTypes.RequestOutputItem{
+			{ManifestName: aws.String("index"), SourceGroup: aws.String("default"), HlsPlaylistSettings: &awsTypes.HlsPlaylistSettings{}},
+		},
+		Tags: map[string]string{"Environment": "test"},
+	}
+}
+
+func TestChannelLifecycle(t *testing.T) {
+	c := NewClient()
+	createVodSource(t, c, "sl", "slate")
+
+	created, err := c.CreateChannel(context.TODO(), linearChannelInput("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *created.Arn != "arn:aws:mediatailor:eu-central-1:123456789012:channel/test" {
+		t.Errorf("unexpected ARN %s", *created.Arn)
+	}
+	if created.ChannelState != awsTypes.ChannelStateStopped {
+		t.Errorf("expected a new channel to be stopped, got %s", created.ChannelState)
+	}
+	if created.Outputs[0].HlsPlaylistSettings.AdMarkupType[0] != awsTypes.AdMarkupTypeDaterange {
+		t.Errorf("expected the ad markup type to default to DATERANGE")
+	}
+
+	if _, err := c.CreateChannel(context.TODO(), linearChannelInput("test")); err == nil {
+		t.Error("expected an error when creating a duplicate channel")
+	}
+
+	if _, err := c.StartChannel(context.TODO(), &mediatailor.StartChannelInput{ChannelName: aws.String("test")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.DeleteChannel(context.TODO(), &mediatailor.DeleteChannelInput{ChannelName: aws.String("test")}); err == nil {
+		t.Error("expected an error when deleting a running channel")
+	}
+	if _, err := c.StopChannel(context.TODO(), &mediatailor.StopChannelInput{ChannelName: aws.String("test")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.DeleteChannel(context.TODO(), &mediatailor.DeleteChannelInput{ChannelName: aws.String("test")}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.DescribeChannel(context.TODO(), &mediatailor.DescribeChannelInput{ChannelName: aws.String("test")})
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) || apiErr.ErrorCode() != "NotFoundException" {
+		t.Errorf("expected a NotFoundException, got %v", err)
+	}
+}
+
+func TestChannelValidation(t *testing.T) {
+	c := NewClient()
+	createVodSource(t, c, "sl", "slate")
+
+	tests := map[string]struct {
+		modify   func(*mediatailor.CreateChannelInput)
+		expected string
+	}{
+		"linear channel without filler slate": {
+			modify:   func(in *mediatailor.CreateChannelInput) { in.FillerSlate = nil },
+			expected: "filler slate",
+		},
+		"loop channel with filler slate": {
+			modify:   func(in *mediatailor.CreateChannelInput) { in.PlaybackMode = awsTypes.PlaybackModeLoop },
+			expected: "filler slate",
+		},
+		"missing filler slate vod source": {
+			modify:   func(in *mediatailor.CreateChannelInput) { in.FillerSlate.VodSourceName = aws.String("missing") },
+			expected: "missing",
+		},
+		"output without settings": {
+			modify:   func(in *mediatailor.CreateChannelInput) { in.Outputs[0].HlsPlaylistSettings = nil },
+			expected: "exactly one of",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			input := linearChannelInput("test")
+			tt.modify(input)
+			_, err := c.CreateChannel(context.TODO(), input)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			var badRequest *awsTypes.BadRequestException
+			if !errors.As(err, &badRequest) {
+				t.Errorf("expected a BadRequestException, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("expected the error to contain %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestChannelPolicy(t *testing.T) {
+	c := NewClient()
+	createVodSource(t, c, "sl", "slate")
+	if _, err := c.CreateChannel(context.TODO(), linearChannelInput("test")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.GetChannelPolicy(context.TODO(), &mediatailor.GetChannelPolicyInput{ChannelName: aws.String("test")}); err == nil || !strings.Contains(err.Error(), "NotFound") {
+		t.Errorf("expected a NotFound error for a channel without policy, got %v", err)
+	}
+	if _, err := c.PutChannelPolicy(context.TODO(), &mediatailor.PutChannelPolicyInput{ChannelName: aws.String("test"), Policy: aws.String("{")}); err == nil {
+		t.Error("expected an error for an invalid policy")
+	}
+
+	policy := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"mediatailor:GetManifest","Resource":"*"}]}`
+	if _, err := c.PutChannelPolicy(context.TODO(), &mediatailor.PutChannelPolicyInput{ChannelName: aws.String("test"), Policy: aws.String(policy)}); err != nil {
+		t.Fatal(err)
+	}
+	output, err := c.GetChannelPolicy(context.TODO(), &mediatailor.GetChannelPolicyInput{ChannelName: aws.String("test")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *output.Policy != policy {
+		t.Errorf("unexpected policy %s", *output.Policy)
+	}
+}
+
+func TestSourceLocationWithSources(t *testing.T) {
+	c := NewClient()
+	createVodSource(t, c, "sl", "vod")
+
+	if _, err := c.DeleteSourceLocation(context.TODO(), &mediatailor.DeleteSourceLocationInput{SourceLocationName: aws.String("sl")}); err == nil {
+		t.Error("expected an error when deleting a source location that still contains sources")
+	}
+	if _, err := c.DeleteVodSource(context.TODO(), &mediatailor.DeleteVodSourceInput{SourceLocationName: aws.String("sl"), VodSourceName: aws.String("vod")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.DeleteSourceLocation(context.TODO(), &mediatailor.DeleteSourceLocationInput{SourceLocationName: aws.String("sl")}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := c.CreateLiveSource(context.TODO(), &mediatailor.CreateLiveSourceInput{
+		SourceLocationName: aws.String("sl"),
+		LiveSourceName:     aws.String("live"),
+		HttpPackageConfigurations: []awsTypes.HttpPackageConfiguration{
+			{Path: aws.String("/"), SourceGroup: aws.String("default"), Type: awsTypes.TypeHls},
+		},
+	})
+	if err == nil || !strings.Contains(err.Error(), "NotFound") {
+		t.Errorf("expected a NotFound error when the source location does not exist, got %v", err)
+	}
+}
+
+func TestTags(t *testing.T) {
+	c := NewClient()
+	createVodSource(t, c, "sl", "slate")
+	created, err := c.CreateChannel(context.TODO(), linearChannelInput("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.TagResource(context.TODO(), &mediatailor.TagResourceInput{ResourceArn: created.Arn, Tags: map[string]string{"Team": "ott"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.UntagResource(context.TODO(), &mediatailor.UntagResourceInput{ResourceArn: created.Arn, TagKeys: []string{"Environment"}}); err != nil {
+		t.Fatal(err)
+	}
+	output, err := c.ListTagsForResource(context.TODO(), &mediatailor.ListTagsForResourceInput{ResourceArn: created.Arn})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(output.Tags) != 1 || output.Tags["Team"] != "ott" {
+		t.Errorf("unexpected tags %v", output.Tags)
+	}
+
+	described, err := c.DescribeChannel(context.TODO(), &mediatailor.DescribeChannelInput{ChannelName: aws.String("test")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if described.Tags["Team"] != "ott" {
+		t.Errorf("expected DescribeChannel to return the updated tags, got %v", described.Tags)
+	}
+
+	if _, err := c.TagResource(context.TODO(), &mediatailor.TagResourceInput{ResourceArn: aws.String("arn:aws:mediatailor:eu-central-1:123456789012:channel/missing"), Tags: map[string]string{"a": "b"}}); err == nil {
+		t.Error("expected an error when tagging an unknown resource")
+	}
+}
+
+func TestPagination(t *testing.T) {
+	c := NewClient()
+	for _, name := range []string{"a", "b", "c"} {
+		if _, err := c.PutPlaybackConfiguration(context.TODO(), &mediatailor.PutPlaybackConfigurationInput{Name: aws.String(name)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var names []string
+	paginator := mediatailor.NewListPlaybackConfigurationsPaginator(c, &mediatailor.ListPlaybackConfigurationsInput{MaxResults: aws.Int32(2)})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, item := range page.Items {
+			names = append(names, *item.Name)
+		}
+	}
+	if strings.Join(names, ",") != "a,b,c" {
+		t.Errorf("unexpected playback configurations %v", names)
+	}
+}
+
+func TestPlaybackConfigurationDefaults(t *testing.T) {
+	c := NewClient()
+	if _, err := c.PutPlaybackConfiguration(context.TODO(), &mediatailor.PutPlaybackConfigurationInput{Name: aws.String("test")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ConfigureLogsForPlaybackConfiguration(context.TODO(), &mediatailor.ConfigureLogsForPlaybackConfigurationInput{PlaybackConfigurationName: aws.String("test"), PercentEnabled: 101}); err == nil {
+		t.Error("expected an error for a log percentage above 100")
+	}
+	if _, err := c.ConfigureLogsForPlaybackConfiguration(context.TODO(), &mediatailor.ConfigureLogsForPlaybackConfigurationInput{PlaybackConfigurationName: aws.String("test"), PercentEnabled: 10}); err != nil {
+		t.Fatal(err)
+	}
+	// replacing the configuration keeps the log configuration, as in MediaTailor
+	if _, err := c.PutPlaybackConfiguration(context.TODO(), &mediatailor.PutPlaybackConfigurationInput{Name: aws.String("test")}); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := c.GetPlaybackConfiguration(context.TODO(), &mediatailor.GetPlaybackConfigurationInput{Name: aws.String("test")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if output.AvailSuppression.Mode != awsTypes.ModeOff {
+		t.Errorf("expected avail suppression to default to OFF, got %s", output.AvailSuppression.Mode)
+	}
+	if *output.DashConfiguration.MpdLocation != "EMT_DEFAULT" || output.DashConfiguration.OriginManifestType != awsTypes.OriginManifestTypeMultiPeriod {
+		t.Errorf("unexpected dash configuration %+v", output.DashConfiguration)
+	}
+	if output.LogConfiguration.PercentEnabled != 10 {
+		t.Errorf("expected the log configuration to be kept, got %d", output.LogConfiguration.PercentEnabled)
+	}
+}
+
+func TestPlaybackConfigurationInvalidURL(t *testing.T) {
+	c := NewClient()
+	for i := 0; i < 10; i++ {
+		_, err := c.PutPlaybackConfiguration(context.TODO(), &mediatailor.PutPlaybackConfigurationInput{
+			Name:                  aws.String("test"),
+			AdDecisionServerUrl:   aws.String("invalid"),
+			VideoContentSourceUrl: aws.String("invalid"),
+		})
+		if err == nil || !strings.Contains(err.Error(), "AdDecisionServerUrl requires a valid URL") {
+			t.Fatalf("expected an invalid URL error for the first invalid field, got %v", err)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
+	awsTypes "github.com/aws/aws-sdk-go-v2/service/mediatailor/types"
+	"maps"
+	"net/url"
+	"slices"
+)
+
+func (c *Client) endpoint(service, name string) *string {
+	return aws.String(fmt.Sprintf("https://%s.%s.mediatailor.%s.amazonaws.com/v1/%s/%s/", c.accountID, service, c.region, service, name))
+}
+
+func isURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func (c *Client) describePlaybackConfiguration(playbackConfiguration *mediatailor.GetPlaybackConfigurationOutput) *mediatailor.GetPlaybackConfigurationOutput {
+	output := *playbackConfiguration
+	output.Tags = c.tagsOf(output.PlaybackConfigurationArn)
+	if output.LogConfiguration != nil {
+		logConfiguration := *output.LogConfiguration
+		logConfiguration.EnabledLoggingStrategies = slices.Clone(logConfiguration.EnabledLoggingStrategies)
+		output.LogConfiguration = &logConfiguration
+	}
+	return &output
+}
+
+func (c *Client) PutPlaybackConfiguration(_ context.Context, params *mediatailor.PutPlaybackConfigurationInput, _ ...func(*mediatailor.Options)) (*mediatailor.PutPlaybackConfigurationOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if isEmpty(params.Name) {
+		return nil, badRequest("PutPlaybackConfiguration", "Name is required")
+	}
+	for _, u := range []struct {
+		field string
+		value *string
+	}{
+		{"AdDecisionServerUrl", params.AdDecisionServerUrl},
+		{"VideoContentSourceUrl", params.VideoContentSourceUrl},
+		{"SlateAdUrl", params.SlateAdUrl},
+	} {
+		if !isEmpty(u.value) && !isURL(*u.value) {
+			return nil, badRequest("PutPlaybackConfiguration", "%s requires a valid URL", u.field)
+		}
+	}
+	if params.PersonalizationThresholdSeconds != nil && *params.PersonalizationThresholdSeconds < 1 {
+		return nil, badRequest("PutPlaybackConfiguration", "PersonalizationThresholdSeconds must be at least 1")
+	}
+	name := *params.Name
+
+	dashConfiguration := &awsTypes.DashConfiguration{
+		ManifestEndpointPrefix: c.endpoint("dash", name),
+		MpdLocation:            aws.String("EMT_DEFAULT"),
+		OriginManifestType:     awsTypes.OriginManifestTypeMultiPeriod,
+	}
+	if params.DashConfiguration != nil {
+		if params.DashConfiguration.MpdLocation != nil {
+			dashConfiguration.MpdLocation = params.DashConfiguration.MpdLocation
+		}
+		if params.DashConfiguration.OriginManifestType != "" {
+			dashConfiguration.OriginManifestType = params.DashConfiguration.OriginManifestType
+		}
+	}
+
+	availSuppression := &awsTypes.AvailSuppression{Mode: awsTypes.ModeOff}
+	if params.AvailSuppression != nil {
+		availSuppression = params.AvailSuppression
+	}
+
+	insertionMode := params.InsertionMode
+	if insertionMode == "" {
+		insertionMode = awsTypes.InsertionModeStitchedOnly
+	}
+
+	logConfiguration := &awsTypes.LogConfiguration{PercentEnabled: 0}
+	arn := c.arn("playbackConfiguration/" + name)
+	if existing, ok := c.playbackConfigurations[name]; ok {
+		logConfiguration = existing.LogConfiguration
+	} else {
+		c.register(arn, nil)
+	}
+	maps.Copy(c.tags[*arn], params.Tags)
+
+	c.playbackConfigurations[name] = &mediatailor.GetPlaybackConfigurationOutput{
+		AdConditioningConfiguration:         params.AdConditioningConfiguration,
+		AdDecisionServerUrl:                 params.AdDecisionServerUrl,
+		AvailSuppression:                    availSuppression,
+		Bumper:                              params.Bumper,
+		CdnConfiguration:                    params.CdnConfiguration,
+		ConfigurationAliases:                params.ConfigurationAliases,
+		DashConfiguration:                   dashConfiguration,
+		HlsConfiguration:                    &awsTypes.HlsConfiguration{ManifestEndpointPrefix: c.endpoint("master", name)},
+		InsertionMode:                       insertionMode,
+		LivePreRollConfiguration:            params.LivePreRollConfiguration,
+		LogConfiguration:                    logConfiguration,
+		ManifestProcessingRules:             params.ManifestProcessingRules,
+		Name:                                aws.String(name),
+		PersonalizationThresholdSeconds:     params.PersonalizationThresholdSeconds,
+		PlaybackConfigurationArn:            arn,
+		PlaybackEndpointPrefix:              aws.String(fmt.Sprintf("https://%s.mediatailor.%s.amazonaws.com", c.accountID, c.region)),
+		SessionInitializationEndpointPrefix: c.endpoint("session", name),
+		SlateAdUrl:                          params.SlateAdUrl,
+		TranscodeProfileName:                params.TranscodeProfileName,
+		VideoContentSourceUrl:               params.VideoContentSourceUrl,
+	}
+
+	output := c.describePlaybackConfiguration(c.playbackConfigurations[name])
+	return &mediatailor.PutPlaybackConfigurationOutput{
+		AdConditioningConfiguration:         output.AdConditioningConfiguration,
+		AdDecisionServerUrl:                 output.AdDecisionServerUrl,
+		AvailSuppression:                    output.AvailSuppression,
+		Bumper:                              output.Bumper,
+		CdnConfiguration:                    output.CdnConfiguration,
+		ConfigurationAliases:                output.ConfigurationAliases,
+		DashConfiguration:                   output.DashConfiguration,
+		HlsConfiguration:                    output.HlsConfiguration,
+		InsertionMode:                       output.InsertionMode,
+		LivePreRollConfiguration:            output.LivePreRollConfiguration,
+		LogConfiguration:                    output.LogConfiguration,
+		ManifestProcessingRules:             output.ManifestProcessingRules,
+		Name:                                output.Name,
+		PersonalizationThresholdSeconds:     output.PersonalizationThresholdSeconds,
+		PlaybackConfigurationArn:            output.PlaybackConfigurationArn,
+		PlaybackEndpointPrefix:              output.PlaybackEndpointPrefix,
+		SessionInitializationEndpointPrefix: output.SessionInitializationEndpointPrefix,
+		SlateAdUrl:                          output.SlateAdUrl,
+		Tags:                                output.Tags,
+		TranscodeProfileName:                output.TranscodeProfileName,
+		VideoContentSourceUrl:               output.VideoContentSourceUrl,
+	}, nil
+}
+
+func (c *Client) playbackConfiguration(operation string, name *string) (*mediatailor.GetPlaybackConfigurationOutput, error) {
+	if isEmpty(name) {
+		return nil, badRequest(operation, "Name is required")
+	}
+	playbackConfiguration, ok := c.playbackConfigurations[*name]
+	if !ok {
+		return nil, notFound(operation, "playback configuration %s does not exist", *name)
+	}
+	return playbackConfiguration, nil
+}
+
+func (c *Client) GetPlaybackConfiguration(_ context.Context, params *mediatailor.GetPlaybackConfigurationInput, _ ...func(*mediatailor.Options)) (*mediatailor.GetPlaybackConfigurationOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	playbackConfiguration, err := c.playbackConfiguration("GetPlaybackConfiguration", params.Name)
+	if err != nil {
+		return nil, err
+	}
+	return c.describePlaybackConfiguration(playbackConfiguration), nil
+}
+
+func (c *Client) DeletePlaybackConfiguration(_ context.Context, params *mediatailor.DeletePlaybackConfigurationInput, _ ...func(*mediatailor.Options)) (*mediatailor.DeletePlaybackConfigurationOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	playbackConfiguration, err := c.playbackConfiguration("DeletePlaybackConfiguration", params.Name)
+	if err != nil {
+		return nil, err
+	}
+	delete(c.tags, *playbackConfiguration.PlaybackConfigurationArn)
+	delete(c.playbackConfigurations, *params.Name)
+	return &mediatailor.DeletePlaybackConfigurationOutput{}, nil
+}
+
+func (c *Client) ListPlaybackConfigurations(_ context.Context, params *mediatailor.ListPlaybackConfigurationsInput, _ ...func(*mediatailor.Options)) (*mediatailor.ListPlaybackConfigurationsOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	names, nextToken, err := page("ListPlaybackConfigurations", c.playbackConfigurations, params.MaxResults, params.NextToken)
+	if err != nil {
+		return nil, err
+	}
+	output := &mediatailor.ListPlaybackConfigurationsOutput{NextToken: nextToken}
+	for _, name := range names {
+		p := c.describePlaybackConfiguration(c.playbackConfigurations[name])
+		output.Items = append(output.Items, awsTypes.PlaybackConfiguration{
+			AdConditioningConfiguration:         p.AdConditioningConfiguration,
+			AdDecisionServerUrl:                 p.AdDecisionServerUrl,
+			AvailSuppression:                    p.AvailSuppression,
+			Bumper:                              p.Bumper,
+			CdnConfiguration:                    p.CdnConfiguration,
+			ConfigurationAliases:                p.ConfigurationAliases,
+			DashConfiguration:                   p.DashConfiguration,
+			HlsConfiguration:                    p.HlsConfiguration,
+			InsertionMode:                       p.InsertionMode,
+			LivePreRollConfiguration:            p.LivePreRollConfiguration,
+			LogConfiguration:                    p.LogConfiguration,
+			ManifestProcessingRules:             p.ManifestProcessingRules,
+			Name:                                p.Name,
+			PersonalizationThresholdSeconds:     p.PersonalizationThresholdSeconds,
+			PlaybackConfigurationArn:            p.PlaybackConfigurationArn,
+			PlaybackEndpointPrefix:              p.PlaybackEndpointPrefix,
+			SessionInitializationEndpointPrefix: p.SessionInitializationEndpointPrefix,
+			SlateAdUrl:                          p.SlateAdUrl,
+			Tags:                                p.Tags,
+			TranscodeProfileName:                p.TranscodeProfileName,
+			VideoContentSourceUrl:               p.VideoContentSourceUrl,
+		})
+	}
+	return output, nil
+}
+
+func (c *Client) ConfigureLogsForPlaybackConfiguration(_ context.Context, params *mediatailor.ConfigureLogsForPlaybackConfigurationInput, _ ...func(*mediatailor.Options)) (*mediatailor.ConfigureLogsForPlaybackConfigurationOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	playbackConfiguration, err := c.playbackConfiguration("ConfigureLogsForPlaybackConfiguration", params.PlaybackConfigurationName)
+	if err != nil {
+		return nil, err
+	}
+	if params.PercentEnabled < 0 || params.PercentEnabled > 100 {
+		return nil, badRequest("ConfigureLogsForPlaybackConfiguration", "PercentEnabled must be between 0 and 100")
+	}
+	for _, strategy := range params.EnabledLoggingStrategies {
+		if strategy != awsTypes.LoggingStrategyVendedLogs && strategy != awsTypes.LoggingStrategyLegacyCloudwatch {
+			return nil, badRequest("ConfigureLogsForPlaybackConfiguration", "invalid logging strategy %q", strategy)
+		}
+	}
+
+	playbackConfiguration.LogConfiguration = &awsTypes.LogConfiguration{
+		AdsInteractionLog:             params.AdsInteractionLog,
+		EnabledLoggingStrategies:      slices.Clone(params.EnabledLoggingStrategies),
+		ManifestServiceInteractionLog: params.ManifestServiceInteractionLog,
+		PercentEnabled:                params.PercentEnabled,
+	}
+	return &mediatailor.ConfigureLogsForPlaybackConfigurationOutput{
+		AdsInteractionLog:             params.AdsInteractionLog,
+		EnabledLoggingStrategies:      slices.Clone(params.EnabledLoggingStrategies),
+		ManifestServiceInteractionLog: params.ManifestServiceInteractionLog,
+		PercentEnabled:                params.PercentEnabled,
+		PlaybackConfigurationName:     params.PlaybackConfigurationName,
+	}, nil
+}
@@ -0,0 +1,440 @@
+package fake
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
+	awsTypes "github.com/aws/aws-sdk-go-v2/service/mediatailor/types"
+	"slices"
+)
+
+func validateAccessConfiguration(operation string, accessConfiguration *awsTypes.AccessConfiguration) error {
+	if accessConfiguration == nil {
+		return nil
+	}
+	switch accessConfiguration.AccessType {
+	case awsTypes.AccessTypeS3Sigv4, awsTypes.AccessTypeAutodetectSigv4:
+		return nil
+	case awsTypes.AccessTypeSecretsManagerAccessToken:
+		smatc := accessConfiguration.SecretsManagerAccessTokenConfiguration
+		if smatc == nil || isEmpty(smatc.HeaderName) || isEmpty(smatc.SecretArn) || isEmpty(smatc.SecretStringKey) {
+			return badRequest(operation, "SECRETS_MANAGER_ACCESS_TOKEN access requires a header name, a secret ARN and a secret string key")
+		}
+		return nil
+	default:
+		return badRequest(operation, "invalid access type %q", accessConfiguration.AccessType)
+	}
+}
+
+func (c *Client) describeSourceLocation(sl *sourceLocation) *mediatailor.DescribeSourceLocationOutput {
+	output := sl.output
+	output.Tags = c.tagsOf(output.Arn)
+	output.SegmentDeliveryConfigurations = slices.Clone(output.SegmentDeliveryConfigurations)
+	return &output
+}
+
+func (c *Client) sourceLocation(operation string, name *string) (*sourceLocation, error) {
+	if isEmpty(name) {
+		return nil, badRequest(operation, "SourceLocationName is required")
+	}
+	sl, ok := c.sourceLocations[*name]
+	if !ok {
+		return nil, notFound(operation, "The specified source-location doesn't exist: %s", *name)
+	}
+	return sl, nil
+}
+
+func (c *Client) CreateSourceLocation(_ context.Context, params *mediatailor.CreateSourceLocationInput, _ ...func(*mediatailor.Options)) (*mediatailor.CreateSourceLocationOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if isEmpty(params.SourceLocationName) {
+		return nil, badRequest("CreateSourceLocation", "SourceLocationName is required")
+	}
+	name := *params.SourceLocationName
+	if _, ok := c.sourceLocations[name]; ok {
+		return nil, badRequest("CreateSourceLocation", "source location %s already exists", name)
+	}
+	if params.HttpConfiguration == nil || isEmpty(params.HttpConfiguration.BaseUrl) {
+		return nil, badRequest("CreateSourceLocation", "HttpConfiguration base URL is required")
+	}
+	if err := validateAccessConfiguration("CreateSourceLocation", params.AccessConfiguration); err != nil {
+		return nil, err
+	}
+
+	timestamp := now()
+	sl := &sourceLocation{
+		output: mediatailor.DescribeSourceLocationOutput{
+			AccessConfiguration:                 params.AccessConfiguration,
+			Arn:                                 c.arn("sourceLocation/" + name),
+			CreationTime:                        timestamp,
+			DefaultSegmentDeliveryConfiguration: params.DefaultSegmentDeliveryConfiguration,
+			HttpConfiguration:                   params.HttpConfiguration,
+			LastModifiedTime:                    timestamp,
+			SegmentDeliveryConfigurations:       slices.Clone(params.SegmentDeliveryConfigurations),
+			SourceLocationName:                  aws.String(name),
+		},
+		vodSources:  map[string]*mediatailor.DescribeVodSourceOutput{},
+		liveSources: map[string]*mediatailor.DescribeLiveSourceOutput{},
+	}
+	c.sourceLocations[name] = sl
+	c.register(sl.output.Arn, params.Tags)
+
+	return (*mediatailor.CreateSourceLocationOutput)(c.describeSourceLocation(sl)), nil
+}
+
+func (c *Client) DescribeSourceLocation(_ context.Context, params *mediatailor.DescribeSourceLocationInput, _ ...func(*mediatailor.Options)) (*mediatailor.DescribeSourceLocationOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sl, err := c.sourceLocation("DescribeSourceLocation", params.SourceLocationName)
+	if err != nil {
+		return nil, err
+	}
+	return c.describeSourceLocation(sl), nil
+}
+
+func (c *Client) UpdateSourceLocation(_ context.Context, params *mediatailor.UpdateSourceLocationInput, _ ...func(*mediatailor.Options)) (*mediatailor.UpdateSourceLocationOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sl, err := c.sourceLocation("UpdateSourceLocation", params.SourceLocationName)
+	if err != nil {
+		return nil, err
+	}
+	if params.HttpConfiguration == nil || isEmpty(params.HttpConfiguration.BaseUrl) {
+		return nil, badRequest("UpdateSourceLocation", "HttpConfiguration base URL is required")
+	}
+	if err := validateAccessConfiguration("UpdateSourceLocation", params.AccessConfiguration); err != nil {
+		return nil, err
+	}
+
+	sl.output.AccessConfiguration = params.AccessConfiguration
+	sl.output.DefaultSegmentDeliveryConfiguration = params.DefaultSegmentDeliveryConfiguration
+	sl.output.HttpConfiguration = params.HttpConfiguration
+	sl.output.SegmentDeliveryConfigurations = slices.Clone(params.SegmentDeliveryConfigurations)
+	sl.output.LastModifiedTime = now()
+
+	return (*mediatailor.UpdateSourceLocationOutput)(c.describeSourceLocation(sl)), nil
+}
+
+func (c *Client) DeleteSourceLocation(_ context.Context, params *mediatailor.DeleteSourceLocationInput, _ ...func(*mediatailor.Options)) (*mediatailor.DeleteSourceLocationOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sl, err := c.sourceLocation("DeleteSourceLocation", params.SourceLocationName)
+	if err != nil {
+		return nil, err
+	}
+	if len(sl.vodSources) > 0 || len(sl.liveSources) > 0 {
+		return nil, badRequest("DeleteSourceLocation", "source location %s still contains vod or live sources", *params.SourceLocationName)
+	}
+	delete(c.tags, *sl.output.Arn)
+	delete(c.sourceLocations, *params.SourceLocationName)
+	return &mediatailor.DeleteSourceLocationOutput{}, nil
+}
+
+func (c *Client) ListSourceLocations(_ context.Context, params *mediatailor.ListSourceLocationsInput, _ ...func(*mediatailor.Options)) (*mediatailor.ListSourceLocationsOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	names, nextToken, err := page("ListSourceLocations", c.sourceLocations, params.MaxResults, params.NextToken)
+	if err != nil {
+		return nil, err
+	}
+	output := &mediatailor.ListSourceLocationsOutput{NextToken: nextToken}
+	for _, name := range names {
+		sl := c.describeSourceLocation(c.sourceLocations[name])
+		output.Items = append(output.Items, awsTypes.SourceLocation{
+			AccessConfiguration:                 sl.AccessConfiguration,
+			Arn:                                 sl.Arn,
+			CreationTime:                        sl.CreationTime,
+			DefaultSegmentDeliveryConfiguration: sl.DefaultSegmentDeliveryConfiguration,
+			HttpConfiguration:                   sl.HttpConfiguration,
+			LastModifiedTime:                    sl.LastModifiedTime,
+			SegmentDeliveryConfigurations:       sl.SegmentDeliveryConfigurations,
+			SourceLocationName:                  sl.SourceLocationName,
+			Tags:                                sl.Tags,
+		})
+	}
+	return output, nil
+}
+
+// vod sources
+
+func (c *Client) describeVodSource(vodSource *mediatailor.DescribeVodSourceOutput) *mediatailor.DescribeVodSourceOutput {
+	output := *vodSource
+	output.Tags = c.tagsOf(output.Arn)
+	output.HttpPackageConfigurations = slices.Clone(output.HttpPackageConfigurations)
+	output.AdBreakOpportunities = slices.Clone(output.AdBreakOpportunities)
+	return &output
+}
+
+func (c *Client) vodSource(operation string, sourceLocationName, name *string) (*sourceLocation, *mediatailor.DescribeVodSourceOutput, error) {
+	sl, err := c.sourceLocation(operation, sourceLocationName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if isEmpty(name) {
+		return nil, nil, badRequest(operation, "VodSourceName is required")
+	}
+	vodSource, ok := sl.vodSources[*name]
+	if !ok {
+		return nil, nil, notFound(operation, "vod source %s does not exist in source location %s", *name, *sourceLocationName)
+	}
+	return sl, vodSource, nil
+}
+
+func (c *Client) CreateVodSource(_ context.Context, params *mediatailor.CreateVodSourceInput, _ ...func(*mediatailor.Options)) (*mediatailor.CreateVodSourceOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sl, err := c.sourceLocation("CreateVodSource", params.SourceLocationName)
+	if err != nil {
+		return nil, err
+	}
+	if isEmpty(params.VodSourceName) {
+		return nil, badRequest("CreateVodSource", "VodSourceName is required")
+	}
+	name := *params.VodSourceName
+	if _, ok := sl.vodSources[name]; ok {
+		return nil, badRequest("CreateVodSource", "vod source %s already exists in source location %s", name, *params.SourceLocationName)
+	}
+	if err := validateHttpPackageConfigurations("CreateVodSource", params.HttpPackageConfigurations); err != nil {
+		return nil, err
+	}
+
+	timestamp := now()
+	vodSource := &mediatailor.DescribeVodSourceOutput{
+		Arn:                       c.arn("vodSource/" + *params.SourceLocationName + "/" + name),
+		CreationTime:              timestamp,
+		HttpPackageConfigurations: slices.Clone(params.HttpPackageConfigurations),
+		LastModifiedTime:          timestamp,
+		SourceLocationName:        aws.String(*params.SourceLocationName),
+		VodSourceName:             aws.String(name),
+	}
+	sl.vodSources[name] = vodSource
+	c.register(vodSource.Arn, params.Tags)
+
+	output := c.describeVodSource(vodSource)
+	return &mediatailor.CreateVodSourceOutput{
+		Arn:                       output.Arn,
+		CreationTime:              output.CreationTime,
+		HttpPackageConfigurations: output.HttpPackageConfigurations,
+		LastModifiedTime:          output.LastModifiedTime,
+		SourceLocationName:        output.SourceLocationName,
+		Tags:                      output.Tags,
+		VodSourceName:             output.VodSourceName,
+	}, nil
+}
+
+func (c *Client) DescribeVodSource(_ context.Context, params *mediatailor.DescribeVodSourceInput, _ ...func(*mediatailor.Options)) (*mediatailor.DescribeVodSourceOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, vodSource, err := c.vodSource("DescribeVodSource", params.SourceLocationName, params.VodSourceName)
+	if err != nil {
+		return nil, err
+	}
+	return c.describeVodSource(vodSource), nil
+}
+
+func (c *Client) UpdateVodSource(_ context.Context, params *mediatailor.UpdateVodSourceInput, _ ...func(*mediatailor.Options)) (*mediatailor.UpdateVodSourceOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, vodSource, err := c.vodSource("UpdateVodSource", params.SourceLocationName, params.VodSourceName)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateHttpPackageConfigurations("UpdateVodSource", params.HttpPackageConfigurations); err != nil {
+		return nil, err
+	}
+	vodSource.HttpPackageConfigurations = slices.Clone(params.HttpPackageConfigurations)
+	vodSource.LastModifiedTime = now()
+
+	output := c.describeVodSource(vodSource)
+	return &mediatailor.UpdateVodSourceOutput{
+		Arn:                       output.Arn,
+		CreationTime:              output.CreationTime,
+		HttpPackageConfigurations: output.HttpPackageConfigurations,
+		LastModifiedTime:          output.LastModifiedTime,
+		SourceLocationName:        output.SourceLocationName,
+		Tags:                      output.Tags,
+		VodSourceName:             output.VodSourceName,
+	}, nil
+}
+
+func (c *Client) DeleteVodSource(_ context.Context, params *mediatailor.DeleteVodSourceInput, _ ...func(*mediatailor.Options)) (*mediatailor.DeleteVodSourceOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sl, vodSource, err := c.vodSource("DeleteVodSource", params.SourceLocationName, params.VodSourceName)
+	if err != nil {
+		return nil, err
+	}
+	for _, ch := range c.channels {
+		slate := ch.output.FillerSlate
+		if slate != nil && *slate.SourceLocationName == *params.SourceLocationName && *slate.VodSourceName == *params.VodSourceName {
+			return nil, badRequest("DeleteVodSource", "vod source %s is used as filler slate by channel %s", *params.VodSourceName, *ch.output.ChannelName)
+		}
+	}
+	delete(c.tags, *vodSource.Arn)
+	delete(sl.vodSources, *params.VodSourceName)
+	return &mediatailor.DeleteVodSourceOutput{}, nil
+}
+
+func (c *Client) ListVodSources(_ context.Context, params *mediatailor.ListVodSourcesInput, _ ...func(*mediatailor.Options)) (*mediatailor.ListVodSourcesOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sl, err := c.sourceLocation("ListVodSources", params.SourceLocationName)
+	if err != nil {
+		return nil, err
+	}
+	names, nextToken, err := page("ListVodSources", sl.vodSources, params.MaxResults, params.NextToken)
+	if err != nil {
+		return nil, err
+	}
+	output := &mediatailor.ListVodSourcesOutput{NextToken: nextToken}
+	for _, name := range names {
+		vodSource := c.describeVodSource(sl.vodSources[name])
+		output.Items = append(output.Items, awsTypes.VodSource{
+			Arn:                       vodSource.Arn,
+			CreationTime:              vodSource.CreationTime,
+			HttpPackageConfigurations: vodSource.HttpPackageConfigurations,
+			LastModifiedTime:          vodSource.LastModifiedTime,
+			SourceLocationName:        vodSource.SourceLocationName,
+			Tags:                      vodSource.Tags,
+			VodSourceName:             vodSource.VodSourceName,
+		})
+	}
+	return output, nil
+}
+
+// live sources
+
+func (c *Client) describeLiveSource(liveSource *mediatailor.DescribeLiveSourceOutput) *mediatailor.DescribeLiveSourceOutput {
+	output := *liveSource
+	output.Tags = c.tagsOf(output.Arn)
+	output.HttpPackageConfigurations = slices.Clone(output.HttpPackageConfigurations)
+	return &output
+}
+
+func (c *Client) liveSource(operation string, sourceLocationName, name *string) (*sourceLocation, *mediatailor.DescribeLiveSourceOutput, error) {
+	sl, err := c.sourceLocation(operation, sourceLocationName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if isEmpty(name) {
+		return nil, nil, badRequest(operation, "LiveSourceName is required")
+	}
+	liveSource, ok := sl.liveSources[*name]
+	if !ok {
+		return nil, nil, notFound(operation, "live source %s does not exist in source location %s", *name, *sourceLocationName)
+	}
+	return sl, liveSource, nil
+}
+
+func (c *Client) CreateLiveSource(_ context.Context, params *mediatailor.CreateLiveSourceInput, _ ...func(*mediatailor.Options)) (*mediatailor.CreateLiveSourceOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sl, err := c.sourceLocation("CreateLiveSource", params.SourceLocationName)
+	if err != nil {
+		return nil, err
+	}
+	if isEmpty(params.LiveSourceName) {
+		return nil, badRequest("CreateLiveSource", "LiveSourceName is required")
+	}
+	name := *params.LiveSourceName
+	if _, ok := sl.liveSources[name]; ok {
+		return nil, badRequest("CreateLiveSource", "live source %s already exists in source location %s", name, *params.SourceLocationName)
+	}
+	if err := validateHttpPackageConfigurations("CreateLiveSource", params.HttpPackageConfigurations); err != nil {
+		return nil, err
+	}
+
+	timestamp := now()
+	liveSource := &mediatailor.DescribeLiveSourceOutput{
+		Arn:                       c.arn("liveSource/" + *params.SourceLocationName + "/" + name),
+		CreationTime:              timestamp,
+		HttpPackageConfigurations: slices.Clone(params.HttpPackageConfigurations),
+		LastModifiedTime:          timestamp,
+		LiveSourceName:            aws.String(name),
+		SourceLocationName:        aws.String(*params.SourceLocationName),
+	}
+	sl.liveSources[name] = liveSource
+	c.register(liveSource.Arn, params.Tags)
+
+	return (*mediatailor.CreateLiveSourceOutput)(c.describeLiveSource(liveSource)), nil
+}
+
+func (c *Client) DescribeLiveSource(_ context.Context, params *mediatailor.DescribeLiveSourceInput, _ ...func(*mediatailor.Options)) (*mediatailor.DescribeLiveSourceOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, liveSource, err := c.liveSource("DescribeLiveSource", params.SourceLocationName, params.LiveSourceName)
+	if err != nil {
+		return nil, err
+	}
+	return c.describeLiveSource(liveSource), nil
+}
+
+func (c *Client) UpdateLiveSource(_ context.Context, params *mediatailor.UpdateLiveSourceInput, _ ...func(*mediatailor.Options)) (*mediatailor.UpdateLiveSourceOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, liveSource, err := c.liveSource("UpdateLiveSource", params.SourceLocationName, params.LiveSourceName)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateHttpPackageConfigurations("UpdateLiveSource", params.HttpPackageConfigurations); err != nil {
+		return nil, err
+	}
+	liveSource.HttpPackageConfigurations = slices.Clone(params.HttpPackageConfigurations)
+	liveSource.LastModifiedTime = now()
+
+	return (*mediatailor.UpdateLiveSourceOutput)(c.describeLiveSource(liveSource)), nil
+}
+
+func (c *Client) DeleteLiveSource(_ context.Context, params *mediatailor.DeleteLiveSourceInput, _ ...func(*mediatailor.Options)) (*mediatailor.DeleteLiveSourceOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sl, liveSource, err := c.liveSource("DeleteLiveSource", params.SourceLocationName, params.LiveSourceName)
+	if err != nil {
+		return nil, err
+	}
+	delete(c.tags, *liveSource.Arn)
+	delete(sl.liveSources, *params.LiveSourceName)
+	return &mediatailor.DeleteLiveSourceOutput{}, nil
+}
+
+func (c *Client) ListLiveSources(_ context.Context, params *mediatailor.ListLiveSourcesInput, _ ...func(*mediatailor.Options)) (*mediatailor.ListLiveSourcesOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sl, err := c.sourceLocation("ListLiveSources", params.SourceLocationName)
+	if err != nil {
+		return nil, err
+	}
+	names, nextToken, err := page("ListLiveSources", sl.liveSources, params.MaxResults, params.NextToken)
+	if err != nil {
+		return nil, err
+	}
+	output := &mediatailor.ListLiveSourcesOutput{NextToken: nextToken}
+	for _, name := range names {
+		liveSource := c.describeLiveSource(sl.liveSources[name])
+		output.Items = append(output.Items, awsTypes.LiveSource{
+			Arn:                       liveSource.Arn,
+			CreationTime:              liveSource.CreationTime,
+			HttpPackageConfigurations: liveSource.HttpPackageConfigurations,
+			LastModifiedTime:          liveSource.LastModifiedTime,
+			LiveSourceName:            liveSource.LiveSourceName,
+			SourceLocationName:        liveSource.SourceLocationName,
+			Tags:                      liveSource.Tags,
+		})
+	}
+	return output, nil
+}
@@ -0,0 +1,74 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &analyzeManifestFunction{}
+
+func AnalyzeManifestFunction() function.Function {
+	return &analyzeManifestFunction{}
+}
+
+type analyzeManifestFunction struct{}
+
+type manifestAnalysisModel struct {
+	Format                 string   `tfsdk:"format"`
+	Variants               []string `tfsdk:"variants"`
+	SegmentDurationsMillis []int64  `tfsdk:"segment_durations_millis"`
+	TotalDurationMillis    int64    `tfsdk:"total_duration_millis"`
+	AdBreakOffsetsMillis   []int64  `tfsdk:"ad_break_offsets_millis"`
+}
+
+func (f *analyzeManifestFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "analyze_manifest"
+}
+
+func (f *analyzeManifestFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Finds the segments and the ad break opportunities of an HLS playlist or a DASH MPD.",
+		Description: "Parses an HLS multivariant or media playlist, or a DASH MPD, for example read with the `file` " +
+			"function, and returns the durations of its segments and the offsets of its SCTE-35 cue-out markers, in " +
+			"milliseconds. `variants` lists the media playlists of a multivariant playlist, which has no segments.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "manifest",
+				Description: "Content of the HLS playlist or of the DASH MPD.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"format":                   types.StringType,
+				"variants":                 types.ListType{ElemType: types.StringType},
+				"segment_durations_millis": types.ListType{ElemType: types.Int64Type},
+				"total_duration_millis":    types.Int64Type,
+				"ad_break_offsets_millis":  types.ListType{ElemType: types.Int64Type},
+			},
+		},
+	}
+}
+
+func (f *analyzeManifestFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var manifest string
+	resp.Error = req.Arguments.Get(ctx, &manifest)
+	if resp.Error != nil {
+		return
+	}
+
+	analysis, err := analyzeManifest(manifest)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, manifestAnalysisModel{
+		Format:                 analysis.format,
+		Variants:               analysis.variants,
+		SegmentDurationsMillis: analysis.segmentDurationsMillis,
+		TotalDurationMillis:    analysis.totalDurationMillis,
+		AdBreakOffsetsMillis:   analysis.adBreakOffsetsMillis,
+	}))
+}
@@ -0,0 +1,49 @@
+package awsmt
+
+import (
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+var manifestAnalysisType = tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+	"format":                   tftypes.String,
+	"variants":                 tftypes.List{ElementType: tftypes.String},
+	"segment_durations_millis": tftypes.List{ElementType: tftypes.Number},
+	"total_duration_millis":    tftypes.Number,
+	"ad_break_offsets_millis":  tftypes.List{ElementType: tftypes.Number},
+}}
+
+func TestAnalyzeManifestFunction(t *testing.T) {
+	result, funcErr := callFunction(t, "analyze_manifest", manifestAnalysisType, tftypes.NewValue(tftypes.String, testHlsMediaPlaylist))
+	if funcErr != nil {
+		t.Fatal(funcErr.Text)
+	}
+	var attributes map[string]tftypes.Value
+	_ = result.As(&attributes)
+
+	var format string
+	var totalDuration big.Float
+	var offsets, variants []tftypes.Value
+	_ = attributes["format"].As(&format)
+	_ = attributes["total_duration_millis"].As(&totalDuration)
+	_ = attributes["ad_break_offsets_millis"].As(&offsets)
+	_ = attributes["variants"].As(&variants)
+	if total, _ := totalDuration.Int64(); format != "HLS" || total != 24514 || len(offsets) != 2 {
+		t.Errorf("unexpected analysis %v", result)
+	}
+	if attributes["variants"].IsNull() || len(variants) != 0 {
+		t.Errorf("expected an empty list of variants, got %v", attributes["variants"])
+	}
+}
+
+func TestAnalyzeManifestFunctionValidation(t *testing.T) {
+	_, funcErr := callFunction(t, "analyze_manifest", manifestAnalysisType, tftypes.NewValue(tftypes.String, "<MPD></MPD>"))
+	if funcErr == nil || !strings.Contains(funcErr.Text, "the MPD has no period") {
+		t.Errorf("expected an error for an MPD without period, got %v", funcErr)
+	}
+	if funcErr != nil && (funcErr.FunctionArgument == nil || *funcErr.FunctionArgument != 0) {
+		t.Errorf("expected the error to point to the manifest argument, got %v", funcErr.FunctionArgument)
+	}
+}
@@ -0,0 +1,136 @@
+package awsmt
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+var (
+	_ function.Function = &hlsManifestUrlFunction{}
+	_ function.Function = &sessionInitializationUrlFunction{}
+)
+
+var manifestUrlParameters = []function.Parameter{
+	function.StringParameter{
+		Name:        "prefix",
+		Description: "Endpoint prefix of the playback configuration, for example its `hls_configuration.manifest_endpoint_prefix` or `session_initialization_endpoint_prefix`.",
+	},
+	function.StringParameter{
+		Name:        "asset",
+		Description: "Path of the asset relative to the content source of the playback configuration, for example `index.m3u8`.",
+	},
+}
+
+// joinManifestUrl appends the asset path to an endpoint prefix of a playback configuration, returning the argument
+// errors of invalid prefixes and assets.
+func joinManifestUrl(prefix, asset string) (string, *function.FuncError) {
+	u, err := url.Parse(prefix)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", function.NewArgumentFuncError(0, fmt.Sprintf("prefix must be an absolute http or https URL, got %q", prefix))
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", function.NewArgumentFuncError(0, fmt.Sprintf("prefix must not have a query or a fragment, got %q", prefix))
+	}
+
+	asset = strings.TrimPrefix(asset, "/")
+	if asset == "" {
+		return "", function.NewArgumentFuncError(1, "asset must not be empty")
+	}
+	if strings.ContainsAny(asset, "?#") {
+		return "", function.NewArgumentFuncError(1, fmt.Sprintf("asset must be a path without query or fragment, got %q", asset))
+	}
+	return strings.TrimSuffix(prefix, "/") + "/" + asset, nil
+}
+
+func HlsManifestUrlFunction() function.Function {
+	return &hlsManifestUrlFunction{}
+}
+
+type hlsManifestUrlFunction struct{}
+
+func (f *hlsManifestUrlFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "hls_manifest_url"
+}
+
+func (f *hlsManifestUrlFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Builds the URL of the HLS manifest of an asset.",
+		Description: "Builds the URL of the HLS multivariant playlist served by MediaTailor for an asset, from the HLS manifest endpoint prefix of a playback configuration.",
+		Parameters:  manifestUrlParameters,
+		Return:      function.StringReturn{},
+	}
+}
+
+func (f *hlsManifestUrlFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var prefix, asset string
+	resp.Error = req.Arguments.Get(ctx, &prefix, &asset)
+	if resp.Error != nil {
+		return
+	}
+
+	manifestUrl, funcErr := joinManifestUrl(prefix, asset)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, manifestUrl))
+}
+
+func SessionInitializationUrlFunction() function.Function {
+	return &sessionInitializationUrlFunction{}
+}
+
+type sessionInitializationUrlFunction struct{}
+
+func (f *sessionInitializationUrlFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "session_initialization_url"
+}
+
+func (f *sessionInitializationUrlFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Builds the session initialization URL of an asset.",
+		Description: "Builds the URL initializing a MediaTailor session for an asset, from the session initialization " +
+			"endpoint prefix of a playback configuration. The parameters are passed to the ad decision server as " +
+			"player parameters, with the `ads.` query prefix expected by MediaTailor.",
+		Parameters: append(slices.Clone(manifestUrlParameters), function.MapParameter{
+			Name:           "params",
+			Description:    "Player parameters of the session, without the `ads.` prefix.",
+			ElementType:    types.StringType,
+			AllowNullValue: true,
+		}),
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *sessionInitializationUrlFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var prefix, asset string
+	var params map[string]string
+	resp.Error = req.Arguments.Get(ctx, &prefix, &asset, &params)
+	if resp.Error != nil {
+		return
+	}
+
+	sessionUrl, funcErr := joinManifestUrl(prefix, asset)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	query := url.Values{}
+	for k, v := range params {
+		if k == "" {
+			resp.Error = function.NewArgumentFuncError(2, "parameter names must not be empty")
+			return
+		}
+		query.Set("ads."+strings.TrimPrefix(k, "ads."), v)
+	}
+	if len(query) > 0 {
+		sessionUrl += "?" + query.Encode()
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, sessionUrl))
+}
@@ -0,0 +1,78 @@
+package awsmt
+
+import (
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"strings"
+	"testing"
+)
+
+const testSessionPrefix = "https://123456789012.mediatailor.eu-central-1.amazonaws.com/v1/session/abc/test/"
+
+func TestHlsManifestUrlFunction(t *testing.T) {
+	for _, tc := range []struct {
+		prefix   string
+		asset    string
+		expected string
+	}{
+		{"https://example.com/v1/master/abc/test/", "index.m3u8", "https://example.com/v1/master/abc/test/index.m3u8"},
+		{"https://example.com/v1/master/abc/test", "/live/index.m3u8", "https://example.com/v1/master/abc/test/live/index.m3u8"},
+	} {
+		result, funcErr := callFunction(t, "hls_manifest_url", tftypes.String, stringValues(tc.prefix, tc.asset)...)
+		if funcErr != nil {
+			t.Fatalf("unexpected error: %s", funcErr.Text)
+		}
+		var manifestUrl string
+		_ = result.As(&manifestUrl)
+		if manifestUrl != tc.expected {
+			t.Errorf("expected %s, got %s", tc.expected, manifestUrl)
+		}
+	}
+}
+
+func TestSessionInitializationUrlFunction(t *testing.T) {
+	params := tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+		"deviceType": tftypes.NewValue(tftypes.String, "tv"),
+		"ads.user":   tftypes.NewValue(tftypes.String, "a b"),
+	})
+	result, funcErr := callFunction(t, "session_initialization_url", tftypes.String, tftypes.NewValue(tftypes.String, testSessionPrefix), tftypes.NewValue(tftypes.String, "index.m3u8"), params)
+	if funcErr != nil {
+		t.Fatalf("unexpected error: %s", funcErr.Text)
+	}
+	var sessionUrl string
+	_ = result.As(&sessionUrl)
+	if expected := testSessionPrefix + "index.m3u8?ads.deviceType=tv&ads.user=a+b"; sessionUrl != expected {
+		t.Errorf("expected %s, got %s", expected, sessionUrl)
+	}
+
+	result, funcErr = callFunction(t, "session_initialization_url", tftypes.String, tftypes.NewValue(tftypes.String, testSessionPrefix), tftypes.NewValue(tftypes.String, "index.mpd"), tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil))
+	if funcErr != nil {
+		t.Fatalf("unexpected error: %s", funcErr.Text)
+	}
+	_ = result.As(&sessionUrl)
+	if expected := testSessionPrefix + "index.mpd"; sessionUrl != expected {
+		t.Errorf("expected %s, got %s", expected, sessionUrl)
+	}
+}
+
+func TestManifestUrlFunctionsValidation(t *testing.T) {
+	for _, tc := range []struct {
+		prefix   string
+		asset    string
+		argument int64
+		expected string
+	}{
+		{"example.com/v1/master", "index.m3u8", 0, "absolute http or https URL"},
+		{"https://example.com/v1/master?a=b", "index.m3u8", 0, "must not have a query"},
+		{"https://example.com/v1/master/", "", 1, "must not be empty"},
+		{"https://example.com/v1/master/", "index.m3u8?a=b", 1, "without query or fragment"},
+	} {
+		_, funcErr := callFunction(t, "hls_manifest_url", tftypes.String, stringValues(tc.prefix, tc.asset)...)
+		if funcErr == nil || !strings.Contains(funcErr.Text, tc.expected) {
+			t.Errorf("expected an error containing %q for %s and %s, got %v", tc.expected, tc.prefix, tc.asset, funcErr)
+			continue
+		}
+		if funcErr.FunctionArgument == nil || *funcErr.FunctionArgument != tc.argument {
+			t.Errorf("expected the error to point to argument %d, got %v", tc.argument, funcErr.FunctionArgument)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &parseArnFunction{}
+
+func ParseArnFunction() function.Function {
+	return &parseArnFunction{}
+}
+
+type parseArnFunction struct{}
+
+type parsedArnModel struct {
+	Partition    types.String `tfsdk:"partition"`
+	Region       types.String `tfsdk:"region"`
+	AccountID    types.String `tfsdk:"account_id"`
+	ResourceType types.String `tfsdk:"resource_type"`
+	ParentName   types.String `tfsdk:"parent_name"`
+	Name         types.String `tfsdk:"name"`
+}
+
+func (f *parseArnFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_arn"
+}
+
+func (f *parseArnFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parses the ARN of a MediaTailor resource.",
+		Description: "Parses the ARN of a MediaTailor channel, source location, vod source, live source, playback " +
+			"configuration or program. `parent_name` is the name of the source location of vod and live sources, and the " +
+			"name of the channel of programs; it is null for the other resources.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "arn",
+				Description: "ARN of the MediaTailor resource.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"partition":     types.StringType,
+				"region":        types.StringType,
+				"account_id":    types.StringType,
+				"resource_type": types.StringType,
+				"parent_name":   types.StringType,
+				"name":          types.StringType,
+			},
+		},
+	}
+}
+
+func (f *parseArnFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var arn string
+	resp.Error = req.Arguments.Get(ctx, &arn)
+	if resp.Error != nil {
+		return
+	}
+
+	parsed, err := parseMediaTailorArn(arn)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	result := parsedArnModel{
+		Partition:    types.StringValue(parsed.Partition),
+		Region:       types.StringValue(parsed.Region),
+		AccountID:    types.StringValue(parsed.AccountID),
+		ResourceType: types.StringValue(parsed.ResourceType),
+		ParentName:   types.StringNull(),
+		Name:         types.StringValue(parsed.Names[len(parsed.Names)-1]),
+	}
+	if len(parsed.Names) > 1 {
+		result.ParentName = types.StringValue(parsed.Names[0])
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
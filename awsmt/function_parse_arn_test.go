@@ -0,0 +1,55 @@
+package awsmt
+
+import (
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"strings"
+	"testing"
+)
+
+var parsedArnType = tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+	"partition":     tftypes.String,
+	"region":        tftypes.String,
+	"account_id":    tftypes.String,
+	"resource_type": tftypes.String,
+	"parent_name":   tftypes.String,
+	"name":          tftypes.String,
+}}
+
+func TestParseArnFunction(t *testing.T) {
+	for _, tc := range []struct {
+		arn      string
+		expected map[string]string
+	}{
+		{"arn:aws:mediatailor:eu-central-1:123456789012:channel/test", map[string]string{
+			"partition": "aws", "region": "eu-central-1", "account_id": "123456789012", "resource_type": "channel", "name": "test",
+		}},
+		{"arn:aws:mediatailor:eu-central-1:123456789012:vodSource/location/vod", map[string]string{
+			"partition": "aws", "region": "eu-central-1", "account_id": "123456789012", "resource_type": "vodSource", "parent_name": "location", "name": "vod",
+		}},
+	} {
+		result, funcErr := callFunction(t, "parse_arn", parsedArnType, tftypes.NewValue(tftypes.String, tc.arn))
+		if funcErr != nil {
+			t.Fatalf("unexpected error for %s: %s", tc.arn, funcErr.Text)
+		}
+		var attributes map[string]tftypes.Value
+		_ = result.As(&attributes)
+		for name, value := range attributes {
+			var s *string
+			_ = value.As(&s)
+			expected, ok := tc.expected[name]
+			if (s == nil && ok) || (s != nil && *s != expected) {
+				t.Errorf("unexpected %s for %s: %v", name, tc.arn, value)
+			}
+		}
+	}
+}
+
+func TestParseArnFunctionValidation(t *testing.T) {
+	_, funcErr := callFunction(t, "parse_arn", parsedArnType, tftypes.NewValue(tftypes.String, "arn:aws:s3:::bucket"))
+	if funcErr == nil || !strings.Contains(funcErr.Text, "expected a mediatailor ARN") {
+		t.Errorf("expected an error for a non MediaTailor ARN, got %v", funcErr)
+	}
+	if funcErr != nil && (funcErr.FunctionArgument == nil || *funcErr.FunctionArgument != 0) {
+		t.Errorf("expected the error to point to the arn argument, got %v", funcErr.FunctionArgument)
+	}
+}
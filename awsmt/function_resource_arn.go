@@ -0,0 +1,109 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"regexp"
+)
+
+var _ function.Function = &resourceArnFunction{}
+
+// resourceArnFunction builds the ARN of a MediaTailor resource from its account, region and names, as the ARN of some
+// resources is not returned by the API, for example the channel ARN used in channel policies.
+type resourceArnFunction struct {
+	name         string
+	resourceType string
+	// nameParameters are the names of the parameters following the account and the region, in the order of the
+	// resource part of the ARN
+	nameParameters []string
+}
+
+func ChannelArnFunction() function.Function {
+	return &resourceArnFunction{name: "channel_arn", resourceType: arnResourceChannel, nameParameters: []string{"name"}}
+}
+
+func SourceLocationArnFunction() function.Function {
+	return &resourceArnFunction{name: "source_location_arn", resourceType: arnResourceSourceLocation, nameParameters: []string{"name"}}
+}
+
+func VodSourceArnFunction() function.Function {
+	return &resourceArnFunction{name: "vod_source_arn", resourceType: arnResourceVodSource, nameParameters: []string{"source_location_name", "name"}}
+}
+
+func LiveSourceArnFunction() function.Function {
+	return &resourceArnFunction{name: "live_source_arn", resourceType: arnResourceLiveSource, nameParameters: []string{"source_location_name", "name"}}
+}
+
+func PlaybackConfigurationArnFunction() function.Function {
+	return &resourceArnFunction{name: "playback_configuration_arn", resourceType: arnResourcePlaybackConfiguration, nameParameters: []string{"name"}}
+}
+
+var arnNameParameterDescriptions = map[string]string{
+	"name":                 "Name of the resource.",
+	"source_location_name": "Name of the source location of the resource.",
+}
+
+func (f *resourceArnFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = f.name
+}
+
+func (f *resourceArnFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	parameters := []function.Parameter{
+		function.StringParameter{
+			Name:        "account_id",
+			Description: "ID of the AWS account of the resource.",
+			Validators: []function.StringParameterValidator{
+				stringvalidator.RegexMatches(regexp.MustCompile(`^\d{12}$`), "must be a 12 digit AWS account ID"),
+			},
+		},
+		function.StringParameter{
+			Name:        "region",
+			Description: "Region of the resource, for example eu-central-1.",
+			Validators: []function.StringParameterValidator{
+				stringvalidator.RegexMatches(regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-\d+$`), "must be an AWS region code"),
+			},
+		},
+	}
+	for _, name := range f.nameParameters {
+		parameters = append(parameters, function.StringParameter{
+			Name:        name,
+			Description: arnNameParameterDescriptions[name],
+			Validators: []function.StringParameterValidator{
+				stringvalidator.LengthAtLeast(1),
+				stringvalidator.RegexMatches(regexp.MustCompile(`^[^/]+$`), "must not contain a slash"),
+			},
+		})
+	}
+
+	resp.Definition = function.Definition{
+		Summary:     "Builds the ARN of a MediaTailor " + f.resourceType + ".",
+		Description: "Builds the ARN of a MediaTailor " + f.resourceType + " from its account, region and name. The partition of the ARN is derived from the region.",
+		Parameters:  parameters,
+		Return:      function.StringReturn{},
+	}
+}
+
+func (f *resourceArnFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var accountID, region string
+	resp.Error = function.ConcatFuncErrors(
+		req.Arguments.GetArgument(ctx, 0, &accountID),
+		req.Arguments.GetArgument(ctx, 1, &region),
+	)
+	names := make([]string, len(f.nameParameters))
+	for i := range f.nameParameters {
+		resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.GetArgument(ctx, i+2, &names[i]))
+	}
+	if resp.Error != nil {
+		return
+	}
+
+	arn := mediaTailorArn{
+		Partition:    partitionForRegion(region),
+		Region:       region,
+		AccountID:    accountID,
+		ResourceType: f.resourceType,
+		Names:        names,
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, arn.String()))
+}
@@ -0,0 +1,60 @@
+package awsmt
+
+import (
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"strings"
+	"testing"
+)
+
+func stringValues(values ...string) []tftypes.Value {
+	var result []tftypes.Value
+	for _, value := range values {
+		result = append(result, tftypes.NewValue(tftypes.String, value))
+	}
+	return result
+}
+
+func TestResourceArnFunctions(t *testing.T) {
+	for _, tc := range []struct {
+		function  string
+		arguments []string
+		expected  string
+	}{
+		{"channel_arn", []string{"123456789012", "eu-central-1", "test"}, "arn:aws:mediatailor:eu-central-1:123456789012:channel/test"},
+		{"source_location_arn", []string{"123456789012", "eu-central-1", "test"}, "arn:aws:mediatailor:eu-central-1:123456789012:sourceLocation/test"},
+		{"vod_source_arn", []string{"123456789012", "eu-central-1", "location", "vod"}, "arn:aws:mediatailor:eu-central-1:123456789012:vodSource/location/vod"},
+		{"live_source_arn", []string{"123456789012", "us-east-1", "location", "live"}, "arn:aws:mediatailor:us-east-1:123456789012:liveSource/location/live"},
+		{"playback_configuration_arn", []string{"123456789012", "eu-central-1", "test"}, "arn:aws:mediatailor:eu-central-1:123456789012:playbackConfiguration/test"},
+		{"channel_arn", []string{"123456789012", "cn-north-1", "test"}, "arn:aws-cn:mediatailor:cn-north-1:123456789012:channel/test"},
+		{"channel_arn", []string{"123456789012", "us-gov-west-1", "test"}, "arn:aws-us-gov:mediatailor:us-gov-west-1:123456789012:channel/test"},
+	} {
+		result, funcErr := callFunction(t, tc.function, tftypes.String, stringValues(tc.arguments...)...)
+		if funcErr != nil {
+			t.Errorf("unexpected error for %s%v: %s", tc.function, tc.arguments, funcErr.Text)
+			continue
+		}
+		var arn string
+		_ = result.As(&arn)
+		if arn != tc.expected {
+			t.Errorf("expected %s, got %s", tc.expected, arn)
+		}
+	}
+}
+
+func TestResourceArnFunctionsValidation(t *testing.T) {
+	for _, tc := range []struct {
+		function  string
+		arguments []string
+		expected  string
+	}{
+		{"channel_arn", []string{"1234", "eu-central-1", "test"}, "12 digit AWS account ID"},
+		{"channel_arn", []string{"123456789012", "Frankfurt", "test"}, "AWS region code"},
+		{"channel_arn", []string{"123456789012", "eu-central-1", ""}, "length must be at least 1"},
+		{"vod_source_arn", []string{"123456789012", "eu-central-1", "location/other", "vod"}, "must not contain a slash"},
+	} {
+		_, funcErr := callFunction(t, tc.function, tftypes.String, stringValues(tc.arguments...)...)
+		if funcErr == nil || !strings.Contains(funcErr.Text, tc.expected) {
+			t.Errorf("expected an error containing %q for %s%v, got %v", tc.expected, tc.function, tc.arguments, funcErr)
+		}
+	}
+}
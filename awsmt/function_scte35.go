@@ -0,0 +1,250 @@
+package awsmt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"math/big"
+)
+
+var (
+	_ function.Function = &scte35DecodeFunction{}
+	_ function.Function = &scte35EncodeFunction{}
+)
+
+var scte35SpliceTimeAttributeTypes = map[string]attr.Type{
+	"pts_time": types.Int64Type,
+}
+
+var scte35AttributeTypes = map[string]attr.Type{
+	"sap_type":            types.Int64Type,
+	"protocol_version":    types.Int64Type,
+	"pts_adjustment":      types.Int64Type,
+	"cw_index":            types.Int64Type,
+	"tier":                types.Int64Type,
+	"splice_command_type": types.StringType,
+	"splice_insert": types.ObjectType{AttrTypes: map[string]attr.Type{
+		"splice_event_id":               types.Int64Type,
+		"splice_event_cancel_indicator": types.BoolType,
+		"out_of_network_indicator":      types.BoolType,
+		"splice_immediate_flag":         types.BoolType,
+		"pts_time":                      types.Int64Type,
+		"components": types.ListType{ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{
+			"component_tag": types.Int64Type,
+			"pts_time":      types.Int64Type,
+		}}},
+		"break_duration": types.ObjectType{AttrTypes: map[string]attr.Type{
+			"auto_return": types.BoolType,
+			"duration":    types.Int64Type,
+		}},
+		"unique_program_id": types.Int64Type,
+		"avail_num":         types.Int64Type,
+		"avails_expected":   types.Int64Type,
+	}},
+	"time_signal": types.ObjectType{AttrTypes: scte35SpliceTimeAttributeTypes},
+	"segmentation_descriptors": types.ListType{ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{
+		"segmentation_event_id":               types.Int64Type,
+		"segmentation_event_cancel_indicator": types.BoolType,
+		"delivery_restrictions": types.ObjectType{AttrTypes: map[string]attr.Type{
+			"web_delivery_allowed": types.BoolType,
+			"no_regional_blackout": types.BoolType,
+			"archive_allowed":      types.BoolType,
+			"device_restrictions":  types.Int64Type,
+		}},
+		"components": types.ListType{ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{
+			"component_tag": types.Int64Type,
+			"pts_offset":    types.Int64Type,
+		}}},
+		"segmentation_duration": types.Int64Type,
+		"upids": types.ListType{ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{
+			"type":  types.Int64Type,
+			"value": types.StringType,
+		}}},
+		"segmentation_type_id":  types.Int64Type,
+		"segment_num":           types.Int64Type,
+		"segments_expected":     types.Int64Type,
+		"sub_segment_num":       types.Int64Type,
+		"sub_segments_expected": types.Int64Type,
+	}}},
+	"descriptors": types.ListType{ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{
+		"tag":        types.Int64Type,
+		"identifier": types.StringType,
+		"data":       types.StringType,
+	}}},
+}
+
+func Scte35DecodeFunction() function.Function {
+	return &scte35DecodeFunction{}
+}
+
+type scte35DecodeFunction struct{}
+
+func (f *scte35DecodeFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "scte35_decode"
+}
+
+func (f *scte35DecodeFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Decodes a SCTE-35 message.",
+		Description: "Decodes a base64 SCTE-35 splice_info_section, or a hexadecimal one starting with `0x` as found in " +
+			"the HLS tags, after checking its CRC. Returns its splice command, its segmentation descriptors with their " +
+			"UPIDs, and its other descriptors. The PTS and the durations are in ticks of the 90kHz clock.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "message",
+				Description: "The SCTE-35 message, in base64 or in hexadecimal starting with `0x`.",
+			},
+		},
+		Return: function.ObjectReturn{AttributeTypes: scte35AttributeTypes},
+	}
+}
+
+func (f *scte35DecodeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var message string
+	resp.Error = req.Arguments.Get(ctx, &message)
+	if resp.Error != nil {
+		return
+	}
+
+	model, err := decodeScte35(message)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, model))
+}
+
+func Scte35EncodeFunction() function.Function {
+	return &scte35EncodeFunction{}
+}
+
+type scte35EncodeFunction struct{}
+
+func (f *scte35EncodeFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "scte35_encode"
+}
+
+func (f *scte35EncodeFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Encodes a SCTE-35 message.",
+		Description: "Encodes a SCTE-35 splice_info_section in base64, with its CRC, from an object with the attributes " +
+			"returned by `scte35_decode`. The attributes left out are null, so that only the attributes of the splice " +
+			"command and of the descriptors of the message have to be set.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:        "message",
+				Description: "The SCTE-35 message, as an object with the attributes returned by `scte35_decode`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *scte35EncodeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var message types.Dynamic
+	resp.Error = req.Arguments.Get(ctx, &message)
+	if resp.Error != nil {
+		return
+	}
+
+	model, err := scte35ModelFromDynamic(ctx, message)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	encoded, err := encodeScte35(model)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, encoded))
+}
+
+// @ADR
+// Context: An object parameter requires every attribute of its type, so that a time_signal message would have to set
+// the dozens of attributes of the splice_insert command and of the descriptors to null.
+// Decision: The encode function takes a dynamic parameter, converted through JSON to the model also returned by the
+// decode function, rejecting the unknown attributes.
+// Consequences: The messages are written with their relevant attributes only, and a decoded message can be encoded
+// again as is. The type errors are reported by the JSON decoder with the path of the attribute.
+func scte35ModelFromDynamic(ctx context.Context, message types.Dynamic) (scte35Model, error) {
+	var model scte35Model
+	if message.IsNull() || message.IsUnderlyingValueNull() {
+		return model, fmt.Errorf("the message must not be null")
+	}
+	value, err := message.UnderlyingValue().ToTerraformValue(ctx)
+	if err != nil {
+		return model, err
+	}
+	converted, err := jsonValue(value)
+	if err != nil {
+		return model, err
+	}
+	encoded, err := json.Marshal(converted)
+	if err != nil {
+		return model, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&model); err != nil {
+		return model, fmt.Errorf("invalid message: %w", err)
+	}
+	return model, nil
+}
+
+// jsonValue converts a Terraform value to the values of encoding/json.
+func jsonValue(value tftypes.Value) (any, error) {
+	if value.IsNull() {
+		return nil, nil
+	}
+	switch {
+	case value.Type().Is(tftypes.String):
+		var s string
+		err := value.As(&s)
+		return s, err
+	case value.Type().Is(tftypes.Bool):
+		var b bool
+		err := value.As(&b)
+		return b, err
+	case value.Type().Is(tftypes.Number):
+		var n big.Float
+		err := value.As(&n)
+		return json.Number(n.Text('f', -1)), err
+	case value.Type().Is(tftypes.Object{}), value.Type().Is(tftypes.Map{}):
+		var attributes map[string]tftypes.Value
+		if err := value.As(&attributes); err != nil {
+			return nil, err
+		}
+		converted := map[string]any{}
+		for name, attribute := range attributes {
+			v, err := jsonValue(attribute)
+			if err != nil {
+				return nil, err
+			}
+			converted[name] = v
+		}
+		return converted, nil
+	case value.Type().Is(tftypes.List{}), value.Type().Is(tftypes.Tuple{}), value.Type().Is(tftypes.Set{}):
+		var elements []tftypes.Value
+		if err := value.As(&elements); err != nil {
+			return nil, err
+		}
+		converted := []any{}
+		for _, element := range elements {
+			v, err := jsonValue(element)
+			if err != nil {
+				return nil, err
+			}
+			converted = append(converted, v)
+		}
+		return converted, nil
+	default:
+		return nil, fmt.Errorf("unsupported value of type %s", value.Type())
+	}
+}
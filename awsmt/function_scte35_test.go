@@ -0,0 +1,101 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestScte35DecodeFunction(t *testing.T) {
+	returnType := types.ObjectType{AttrTypes: scte35AttributeTypes}.TerraformType(context.Background())
+	result, funcErr := callFunction(t, "scte35_decode", returnType, tftypes.NewValue(tftypes.String, testScte35TimeSignal))
+	if funcErr != nil {
+		t.Fatal(funcErr.Text)
+	}
+	var attributes, timeSignal map[string]tftypes.Value
+	var commandType string
+	var ptsTime big.Float
+	_ = result.As(&attributes)
+	_ = attributes["splice_command_type"].As(&commandType)
+	_ = attributes["time_signal"].As(&timeSignal)
+	_ = timeSignal["pts_time"].As(&ptsTime)
+	if pts, _ := ptsTime.Int64(); commandType != "time_signal" || pts != 1924989008 {
+		t.Errorf("unexpected message %v", result)
+	}
+	if !attributes["splice_insert"].IsNull() {
+		t.Errorf("expected a null splice_insert, got %v", attributes["splice_insert"])
+	}
+
+	_, funcErr = callFunction(t, "scte35_decode", returnType, tftypes.NewValue(tftypes.String, "/DAvAAAAAAAA///wFAVIAACPf+/+c2nALv4AUsz1AAAAAAAKAAhDVUVJAAABNWLbowA="))
+	if funcErr == nil || !strings.Contains(funcErr.Text, "invalid CRC") || funcErr.FunctionArgument == nil || *funcErr.FunctionArgument != 0 {
+		t.Errorf("expected a CRC error on the message argument, got %v", funcErr)
+	}
+}
+
+// runScte35Encode runs the encode function with a dynamic argument, as Terraform passes an object literal.
+func runScte35Encode(t *testing.T, message attr.Value) (string, *function.FuncError) {
+	t.Helper()
+	req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.DynamicValue(message)})}
+	resp := &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	Scte35EncodeFunction().Run(context.Background(), req, resp)
+	if resp.Error != nil {
+		return "", resp.Error
+	}
+	return resp.Result.Value().(types.String).ValueString(), nil
+}
+
+func TestScte35EncodeFunction(t *testing.T) {
+	descriptor := types.ObjectValueMust(
+		map[string]attr.Type{
+			"segmentation_event_id": types.NumberType,
+			"segmentation_type_id":  types.NumberType,
+			"upids":                 types.TupleType{ElemTypes: []attr.Type{types.ObjectType{AttrTypes: map[string]attr.Type{"type": types.NumberType, "value": types.StringType}}}},
+		},
+		map[string]attr.Value{
+			"segmentation_event_id": types.NumberValue(big.NewFloat(1)),
+			"segmentation_type_id":  types.NumberValue(big.NewFloat(0x34)),
+			"upids": types.TupleValueMust(
+				[]attr.Type{types.ObjectType{AttrTypes: map[string]attr.Type{"type": types.NumberType, "value": types.StringType}}},
+				[]attr.Value{types.ObjectValueMust(
+					map[string]attr.Type{"type": types.NumberType, "value": types.StringType},
+					map[string]attr.Value{"type": types.NumberValue(big.NewFloat(0x03)), "value": types.StringValue("ABCD01234567")},
+				)},
+			),
+		},
+	)
+	timeSignal := types.ObjectValueMust(
+		map[string]attr.Type{"pts_time": types.NumberType},
+		map[string]attr.Value{"pts_time": types.NumberValue(big.NewFloat(900000))},
+	)
+	message := types.ObjectValueMust(
+		map[string]attr.Type{"time_signal": timeSignal.Type(context.Background()), "segmentation_descriptors": types.TupleType{ElemTypes: []attr.Type{descriptor.Type(context.Background())}}},
+		map[string]attr.Value{"time_signal": timeSignal, "segmentation_descriptors": types.TupleValueMust([]attr.Type{descriptor.Type(context.Background())}, []attr.Value{descriptor})},
+	)
+
+	encoded, funcErr := runScte35Encode(t, message)
+	if funcErr != nil {
+		t.Fatal(funcErr.Text)
+	}
+	decoded, err := decodeScte35(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *decoded.TimeSignal.PtsTime != 900000 || len(decoded.SegmentationDescriptors) != 1 || decoded.SegmentationDescriptors[0].Upids[0].Value != "ABCD01234567" {
+		t.Errorf("unexpected message %s", encoded)
+	}
+}
+
+func TestScte35EncodeFunctionValidation(t *testing.T) {
+	_, funcErr := runScte35Encode(t, types.ObjectValueMust(
+		map[string]attr.Type{"time_signals": types.StringType},
+		map[string]attr.Value{"time_signals": types.StringValue("")},
+	))
+	if funcErr == nil || !strings.Contains(funcErr.Text, `unknown field "time_signals"`) {
+		t.Errorf("expected an error for the unknown attribute, got %v", funcErr)
+	}
+}
@@ -0,0 +1,132 @@
+package awsmt
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strings"
+)
+
+// resource types used in the resource part of the MediaTailor ARNs
+const (
+	arnResourceChannel               = "channel"
+	arnResourceLiveSource            = "liveSource"
+	arnResourcePlaybackConfiguration = "playbackConfiguration"
+	arnResourceProgram               = "program"
+	arnResourceSourceLocation        = "sourceLocation"
+	arnResourceVodSource             = "vodSource"
+)
+
+// arnResourceNames is the number of names following the resource type in the ARN, for example
+// vodSource/<source location name>/<vod source name>.
+var arnResourceNames = map[string]int{
+	arnResourceChannel:               1,
+	arnResourceLiveSource:            2,
+	arnResourcePlaybackConfiguration: 1,
+	arnResourceProgram:               2,
+	arnResourceSourceLocation:        1,
+	arnResourceVodSource:             2,
+}
+
+type mediaTailorArn struct {
+	Partition    string
+	Region       string
+	AccountID    string
+	ResourceType string
+	// Names holds the names of the parent and of the resource itself, for example the source location name and the
+	// vod source name of a vod source.
+	Names []string
+}
+
+func parseMediaTailorArn(s string) (*mediaTailorArn, error) {
+	parsed, err := arn.Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ARN %q: %w", s, err)
+	}
+	if parsed.Service != "mediatailor" {
+		return nil, fmt.Errorf("invalid ARN %q: expected a mediatailor ARN, got a %s ARN", s, parsed.Service)
+	}
+
+	parts := strings.Split(parsed.Resource, "/")
+	count, ok := arnResourceNames[parts[0]]
+	if !ok {
+		return nil, fmt.Errorf("invalid ARN %q: unknown MediaTailor resource type %q", s, parts[0])
+	}
+	names := parts[1:]
+	if len(names) != count {
+		return nil, fmt.Errorf("invalid ARN %q: expected %d name(s) after %s/, got %d", s, count, parts[0], len(names))
+	}
+	for _, name := range names {
+		if name == "" {
+			return nil, fmt.Errorf("invalid ARN %q: empty name in the resource part", s)
+		}
+	}
+
+	return &mediaTailorArn{
+		Partition:    parsed.Partition,
+		Region:       parsed.Region,
+		AccountID:    parsed.AccountID,
+		ResourceType: parts[0],
+		Names:        names,
+	}, nil
+}
+
+func (a mediaTailorArn) String() string {
+	return arn.ARN{
+		Partition: a.Partition,
+		Service:   "mediatailor",
+		Region:    a.Region,
+		AccountID: a.AccountID,
+		Resource:  strings.Join(append([]string{a.ResourceType}, a.Names...), "/"),
+	}.String()
+}
+
+// partitionForRegion returns the partition of the ARNs of a region.
+func partitionForRegion(region string) string {
+	switch {
+	case strings.HasPrefix(region, "cn-"):
+		return "aws-cn"
+	case strings.HasPrefix(region, "us-gov-"):
+		return "aws-us-gov"
+	default:
+		return "aws"
+	}
+}
+
+// parseImportArn parses the ARN used as import identifier and checks that it belongs to the imported resource type.
+func parseImportArn(id string, resourceType string) (*mediaTailorArn, error) {
+	parsed, err := parseMediaTailorArn(id)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.ResourceType != resourceType {
+		return nil, fmt.Errorf("expected the ARN of a %s, got the ARN of a %s: %q", resourceType, parsed.ResourceType, id)
+	}
+	return parsed, nil
+}
+
+// importStateByName imports the resources identified by their name, either from the name, from the ARN or from the
+// resource identity. The ARN and the identity must be in the region of the provider.
+func importStateByName(ctx context.Context, resourceType string, region string, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" && req.Identity != nil {
+		importStateByNameIdentity(ctx, region, req, resp)
+		return
+	}
+	if !arn.IsARN(req.ID) {
+		resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+		return
+	}
+
+	parsed, err := parseImportArn(req.ID, resourceType)
+	if err == nil {
+		err = checkIdentityRegion(types.StringValue(parsed.Region), region)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), parsed.Names[0])...)
+}
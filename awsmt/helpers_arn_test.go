@@ -0,0 +1,105 @@
+package awsmt
+
+import (
+	"context"
+	"fmt"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseMediaTailorArn(t *testing.T) {
+	for _, tc := range []struct {
+		arn          string
+		partition    string
+		region       string
+		resourceType string
+		names        []string
+	}{
+		{"arn:aws:mediatailor:eu-central-1:123456789012:channel/test", "aws", "eu-central-1", arnResourceChannel, []string{"test"}},
+		{"arn:aws:mediatailor:eu-central-1:123456789012:sourceLocation/test", "aws", "eu-central-1", arnResourceSourceLocation, []string{"test"}},
+		{"arn:aws:mediatailor:eu-central-1:123456789012:vodSource/location/vod", "aws", "eu-central-1", arnResourceVodSource, []string{"location", "vod"}},
+		{"arn:aws:mediatailor:eu-central-1:123456789012:liveSource/location/live", "aws", "eu-central-1", arnResourceLiveSource, []string{"location", "live"}},
+		{"arn:aws:mediatailor:us-east-1:123456789012:playbackConfiguration/test", "aws", "us-east-1", arnResourcePlaybackConfiguration, []string{"test"}},
+		{"arn:aws:mediatailor:eu-central-1:123456789012:program/channel/program", "aws", "eu-central-1", arnResourceProgram, []string{"channel", "program"}},
+		{"arn:aws-cn:mediatailor:cn-north-1:123456789012:channel/test", "aws-cn", "cn-north-1", arnResourceChannel, []string{"test"}},
+	} {
+		parsed, err := parseMediaTailorArn(tc.arn)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %v", tc.arn, err)
+			continue
+		}
+		if parsed.Partition != tc.partition || parsed.Region != tc.region || parsed.AccountID != "123456789012" {
+			t.Errorf("unexpected partition, region or account for %s: %+v", tc.arn, parsed)
+		}
+		if parsed.ResourceType != tc.resourceType || !slices.Equal(parsed.Names, tc.names) {
+			t.Errorf("expected %s %v, got %s %v", tc.resourceType, tc.names, parsed.ResourceType, parsed.Names)
+		}
+	}
+}
+
+func TestParseMediaTailorArnErrors(t *testing.T) {
+	for _, tc := range []struct {
+		arn      string
+		expected string
+	}{
+		{"test", "invalid ARN"},
+		{"arn:aws:s3:::bucket/key", "expected a mediatailor ARN"},
+		{"arn:aws:mediatailor:eu-central-1:123456789012:prefetchSchedule/test", "unknown MediaTailor resource type"},
+		{"arn:aws:mediatailor:eu-central-1:123456789012:channel", "expected 1 name(s)"},
+		{"arn:aws:mediatailor:eu-central-1:123456789012:vodSource/location", "expected 2 name(s)"},
+		{"arn:aws:mediatailor:eu-central-1:123456789012:vodSource/location/", "empty name"},
+	} {
+		if _, err := parseMediaTailorArn(tc.arn); err == nil || !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("expected error containing %q for %s, got %v", tc.expected, tc.arn, err)
+		}
+	}
+}
+
+func TestParseImportArn(t *testing.T) {
+	if _, err := parseImportArn("arn:aws:mediatailor:eu-central-1:123456789012:vodSource/location/vod", arnResourceVodSource); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	_, err := parseImportArn("arn:aws:mediatailor:eu-central-1:123456789012:liveSource/location/live", arnResourceVodSource)
+	if err == nil || !strings.Contains(err.Error(), "expected the ARN of a vodSource, got the ARN of a liveSource") {
+		t.Errorf("expected a resource type mismatch, got %v", err)
+	}
+}
+
+func TestImportStateByArnChecksTheRegion(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		r   fwresource.ResourceWithImportState
+		arn string
+	}{
+		{&resourceChannel{region: "eu-central-1"}, "arn:aws:mediatailor:%s:123456789012:channel/test"},
+		{&resourceVodSource{region: "eu-central-1"}, "arn:aws:mediatailor:%s:123456789012:vodSource/location/vod"},
+	} {
+		var schema fwresource.SchemaResponse
+		tc.r.Schema(ctx, fwresource.SchemaRequest{}, &schema)
+		for region, expectError := range map[string]bool{"eu-central-1": false, "us-east-1": true} {
+			resp := fwresource.ImportStateResponse{State: tfsdk.State{Schema: schema.Schema, Raw: tftypes.NewValue(schema.Schema.Type().TerraformType(ctx), nil)}}
+			id := fmt.Sprintf(tc.arn, region)
+			tc.r.ImportState(ctx, fwresource.ImportStateRequest{ID: id}, &resp)
+			if resp.Diagnostics.HasError() != expectError {
+				t.Errorf("expected an error for %s only outside the region of the provider, got %v", id, resp.Diagnostics)
+			}
+		}
+	}
+}
+
+// importStateIdFromArn returns the ARN stored in the state, to import the resource by ARN.
+func importStateIdFromArn(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource %s not found in the state", resourceName)
+		}
+		return rs.Primary.Attributes["arn"], nil
+	}
+}
@@ -3,10 +3,13 @@ package awsmt
 import (
 	"context"
 	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
 	awsTypes "github.com/aws/aws-sdk-go-v2/service/mediatailor/types"
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"maps"
 	"reflect"
 	"slices"
 	"strings"
@@ -28,7 +31,7 @@ func getConfigureLogsForChannelInput(model models.ChannelModel) *mediatailor.Con
 	return &input
 }
 
-func getCreateChannelInput(model models.ChannelModel) *mediatailor.CreateChannelInput {
+func getCreateChannelInput(model models.ChannelResourceModel) *mediatailor.CreateChannelInput {
 	var input mediatailor.CreateChannelInput
 
 	input.ChannelName, input.FillerSlate, input.Outputs = getSharedChannelInput(&model)
@@ -44,8 +47,8 @@ func getCreateChannelInput(model models.ChannelModel) *mediatailor.CreateChannel
 		input.PlaybackMode = mode
 	}
 
-	if len(model.Tags) > 0 {
-		input.Tags = model.Tags
+	if tags := tagsAllToMap(model.TagsAll); len(tags) > 0 {
+		input.Tags = tags
 	}
 
 	if model.Tier != nil {
@@ -62,7 +65,7 @@ func getCreateChannelInput(model models.ChannelModel) *mediatailor.CreateChannel
 	return &input
 }
 
-func getUpdateChannelInput(model models.ChannelModel) *mediatailor.UpdateChannelInput {
+func getUpdateChannelInput(model models.ChannelResourceModel) *mediatailor.UpdateChannelInput {
 	var input mediatailor.UpdateChannelInput
 
 	input.ChannelName, input.FillerSlate, input.Outputs = getSharedChannelInput(&model)
@@ -70,8 +73,8 @@ func getUpdateChannelInput(model models.ChannelModel) *mediatailor.UpdateChannel
 	return &input
 }
 
-func getSharedChannelInput(model *models.ChannelModel) (name *string, source *awsTypes.SlateSource, outputItem []awsTypes.RequestOutputItem) {
-	return model.Name, buildSlateSource(model), buildRequestOutputs(model)
+func getSharedChannelInput(model *models.ChannelResourceModel) (name *string, source *awsTypes.SlateSource, outputItem []awsTypes.RequestOutputItem) {
+	return model.Name, buildSlateSource(&model.ChannelModel), buildRequestOutputs(model.Outputs)
 }
 
 func buildSlateSource(model *models.ChannelModel) *awsTypes.SlateSource {
@@ -88,11 +91,12 @@ func buildSlateSource(model *models.ChannelModel) *awsTypes.SlateSource {
 	return temp
 }
 
-func buildRequestOutputs(model *models.ChannelModel) []awsTypes.RequestOutputItem {
+func buildRequestOutputs(outputs map[string]models.ChannelOutputModel) []awsTypes.RequestOutputItem {
 	var temp []awsTypes.RequestOutputItem
 
-	for _, o := range model.Outputs {
-		output := awsTypes.RequestOutputItem{}
+	for _, manifestName := range slices.Sorted(maps.Keys(outputs)) {
+		o := outputs[manifestName]
+		output := awsTypes.RequestOutputItem{ManifestName: aws.String(manifestName)}
 
 		if o.DashPlaylistSettings != nil {
 			output.DashPlaylistSettings = buildDashPlaylistSettings(o.DashPlaylistSettings)
@@ -102,10 +106,6 @@ func buildRequestOutputs(model *models.ChannelModel) []awsTypes.RequestOutputIte
 			output.HlsPlaylistSettings = buildHLSPlaylistSettings(o.HlsPlaylistSettings)
 		}
 
-		if o.ManifestName != nil {
-			output.ManifestName = o.ManifestName
-		}
-
 		if o.SourceGroup != nil {
 			output.SourceGroup = o.SourceGroup
 		}
@@ -142,14 +142,10 @@ func buildHLSPlaylistSettings(settings *models.HlsPlaylistSettingsModel) *awsTyp
 	hlsSettings := &awsTypes.HlsPlaylistSettings{}
 
 	if len(settings.AdMarkupType) > 0 {
+		// the values are validated by the schema, so they are passed as they are instead of being mapped to a default
 		var adMarkupType []awsTypes.AdMarkupType
 		for _, a := range settings.AdMarkupType {
-			switch *a {
-			case "SCTE35_ENHANCED":
-				adMarkupType = append(adMarkupType, awsTypes.AdMarkupTypeScte35Enhanced)
-			default:
-				adMarkupType = append(adMarkupType, awsTypes.AdMarkupTypeDaterange)
-			}
+			adMarkupType = append(adMarkupType, awsTypes.AdMarkupType(*a))
 		}
 		hlsSettings.AdMarkupType = append(hlsSettings.AdMarkupType, adMarkupType...)
 	} else if settings.AdMarkupType == nil {
@@ -166,7 +162,7 @@ func buildHLSPlaylistSettings(settings *models.HlsPlaylistSettingsModel) *awsTyp
 
 // functions to manipulate a channel once it is created
 
-func createChannelPolicy(channelName *string, policy *string, client *mediatailor.Client) error {
+func createChannelPolicy(channelName *string, policy *string, client mediaTailorClient) error {
 	putChannelPolicyParams := mediatailor.PutChannelPolicyInput{
 		ChannelName: channelName,
 		Policy:      policy,
@@ -178,7 +174,7 @@ func createChannelPolicy(channelName *string, policy *string, client *mediatailo
 	return err
 }
 
-func stopChannel(state awsTypes.ChannelState, channelName *string, client *mediatailor.Client) error {
+func stopChannel(state awsTypes.ChannelState, channelName *string, client mediaTailorClient) error {
 	if state == awsTypes.ChannelStateRunning {
 		_, err := client.StopChannel(context.TODO(), &mediatailor.StopChannelInput{ChannelName: channelName})
 		if err != nil {
@@ -194,7 +190,7 @@ func shouldUpdateChannelLogging(currentLogs []awsTypes.LogType, plan models.Chan
 	return asRunLogsShouldBeEnabled != asRunLogsCurrentlyEnabled
 }
 
-func handlePolicyUpdate(context context.Context, client *mediatailor.Client, plan models.ChannelModel) error {
+func handlePolicyUpdate(context context.Context, client mediaTailorClient, plan models.ChannelModel) error {
 	var normalizedOldPolicy jsontypes.Normalized
 
 	oldPolicy, err := client.GetChannelPolicy(context, &mediatailor.GetChannelPolicyInput{ChannelName: plan.Name})
@@ -215,7 +211,7 @@ func handlePolicyUpdate(context context.Context, client *mediatailor.Client, pla
 	return nil
 }
 
-func updatePolicy(model *models.ChannelModel, channelName *string, oldPolicy jsontypes.Normalized, newPolicy jsontypes.Normalized, client *mediatailor.Client) (models.ChannelModel, error) {
+func updatePolicy(model *models.ChannelModel, channelName *string, oldPolicy jsontypes.Normalized, newPolicy jsontypes.Normalized, client mediaTailorClient) (models.ChannelModel, error) {
 	if !reflect.DeepEqual(oldPolicy, newPolicy) {
 		if !newPolicy.IsNull() {
 			model.Policy = newPolicy
@@ -249,11 +245,11 @@ func readChannelComputedValues(model models.ChannelModel, arn *string, channelNa
 	model.Name = channelName
 
 	if creationTime != nil {
-		model.CreationTime = types.StringValue(creationTime.String())
+		model.CreationTime = timetypes.NewRFC3339TimeValue(*creationTime)
 	}
 
 	if lastModifiedTime != nil {
-		model.LastModifiedTime = types.StringValue(lastModifiedTime.String())
+		model.LastModifiedTime = timetypes.NewRFC3339TimeValue(*lastModifiedTime)
 	}
 
 	return model
@@ -272,32 +268,50 @@ func readFillerSlate(plan models.ChannelModel, fillerSlate *awsTypes.SlateSource
 	return plan
 }
 
-func readOutputs(plan models.ChannelModel, responseOutputItems []awsTypes.ResponseOutputItem) models.ChannelModel {
+// readOutputs reads the outputs of a channel in the order returned by the API.
+func readOutputs(responseOutputItems []awsTypes.ResponseOutputItem) []models.OutputsModel {
+	var outputs []models.OutputsModel
+	for _, output := range responseOutputItems {
+		o := models.OutputsModel{}
+		if output.DashPlaylistSettings != nil {
+			o.DashPlaylistSettings = readDashPlaylistConfigurationsToPlan(&output)
+		}
+		if output.HlsPlaylistSettings != nil {
+			o.HlsPlaylistSettings = readHlsPlaylistConfigurationsToPlanDS(&output)
+		}
+		o.ManifestName, o.PlaybackUrl, o.SourceGroup = readRMPS(output.ManifestName, output.PlaybackUrl, output.SourceGroup)
+		outputs = append(outputs, o)
+	}
+	return outputs
+}
 
+// readOutputsByManifestName reads the outputs of a channel keyed by manifest name, matching each output returned by
+// the API with the output of the plan or state having the same manifest name.
+func readOutputsByManifestName(prior map[string]models.ChannelOutputModel, responseOutputItems []awsTypes.ResponseOutputItem) map[string]models.ChannelOutputModel {
 	if responseOutputItems == nil {
-		return plan
+		return prior
 	}
 
-	var tempOutputs []models.OutputsModel
-	for i, output := range responseOutputItems {
-		outputs := models.OutputsModel{}
+	outputs := map[string]models.ChannelOutputModel{}
+	for _, output := range responseOutputItems {
+		if output.ManifestName == nil {
+			continue
+		}
+		o := models.ChannelOutputModel{}
 		if output.DashPlaylistSettings != nil {
-			outputs.DashPlaylistSettings = readDashPlaylistConfigurationsToPlan(&output)
+			o.DashPlaylistSettings = readDashPlaylistConfigurationsToPlan(&output)
 		}
 		if output.HlsPlaylistSettings != nil {
-			if len(plan.Outputs) > 0 && i <= len(plan.Outputs) {
-				outputs.HlsPlaylistSettings = readHlsPlaylistConfigurationsToPlan(&output, plan.Outputs[i])
+			if priorOutput, ok := prior[*output.ManifestName]; ok && priorOutput.HlsPlaylistSettings != nil {
+				o.HlsPlaylistSettings = readHlsPlaylistConfigurationsToPlan(&output, priorOutput.HlsPlaylistSettings)
 			} else {
-				outputs.HlsPlaylistSettings = readHlsPlaylistConfigurationsToPlanDS(&output)
+				o.HlsPlaylistSettings = readHlsPlaylistConfigurationsToPlanDS(&output)
 			}
-
 		}
-		outputs.ManifestName, outputs.PlaybackUrl, outputs.SourceGroup = readRMPS(output.ManifestName, output.PlaybackUrl, output.SourceGroup)
-		tempOutputs = append(tempOutputs, outputs)
+		_, o.PlaybackUrl, o.SourceGroup = readRMPS(output.ManifestName, output.PlaybackUrl, output.SourceGroup)
+		outputs[*output.ManifestName] = o
 	}
-	plan.Outputs = tempOutputs
-
-	return plan
+	return outputs
 }
 
 func readLogConfiguration(plan models.ChannelModel, logConfiguration *awsTypes.LogConfigurationForChannel) models.ChannelModel {
@@ -348,9 +362,9 @@ func readDashPlaylistConfigurationsToPlan(output *awsTypes.ResponseOutputItem) *
 	return outputs
 }
 
-func readHlsPlaylistConfigurationsToPlan(output *awsTypes.ResponseOutputItem, stateOutput models.OutputsModel) *models.HlsPlaylistSettingsModel {
+func readHlsPlaylistConfigurationsToPlan(output *awsTypes.ResponseOutputItem, stateSettings *models.HlsPlaylistSettingsModel) *models.HlsPlaylistSettingsModel {
 	outputs := &models.HlsPlaylistSettingsModel{}
-	if stateOutput.HlsPlaylistSettings.AdMarkupType != nil && output.HlsPlaylistSettings.AdMarkupType != nil && len(output.HlsPlaylistSettings.AdMarkupType) > 0 {
+	if stateSettings.AdMarkupType != nil && output.HlsPlaylistSettings.AdMarkupType != nil && len(output.HlsPlaylistSettings.AdMarkupType) > 0 {
 		var adMarkupTypes []*string
 		for _, a := range output.HlsPlaylistSettings.AdMarkupType {
 			adMarkupType := string(a)
@@ -358,7 +372,7 @@ func readHlsPlaylistConfigurationsToPlan(output *awsTypes.ResponseOutputItem, st
 		}
 		outputs.AdMarkupType = append(outputs.AdMarkupType, adMarkupTypes...)
 	}
-	if stateOutput.HlsPlaylistSettings.ManifestWindowSeconds != nil && output.HlsPlaylistSettings.ManifestWindowSeconds != nil {
+	if stateSettings.ManifestWindowSeconds != nil && output.HlsPlaylistSettings.ManifestWindowSeconds != nil {
 		manifestWindowSeconds := int64(*output.HlsPlaylistSettings.ManifestWindowSeconds)
 		outputs.ManifestWindowSeconds = &manifestWindowSeconds
 	}
@@ -390,6 +404,7 @@ func readOptionalValues(plan models.ChannelModel, playbackMode *string, tags map
 	if len(tags) > 0 {
 		plan.Tags = tags
 	}
+	plan.TagsAll = tagsAllValue(tags)
 
 	if tier != nil {
 		plan.Tier = tier
@@ -403,8 +418,6 @@ func writeChannelToPlan(model models.ChannelModel, channel mediatailor.CreateCha
 
 	model = readFillerSlate(model, channel.FillerSlate)
 
-	model = readOutputs(model, channel.Outputs)
-
 	model = readOptionalValues(model, channel.PlaybackMode, channel.Tags, channel.Tier)
 
 	return model
@@ -416,8 +429,6 @@ func writeChannelToState(model models.ChannelModel, channel mediatailor.Describe
 
 	model = readFillerSlate(model, channel.FillerSlate)
 
-	model = readOutputs(model, channel.Outputs)
-
 	model = readOptionalValues(model, channel.PlaybackMode, channel.Tags, channel.Tier)
 
 	model = readLogConfiguration(model, channel.LogConfiguration)
@@ -425,6 +436,40 @@ func writeChannelToState(model models.ChannelModel, channel mediatailor.Describe
 	return model
 }
 
+// outputManifestTypes returns the types of the manifests of a channel output, which are the types of the package
+// configurations its source group must match on the sources of the channel.
+func outputManifestTypes(output models.ChannelOutputModel) []awsTypes.Type {
+	var manifestTypes []awsTypes.Type
+	if output.HlsPlaylistSettings != nil {
+		manifestTypes = append(manifestTypes, awsTypes.TypeHls)
+	}
+	if output.DashPlaylistSettings != nil {
+		manifestTypes = append(manifestTypes, awsTypes.TypeDash)
+	}
+	return manifestTypes
+}
+
+// unmatchedOutputs returns, for the manifest name of each output of a channel that the package configurations of a
+// source cannot serve, the manifest type missing from the source group of the output.
+func unmatchedOutputs(outputs map[string]models.ChannelOutputModel, configurations []awsTypes.HttpPackageConfiguration) map[string]awsTypes.Type {
+	unmatched := map[string]awsTypes.Type{}
+	for manifestName, output := range outputs {
+		if output.SourceGroup == nil {
+			continue
+		}
+		for _, manifestType := range outputManifestTypes(output) {
+			matches := slices.ContainsFunc(configurations, func(c awsTypes.HttpPackageConfiguration) bool {
+				return c.SourceGroup != nil && *c.SourceGroup == *output.SourceGroup && c.Type == manifestType
+			})
+			if !matches {
+				unmatched[manifestName] = manifestType
+				break
+			}
+		}
+	}
+	return unmatched
+}
+
 // helper functions to simplify update function logic
 func shouldStartChannel(previousState awsTypes.ChannelState, newState *string) bool {
 	wasRunning := previousState == awsTypes.ChannelStateRunning
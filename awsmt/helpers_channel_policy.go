@@ -0,0 +1,182 @@
+package awsmt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"net"
+	"reflect"
+	"slices"
+	"strings"
+	"terraform-provider-mediatailor/awsmt/models"
+)
+
+const getManifestAction = "mediatailor:GetManifest"
+
+type channelPolicyDocument struct {
+	Version   string                   `json:"Version"`
+	Statement []channelPolicyStatement `json:"Statement"`
+}
+
+type channelPolicyStatement struct {
+	Sid       string                         `json:"Sid"`
+	Effect    string                         `json:"Effect"`
+	Principal any                            `json:"Principal"`
+	Action    string                         `json:"Action"`
+	Resource  string                         `json:"Resource"`
+	Condition map[string]map[string][]string `json:"Condition,omitempty"`
+}
+
+// renderAccessPolicy renders the access policy of a channel to the JSON policy document of the channel with the given
+// ARN, returning an error if the access policy does not grant access to anyone or holds invalid values.
+func renderAccessPolicy(accessPolicy *models.AccessPolicyModel, channelArn string) (string, error) {
+	conditions, err := accessPolicyConditions(accessPolicy)
+	if err != nil {
+		return "", err
+	}
+
+	document := channelPolicyDocument{Version: "2012-10-17"}
+	if accessPolicy.AllowAnonymousGetManifest.ValueBool() {
+		document.Statement = append(document.Statement, channelPolicyStatement{
+			Sid:       "AllowAnonymousGetManifest",
+			Principal: "*",
+		})
+	}
+	if len(accessPolicy.AllowedPrincipals) > 0 {
+		principals := slices.Sorted(slices.Values(accessPolicy.AllowedPrincipals))
+		document.Statement = append(document.Statement, channelPolicyStatement{
+			Sid:       "AllowPrincipalsGetManifest",
+			Principal: map[string][]string{"AWS": principals},
+		})
+	}
+	if len(document.Statement) == 0 {
+		return "", errors.New("access_policy must allow anonymous access or at least one principal")
+	}
+
+	for i := range document.Statement {
+		document.Statement[i].Effect = "Allow"
+		document.Statement[i].Action = getManifestAction
+		document.Statement[i].Resource = channelArn
+		document.Statement[i].Condition = conditions
+	}
+
+	policy, err := json.Marshal(document)
+	if err != nil {
+		return "", err
+	}
+	return string(policy), nil
+}
+
+// accessPolicyConditions returns the conditions of the statements of an access policy, grouping the values of the
+// conditions testing the same variable with the same operator.
+func accessPolicyConditions(accessPolicy *models.AccessPolicyModel) (map[string]map[string][]string, error) {
+	conditions := map[string]map[string][]string{}
+	addCondition := func(test, variable string, values []string) {
+		if conditions[test] == nil {
+			conditions[test] = map[string][]string{}
+		}
+		conditions[test][variable] = append(conditions[test][variable], values...)
+		slices.Sort(conditions[test][variable])
+		conditions[test][variable] = slices.Compact(conditions[test][variable])
+	}
+
+	for _, cidr := range accessPolicy.SourceIpCidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return nil, fmt.Errorf("source_ip_cidrs must only hold CIDR blocks, got %q", cidr)
+		}
+	}
+	if len(accessPolicy.SourceIpCidrs) > 0 {
+		addCondition("IpAddress", "aws:SourceIp", accessPolicy.SourceIpCidrs)
+	}
+
+	for _, condition := range accessPolicy.Conditions {
+		if condition.Test == nil || condition.Variable == nil || len(condition.Values) == 0 {
+			return nil, errors.New("conditions must have a test, a variable and at least one value")
+		}
+		addCondition(*condition.Test, *condition.Variable, condition.Values)
+	}
+
+	if len(conditions) == 0 {
+		return nil, nil
+	}
+	return conditions, nil
+}
+
+// @ADR
+// Context: MediaTailor stores channel policies as they are put, but IAM policies can express the same permissions in
+// different ways, for example with a single string instead of a list with one element, or with the statements in
+// another order.
+// Decision: The access policy of a channel is compared to the remote policy after both are brought to a canonical
+// form, in which lists with one element are replaced by the element and the elements of lists are sorted.
+// Consequences: Changes to the policy made outside Terraform are only reported when they change the permissions, but
+// the rare policies in which the order of the elements matters cannot be managed with access_policy.
+func policiesEquivalent(a, b string) bool {
+	var documentA, documentB any
+	if err := json.Unmarshal([]byte(a), &documentA); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(b), &documentB); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(canonicalPolicyValue(documentA), canonicalPolicyValue(documentB))
+}
+
+func canonicalPolicyValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		canonical := make(map[string]any, len(v))
+		for key, element := range v {
+			canonical[key] = canonicalPolicyValue(element)
+		}
+		return canonical
+	case []any:
+		if len(v) == 1 {
+			return canonicalPolicyValue(v[0])
+		}
+		type element struct {
+			value any
+			key   string
+		}
+		elements := make([]element, 0, len(v))
+		for _, e := range v {
+			c := canonicalPolicyValue(e)
+			key, _ := json.Marshal(c)
+			elements = append(elements, element{value: c, key: string(key)})
+		}
+		slices.SortFunc(elements, func(a, b element) int { return strings.Compare(a.key, b.key) })
+		canonical := make([]any, 0, len(elements))
+		for _, e := range elements {
+			canonical = append(canonical, e.value)
+		}
+		return canonical
+	default:
+		return v
+	}
+}
+
+// desiredChannelPolicy returns the policy the channel with the given ARN must have, which is rendered from the access
+// policy when it is configured and the raw policy otherwise.
+func desiredChannelPolicy(model models.ChannelResourceModel, channelArn string) (jsontypes.Normalized, error) {
+	if model.AccessPolicy == nil {
+		return model.Policy, nil
+	}
+	policy, err := renderAccessPolicy(model.AccessPolicy, channelArn)
+	if err != nil {
+		return jsontypes.NewNormalizedNull(), err
+	}
+	return jsontypes.NewNormalizedValue(policy), nil
+}
+
+// readAccessPolicy returns the access policy of the state if the remote policy of the channel is equivalent to it, and
+// nil otherwise so that Terraform plans to put the access policy again.
+func readAccessPolicy(accessPolicy *models.AccessPolicyModel, remotePolicy jsontypes.Normalized, channelArn string) *models.AccessPolicyModel {
+	if accessPolicy == nil || remotePolicy.IsNull() {
+		return nil
+	}
+	rendered, err := renderAccessPolicy(accessPolicy, channelArn)
+	if err != nil || !policiesEquivalent(rendered, remotePolicy.ValueString()) {
+		return nil
+	}
+	return accessPolicy
+}
@@ -0,0 +1,105 @@
+package awsmt
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strings"
+	"terraform-provider-mediatailor/awsmt/models"
+	"testing"
+)
+
+const testChannelArn = "arn:aws:mediatailor:eu-central-1:123456789012:channel/test"
+
+func TestRenderAccessPolicy(t *testing.T) {
+	policy, err := renderAccessPolicy(&models.AccessPolicyModel{
+		AllowAnonymousGetManifest: types.BoolValue(true),
+		AllowedPrincipals:         []string{"arn:aws:iam::123456789012:role/b", "arn:aws:iam::123456789012:role/a"},
+		SourceIpCidrs:             []string{"10.0.0.0/8"},
+		Conditions: []models.AccessPolicyConditionModel{
+			{Test: aws.String("IpAddress"), Variable: aws.String("aws:SourceIp"), Values: []string{"192.168.0.0/16"}},
+			{Test: aws.String("StringEquals"), Variable: aws.String("aws:UserAgent"), Values: []string{"player"}},
+		},
+	}, testChannelArn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	condition := `"Condition":{"IpAddress":{"aws:SourceIp":["10.0.0.0/8","192.168.0.0/16"]},"StringEquals":{"aws:UserAgent":["player"]}}`
+	expected := `{"Version":"2012-10-17","Statement":[` +
+		`{"Sid":"AllowAnonymousGetManifest","Effect":"Allow","Principal":"*","Action":"mediatailor:GetManifest","Resource":"` + testChannelArn + `",` + condition + `},` +
+		`{"Sid":"AllowPrincipalsGetManifest","Effect":"Allow","Principal":{"AWS":["arn:aws:iam::123456789012:role/a","arn:aws:iam::123456789012:role/b"]},"Action":"mediatailor:GetManifest","Resource":"` + testChannelArn + `",` + condition + `}]}`
+	if policy != expected {
+		t.Errorf("expected %s, got %s", expected, policy)
+	}
+}
+
+func TestRenderAccessPolicyErrors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		accessPolicy models.AccessPolicyModel
+		err          string
+	}{
+		"no statement": {
+			accessPolicy: models.AccessPolicyModel{AllowAnonymousGetManifest: types.BoolValue(false)},
+			err:          "must allow anonymous access or at least one principal",
+		},
+		"invalid cidr": {
+			accessPolicy: models.AccessPolicyModel{AllowAnonymousGetManifest: types.BoolValue(true), SourceIpCidrs: []string{"10.0.0.1"}},
+			err:          "source_ip_cidrs must only hold CIDR blocks",
+		},
+		"condition without values": {
+			accessPolicy: models.AccessPolicyModel{
+				AllowAnonymousGetManifest: types.BoolValue(true),
+				Conditions:                []models.AccessPolicyConditionModel{{Test: aws.String("Bool"), Variable: aws.String("aws:SecureTransport")}},
+			},
+			err: "conditions must have a test, a variable and at least one value",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := renderAccessPolicy(&tc.accessPolicy, testChannelArn); err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("expected an error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestPoliciesEquivalent(t *testing.T) {
+	rendered := `{"Version":"2012-10-17","Statement":[` +
+		`{"Sid":"AllowAnonymousGetManifest","Effect":"Allow","Principal":"*","Action":"mediatailor:GetManifest","Resource":"` + testChannelArn + `"},` +
+		`{"Sid":"AllowPrincipalsGetManifest","Effect":"Allow","Principal":{"AWS":["arn:aws:iam::123456789012:role/a","arn:aws:iam::123456789012:role/b"]},"Action":"mediatailor:GetManifest","Resource":"` + testChannelArn + `"}]}`
+
+	equivalent := `{"Statement":[
+		{"Resource":"` + testChannelArn + `","Action":["mediatailor:GetManifest"],"Sid":"AllowPrincipalsGetManifest","Effect":"Allow","Principal":{"AWS":["arn:aws:iam::123456789012:role/b","arn:aws:iam::123456789012:role/a"]}},
+		{"Sid":"AllowAnonymousGetManifest","Effect":"Allow","Principal":"*","Action":"mediatailor:GetManifest","Resource":["` + testChannelArn + `"]}
+	],"Version":"2012-10-17"}`
+	if !policiesEquivalent(rendered, equivalent) {
+		t.Error("expected the policies to be equivalent")
+	}
+
+	different := strings.Replace(equivalent, "role/b", "role/c", 1)
+	if policiesEquivalent(rendered, different) {
+		t.Error("expected the policies to differ")
+	}
+	if policiesEquivalent(rendered, "not json") {
+		t.Error("expected an invalid policy to differ")
+	}
+}
+
+func TestReadAccessPolicy(t *testing.T) {
+	accessPolicy := &models.AccessPolicyModel{AllowAnonymousGetManifest: types.BoolValue(true)}
+	rendered, err := renderAccessPolicy(accessPolicy, testChannelArn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if read := readAccessPolicy(accessPolicy, jsontypes.NewNormalizedValue(rendered), testChannelArn); read != accessPolicy {
+		t.Errorf("expected the access policy to be kept, got %+v", read)
+	}
+	if read := readAccessPolicy(accessPolicy, jsontypes.NewNormalizedNull(), testChannelArn); read != nil {
+		t.Errorf("expected a deleted policy to drift, got %+v", read)
+	}
+	drifted := strings.Replace(rendered, `"Principal":"*"`, `"Principal":{"AWS":"arn:aws:iam::123456789012:root"}`, 1)
+	if read := readAccessPolicy(accessPolicy, jsontypes.NewNormalizedValue(drifted), testChannelArn); read != nil {
+		t.Errorf("expected a changed policy to drift, got %+v", read)
+	}
+}
@@ -0,0 +1,75 @@
+package awsmt
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsTypes "github.com/aws/aws-sdk-go-v2/service/mediatailor/types"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"maps"
+	"slices"
+	"terraform-provider-mediatailor/awsmt/models"
+	"testing"
+)
+
+func TestUnmatchedOutputs(t *testing.T) {
+	configurations := []awsTypes.HttpPackageConfiguration{
+		{Path: aws.String("/hls"), SourceGroup: aws.String("default"), Type: awsTypes.TypeHls},
+		{Path: aws.String("/dash"), SourceGroup: aws.String("dash"), Type: awsTypes.TypeDash},
+	}
+	outputs := map[string]models.ChannelOutputModel{
+		"hls":         {SourceGroup: aws.String("default"), HlsPlaylistSettings: &models.HlsPlaylistSettingsModel{}},
+		"dash":        {SourceGroup: aws.String("dash"), DashPlaylistSettings: &models.DashPlaylistSettingsModel{}},
+		"wrong_type":  {SourceGroup: aws.String("default"), DashPlaylistSettings: &models.DashPlaylistSettingsModel{}},
+		"wrong_group": {SourceGroup: aws.String("other"), HlsPlaylistSettings: &models.HlsPlaylistSettingsModel{}},
+	}
+
+	unmatched := unmatchedOutputs(outputs, configurations)
+	expected := map[string]awsTypes.Type{"wrong_type": awsTypes.TypeDash, "wrong_group": awsTypes.TypeHls}
+	if !maps.Equal(unmatched, expected) {
+		t.Errorf("expected %v, got %v", expected, unmatched)
+	}
+
+	delete(outputs, "wrong_type")
+	delete(outputs, "wrong_group")
+	if unmatched := unmatchedOutputs(outputs, configurations); len(unmatched) != 0 {
+		t.Errorf("expected every output to match, got %v", unmatched)
+	}
+}
+
+func TestReadOutputsByManifestName(t *testing.T) {
+	prior := map[string]models.ChannelOutputModel{
+		"dash": {SourceGroup: aws.String("default"), DashPlaylistSettings: &models.DashPlaylistSettingsModel{}},
+		"hls":  {SourceGroup: aws.String("default"), HlsPlaylistSettings: &models.HlsPlaylistSettingsModel{}},
+	}
+	// the API returns the outputs in another order than the configuration, and with a default ad markup type
+	items := []awsTypes.ResponseOutputItem{
+		{
+			ManifestName:        aws.String("hls"),
+			PlaybackUrl:         aws.String("https://example.com/hls.m3u8"),
+			SourceGroup:         aws.String("default"),
+			HlsPlaylistSettings: &awsTypes.HlsPlaylistSettings{AdMarkupType: []awsTypes.AdMarkupType{awsTypes.AdMarkupTypeDaterange}},
+		},
+		{
+			ManifestName:         aws.String("dash"),
+			PlaybackUrl:          aws.String("https://example.com/dash.mpd"),
+			SourceGroup:          aws.String("default"),
+			DashPlaylistSettings: &awsTypes.DashPlaylistSettings{},
+		},
+	}
+
+	outputs := readOutputsByManifestName(prior, items)
+	if keys := slices.Sorted(maps.Keys(outputs)); !slices.Equal(keys, []string{"dash", "hls"}) {
+		t.Fatalf("unexpected outputs %v", keys)
+	}
+	if outputs["hls"].HlsPlaylistSettings == nil || outputs["hls"].HlsPlaylistSettings.AdMarkupType != nil {
+		t.Errorf("expected the unconfigured ad markup type of the hls output to stay null, got %+v", outputs["hls"].HlsPlaylistSettings)
+	}
+	if outputs["dash"].PlaybackUrl != types.StringValue("https://example.com/dash.mpd") || outputs["dash"].HlsPlaylistSettings != nil {
+		t.Errorf("unexpected dash output %+v", outputs["dash"])
+	}
+
+	// outputs that are not in the prior state are read entirely
+	outputs = readOutputsByManifestName(nil, items)
+	if outputs["hls"].HlsPlaylistSettings == nil || len(outputs["hls"].HlsPlaylistSettings.AdMarkupType) != 1 {
+		t.Errorf("expected the ad markup type of the hls output to be read, got %+v", outputs["hls"].HlsPlaylistSettings)
+	}
+}
@@ -0,0 +1,197 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
+	awsTypes "github.com/aws/aws-sdk-go-v2/service/mediatailor/types"
+	"reflect"
+	"slices"
+	"strings"
+	"terraform-provider-mediatailor/awsmt/models"
+)
+
+// @ADR
+// Context: Updating a channel takes several calls to the API: the tags, stopping the channel, the policy, the channel
+// itself, starting the channel and the log configuration. When one of them failed, the update returned without saving
+// the state, which left the channel stopped, or half updated, with a state that reflected neither the prior nor the
+// planned configuration.
+// Decision: We decided to run the update as a sequence of steps, each recording what it changed in the state once it
+// succeeds. When a step fails, the channel is started again if the update stopped it, and the state holding the
+// applied steps is saved along with an error listing them. A stop undone that way is left out of the applied steps.
+// Consequences: The next plan only shows the changes that were not applied. The changes applied before the failure are
+// not reverted, as reverting them could fail in turn.
+type channelUpdate struct {
+	client mediaTailorClient
+	tags   tagsConfig
+
+	plan  models.ChannelResourceModel
+	state models.ChannelResourceModel
+
+	// channel is the channel described before the update.
+	channel *mediatailor.DescribeChannelOutput
+	// updatedChannel is the output of the UpdateChannel step.
+	updatedChannel *mediatailor.UpdateChannelOutput
+
+	applied []string
+	stopped bool
+}
+
+// channelUpdateStep is a step of the update of a channel. It returns whether it called the API, and records the
+// attributes it changed in the state of the update when it succeeds.
+type channelUpdateStep struct {
+	description string
+	run         func(u *channelUpdate, ctx context.Context) (bool, error)
+}
+
+// stoppingTheChannel describes the step stopping the channel, which is undone by restore.
+const stoppingTheChannel = "stopping the channel"
+
+var channelUpdateSteps = []channelUpdateStep{
+	{"updating the tags", (*channelUpdate).updateTags},
+	{stoppingTheChannel, (*channelUpdate).stop},
+	{"updating the policy", (*channelUpdate).updatePolicy},
+	{"updating the channel", (*channelUpdate).updateChannel},
+	{"starting the channel", (*channelUpdate).start},
+	{"configuring the logs", (*channelUpdate).configureLogs},
+}
+
+func newChannelUpdate(client mediaTailorClient, tags tagsConfig, plan, state models.ChannelResourceModel, channel *mediatailor.DescribeChannelOutput) *channelUpdate {
+	return &channelUpdate{client: client, tags: tags, plan: plan, state: state, channel: channel}
+}
+
+// apply runs the steps of the update in sequence and stops at the first one that fails, returning its description
+// and its error.
+func (u *channelUpdate) apply(ctx context.Context) (string, error) {
+	for _, step := range channelUpdateSteps {
+		called, err := step.run(u, ctx)
+		if err != nil {
+			return step.description, err
+		}
+		if called {
+			u.applied = append(u.applied, step.description)
+		}
+	}
+
+	u.state.Tags, u.state.TagsAll = u.tags.readTags(u.updatedChannel.Tags, u.plan.Tags)
+	return "", nil
+}
+
+// restore starts the channel again if the update stopped it, removing the stop from the applied steps, and returns a
+// description of the outcome for the diagnostics.
+func (u *channelUpdate) restore(ctx context.Context) string {
+	if !u.stopped {
+		return ""
+	}
+	if _, err := u.client.StartChannel(ctx, &mediatailor.StartChannelInput{ChannelName: u.plan.Name}); err != nil {
+		return "The channel was stopped by the update and could not be started again: " + err.Error()
+	}
+	u.stopped = false
+	u.applied = slices.DeleteFunc(u.applied, func(step string) bool { return step == stoppingTheChannel })
+	if u.state.ChannelState != nil {
+		u.state.ChannelState = aws.String(string(awsTypes.ChannelStateRunning))
+	}
+	return "The channel was stopped by the update and was started again."
+}
+
+// appliedSteps describes the steps applied before a failure, for the diagnostics.
+func (u *channelUpdate) appliedSteps() string {
+	if len(u.applied) == 0 {
+		return "No step of the update was applied."
+	}
+	return "The following steps were applied and saved to the state: " + strings.Join(u.applied, ", ") + "."
+}
+
+func (u *channelUpdate) updateTags(_ context.Context) (bool, error) {
+	oldTags := u.tags.withoutIgnored(u.channel.Tags)
+	newTags := tagsAllToMap(u.plan.TagsAll)
+	updated, removed := diffTags(oldTags, newTags)
+	if err := UpdatesTags(u.client, oldTags, newTags, *u.channel.Arn); err != nil {
+		return false, err
+	}
+
+	u.state.Tags = u.plan.Tags
+	if !u.plan.TagsAll.IsUnknown() {
+		u.state.TagsAll = u.plan.TagsAll
+	}
+	return len(updated) != 0 || len(removed) != 0, nil
+}
+
+func (u *channelUpdate) stop(_ context.Context) (bool, error) {
+	if u.channel.ChannelState != awsTypes.ChannelStateRunning {
+		return false, nil
+	}
+	if err := stopChannel(u.channel.ChannelState, u.plan.Name, u.client); err != nil {
+		return false, err
+	}
+
+	u.stopped = true
+	if u.state.ChannelState != nil {
+		u.state.ChannelState = aws.String(string(awsTypes.ChannelStateStopped))
+	}
+	return true, nil
+}
+
+func (u *channelUpdate) updatePolicy(ctx context.Context) (bool, error) {
+	policy, err := desiredChannelPolicy(u.plan, *u.channel.Arn)
+	if err != nil {
+		return false, err
+	}
+
+	policyPlan := u.plan.ChannelModel
+	policyPlan.Policy = policy
+	if err := handlePolicyUpdate(ctx, u.client, policyPlan); err != nil {
+		return false, err
+	}
+
+	changed := !u.plan.Policy.Equal(u.state.Policy) || !reflect.DeepEqual(u.plan.AccessPolicy, u.state.AccessPolicy)
+	u.state.Policy = u.plan.Policy
+	u.state.AccessPolicy = u.plan.AccessPolicy
+	return changed, nil
+}
+
+func (u *channelUpdate) updateChannel(ctx context.Context) (bool, error) {
+	updatedChannel, err := u.client.UpdateChannel(ctx, getUpdateChannelInput(u.plan))
+	if err != nil {
+		return false, err
+	}
+	u.updatedChannel = updatedChannel
+
+	// the attributes set by the other steps are kept as they are in the state
+	channel := writeChannelToPlan(u.plan.ChannelModel, mediatailor.CreateChannelOutput(*updatedChannel))
+	channel.ChannelState = u.state.ChannelState
+	channel.EnableAsRunLogs = u.state.EnableAsRunLogs
+	channel.Policy = u.state.Policy
+	channel.Tags, channel.TagsAll = u.state.Tags, u.state.TagsAll
+	u.state.ChannelModel = channel
+	u.state.Outputs = readOutputsByManifestName(u.plan.Outputs, updatedChannel.Outputs)
+	return true, nil
+}
+
+func (u *channelUpdate) start(ctx context.Context) (bool, error) {
+	called := false
+	if shouldStartChannel(u.channel.ChannelState, u.plan.ChannelState) {
+		if _, err := u.client.StartChannel(ctx, &mediatailor.StartChannelInput{ChannelName: u.plan.Name}); err != nil {
+			return false, err
+		}
+		called = true
+	}
+
+	// the channel is in its planned running state, which is not restored if a later step fails
+	u.stopped = false
+	u.state.ChannelState = u.plan.ChannelState
+	return called, nil
+}
+
+func (u *channelUpdate) configureLogs(ctx context.Context) (bool, error) {
+	called := false
+	if shouldUpdateChannelLogging(u.channel.LogConfiguration.LogTypes, u.plan.ChannelModel) {
+		if _, err := u.client.ConfigureLogsForChannel(ctx, getConfigureLogsForChannelInput(u.plan.ChannelModel)); err != nil {
+			return false, err
+		}
+		called = true
+	}
+
+	u.state.EnableAsRunLogs = u.plan.EnableAsRunLogs
+	return called, nil
+}
@@ -0,0 +1,139 @@
+package awsmt
+
+import (
+	"context"
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
+	awsTypes "github.com/aws/aws-sdk-go-v2/service/mediatailor/types"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"maps"
+	"strings"
+	"terraform-provider-mediatailor/awsmt/fake"
+	"terraform-provider-mediatailor/awsmt/models"
+	"testing"
+)
+
+// failingUpdateClient fails the UpdateChannel calls made to the fake.
+type failingUpdateClient struct {
+	*fake.Client
+}
+
+func (c *failingUpdateClient) UpdateChannel(context.Context, *mediatailor.UpdateChannelInput, ...func(*mediatailor.Options)) (*mediatailor.UpdateChannelOutput, error) {
+	return nil, errors.New("throttled")
+}
+
+const updateTestPolicy = `{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Principal": "*", "Action": "mediatailor:GetManifest", "Resource": "*"}]}`
+
+// runningChannelUpdate creates a running channel in the fake, and returns its prior state and a plan changing its
+// tags, policy and outputs.
+func runningChannelUpdate(t *testing.T, client mediaTailorClient) (models.ChannelResourceModel, models.ChannelResourceModel, *mediatailor.DescribeChannelOutput) {
+	t.Helper()
+	ctx := context.Background()
+	_, err := client.CreateChannel(ctx, &mediatailor.CreateChannelInput{
+		ChannelName:  aws.String("test"),
+		PlaybackMode: awsTypes.PlaybackModeLoop,
+		Outputs: []awsTypes.RequestOutputItem{
+			{ManifestName: aws.String("default"), SourceGroup: aws.String("default"), HlsPlaylistSettings: &awsTypes.HlsPlaylistSettings{}},
+		},
+		Tags: map[string]string{"Environment": "dev"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.StartChannel(ctx, &mediatailor.StartChannelInput{ChannelName: aws.String("test")}); err != nil {
+		t.Fatal(err)
+	}
+	channel, err := client.DescribeChannel(ctx, &mediatailor.DescribeChannelInput{ChannelName: aws.String("test")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var state models.ChannelResourceModel
+	state.ChannelModel = writeChannelToState(state.ChannelModel, *channel)
+	state.ChannelState = aws.String("RUNNING")
+	state.EnableAsRunLogs = types.BoolValue(false)
+	state.Policy = jsontypes.NewNormalizedNull()
+	state.Tags = map[string]string{"Environment": "dev"}
+	state.Outputs = readOutputsByManifestName(nil, channel.Outputs)
+
+	plan := state
+	plan.Policy = jsontypes.NewNormalizedValue(updateTestPolicy)
+	plan.Tags = map[string]string{"Environment": "prod"}
+	plan.TagsAll = tagsAllValue(plan.Tags)
+	plan.Outputs = map[string]models.ChannelOutputModel{
+		"hls": {SourceGroup: aws.String("default"), HlsPlaylistSettings: &models.HlsPlaylistSettingsModel{}},
+	}
+	return state, plan, channel
+}
+
+func TestChannelUpdateApply(t *testing.T) {
+	client := fake.NewClient()
+	state, plan, channel := runningChannelUpdate(t, client)
+
+	update := newChannelUpdate(client, tagsConfig{}, plan, state, channel)
+	if step, err := update.apply(context.Background()); err != nil {
+		t.Fatalf("unexpected error while %s: %v", step, err)
+	}
+	if _, ok := update.state.Outputs["hls"]; !ok || len(update.state.Outputs) != 1 {
+		t.Errorf("expected the planned outputs, got %v", update.state.Outputs)
+	}
+	if !update.state.Policy.Equal(plan.Policy) || !maps.Equal(update.state.Tags, plan.Tags) || *update.state.ChannelState != "RUNNING" {
+		t.Errorf("expected the planned policy, tags and channel state, got %+v", update.state.ChannelModel)
+	}
+
+	described, err := client.DescribeChannel(context.Background(), &mediatailor.DescribeChannelInput{ChannelName: aws.String("test")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if described.ChannelState != awsTypes.ChannelStateRunning {
+		t.Errorf("expected the channel to be started again, got %s", described.ChannelState)
+	}
+}
+
+func TestChannelUpdateRecordsTheAppliedSteps(t *testing.T) {
+	client := &failingUpdateClient{Client: fake.NewClient()}
+	state, plan, channel := runningChannelUpdate(t, client)
+	ctx := context.Background()
+
+	update := newChannelUpdate(client, tagsConfig{}, plan, state, channel)
+	step, err := update.apply(ctx)
+	if err == nil || step != "updating the channel" {
+		t.Fatalf("expected the update of the channel to fail, got %q and %v", step, err)
+	}
+	if applied := update.appliedSteps(); !strings.Contains(applied, "updating the tags, stopping the channel, updating the policy.") {
+		t.Errorf("unexpected applied steps %q", applied)
+	}
+
+	// the tags and the policy are recorded, the outputs are left as they were
+	if !update.state.Policy.Equal(plan.Policy) || !maps.Equal(update.state.Tags, plan.Tags) {
+		t.Errorf("expected the applied policy and tags to be recorded, got %+v", update.state.ChannelModel)
+	}
+	if _, ok := update.state.Outputs["default"]; !ok || len(update.state.Outputs) != 1 {
+		t.Errorf("expected the prior outputs, got %v", update.state.Outputs)
+	}
+	if *update.state.ChannelState != "STOPPED" {
+		t.Errorf("expected the channel to be recorded as stopped, got %s", *update.state.ChannelState)
+	}
+
+	if restored := update.restore(ctx); !strings.Contains(restored, "started again") {
+		t.Errorf("expected the channel to be started again, got %q", restored)
+	}
+	if *update.state.ChannelState != "RUNNING" {
+		t.Errorf("expected the channel to be recorded as running, got %s", *update.state.ChannelState)
+	}
+	if applied := update.appliedSteps(); !strings.Contains(applied, "updating the tags, updating the policy.") {
+		t.Errorf("expected the stop undone by the restore to be left out of the applied steps, got %q", applied)
+	}
+	described, err := client.DescribeChannel(ctx, &mediatailor.DescribeChannelInput{ChannelName: aws.String("test")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if described.ChannelState != awsTypes.ChannelStateRunning {
+		t.Errorf("expected the channel to be running, got %s", described.ChannelState)
+	}
+	if restored := update.restore(ctx); restored != "" {
+		t.Errorf("expected the channel to be restored only once, got %q", restored)
+	}
+}
@@ -3,27 +3,179 @@ package awsmt
 import (
 	"context"
 	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	mediatailorV2 "github.com/aws/aws-sdk-go-v2/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"maps"
+	"slices"
 	"strings"
 )
 
-func untag(client *mediatailorV2.Client, oldTags map[string]string, resourceArn string) error {
-	var removeTags []string
-	for k := range oldTags {
-		removeTags = append(removeTags, k)
+// tagsConfig holds the tag settings of the provider, which apply to every resource.
+type tagsConfig struct {
+	defaultTags       map[string]string
+	ignoreKeys        []string
+	ignoreKeyPrefixes []string
+}
+
+// isIgnored reports whether the tag is managed outside Terraform, according to the ignore_tags block of the provider.
+func (c tagsConfig) isIgnored(key string) bool {
+	if slices.Contains(c.ignoreKeys, key) {
+		return true
+	}
+	for _, prefix := range c.ignoreKeyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutIgnored returns the tags that are not ignored.
+func (c tagsConfig) withoutIgnored(tags map[string]string) map[string]string {
+	if tags == nil {
+		return nil
+	}
+	filtered := map[string]string{}
+	for k, v := range tags {
+		if !c.isIgnored(k) {
+			filtered[k] = v
+		}
+	}
+	return filtered
+}
+
+// @ADR
+// Context: The provider must apply the default tags configured in the provider block to every resource, while the
+// tags attribute of each resource only contains the tags configured for that resource.
+// Decision: We added a computed tags_all attribute to every resource, holding the tags of the resource merged with the
+// default tags. The tags_all attribute is planned by the ModifyPlan function of each resource, and is used to create
+// and tag the objects in MediaTailor.
+// Consequences: The tags read from MediaTailor are written to tags_all, while the default tags that are not configured
+// in the resource are removed from tags, so that they do not cause a difference with the configuration.
+func (c tagsConfig) mergeTags(tags map[string]string) map[string]string {
+	merged := map[string]string{}
+	maps.Copy(merged, c.defaultTags)
+	maps.Copy(merged, tags)
+	return merged
+}
+
+// readTags returns the values of the tags and tags_all attributes of a resource from the tags of the MediaTailor object
+// and the tags configured in the resource. The ignored tags are left out of tags_all, and out of tags unless they are
+// configured, in which case the configured value is kept.
+func (c tagsConfig) readTags(remoteTags map[string]string, configuredTags map[string]string) (map[string]string, types.Map) {
+	remoteTags = c.withoutIgnored(remoteTags)
+	tags := map[string]string{}
+	for k, v := range remoteTags {
+		if defaultValue, ok := c.defaultTags[k]; ok && defaultValue == v {
+			if _, configured := configuredTags[k]; !configured {
+				continue
+			}
+		}
+		tags[k] = v
+	}
+	for k, v := range configuredTags {
+		if c.isIgnored(k) {
+			tags[k] = v
+		}
+	}
+	if len(tags) == 0 && len(configuredTags) == 0 {
+		tags = configuredTags
+	}
+	return tags, tagsAllValue(remoteTags)
+}
+
+// dataSourceTags returns the values of the tags and tags_all attributes of a data source from the tags of the
+// MediaTailor object. Unlike readTags, the default tags are kept in both attributes.
+func (c tagsConfig) dataSourceTags(remoteTags map[string]string) (map[string]string, types.Map) {
+	tags := c.withoutIgnored(remoteTags)
+	if len(tags) == 0 {
+		return nil, tagsAllValue(nil)
+	}
+	return tags, tagsAllValue(tags)
+}
+
+// lookupDataSourceTags returns the tags and tags_all attributes of a data source looked up by the given tags, whose
+// tags attribute keeps the configured tags. Data sources looked up otherwise read their tags like dataSourceTags.
+func (c tagsConfig) lookupDataSourceTags(lookupTags map[string]string, remoteTags map[string]string) (map[string]string, types.Map) {
+	tags, tagsAll := c.dataSourceTags(remoteTags)
+	if lookupTags != nil {
+		return lookupTags, tagsAll
+	}
+	return tags, tagsAll
+}
+
+// tagsAllValue returns the value of the tags_all attribute for the given tags, which is null if there are no tags.
+func tagsAllValue(tags map[string]string) types.Map {
+	if len(tags) == 0 {
+		return types.MapNull(types.StringType)
+	}
+	elements := map[string]attr.Value{}
+	for k, v := range tags {
+		elements[k] = types.StringValue(v)
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
+
+// tagsAllToMap returns the tags held by the tags_all attribute.
+func tagsAllToMap(tagsAll types.Map) map[string]string {
+	if tagsAll.IsNull() || tagsAll.IsUnknown() {
+		return nil
+	}
+	tags := map[string]string{}
+	for k, v := range tagsAll.Elements() {
+		if s, ok := v.(types.String); ok {
+			tags[k] = s.ValueString()
+		}
 	}
-	if len(removeTags) == 0 {
+	return tags
+}
+
+// planTagsAll sets the tags_all attribute of the plan to the tags of the resource merged with the default tags, without
+// the ignored tags, as read back by readTags. It is called by the ModifyPlan function of every resource.
+func planTagsAll(ctx context.Context, c tagsConfig, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var tags types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("tags"), &tags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if tags.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), types.MapUnknown(types.StringType))...)
+		return
+	}
+
+	resourceTags := map[string]string{}
+	for k, v := range tags.Elements() {
+		if v.IsUnknown() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), types.MapUnknown(types.StringType))...)
+			return
+		}
+		resourceTags[k] = v.(types.String).ValueString()
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), tagsAllValue(c.withoutIgnored(c.mergeTags(resourceTags))))...)
+}
+
+func untag(client mediaTailorClient, keys []string, resourceArn string) error {
+	if len(keys) == 0 {
 		return nil
 	}
-	if _, err := client.UntagResource(context.TODO(), &mediatailorV2.UntagResourceInput{ResourceArn: &resourceArn, TagKeys: removeTags}); err != nil {
+	if _, err := client.UntagResource(context.TODO(), &mediatailorV2.UntagResourceInput{ResourceArn: &resourceArn, TagKeys: keys}); err != nil {
 		return err
 	}
 	return nil
 }
 
-func tag(client *mediatailorV2.Client, newTags map[string]string, resourceArn string) error {
+func tag(client mediaTailorClient, newTags map[string]string, resourceArn string) error {
 	if len(newTags) == 0 {
 		return nil
 	}
@@ -33,37 +185,90 @@ func tag(client *mediatailorV2.Client, newTags map[string]string, resourceArn st
 	return nil
 }
 
-func UpdatesTags(client *mediatailorV2.Client, oldTags map[string]string, newTags map[string]string, resourceArn string) error {
-	if !tagsEqual(oldTags, newTags) {
-		if err := untag(client, oldTags, resourceArn); err != nil {
-			return err
+// diffTags returns the tags that are added or changed, and the keys of the tags that are removed, when going from
+// oldTags to newTags.
+func diffTags(oldTags, newTags map[string]string) (map[string]string, []string) {
+	updated := map[string]string{}
+	for k, v := range newTags {
+		if oldValue, ok := oldTags[k]; !ok || oldValue != v {
+			updated[k] = v
 		}
-		if err := tag(client, newTags, resourceArn); err != nil {
-			return err
+	}
+	var removed []string
+	for k := range oldTags {
+		if _, ok := newTags[k]; !ok {
+			removed = append(removed, k)
 		}
 	}
-	return nil
+	slices.Sort(removed)
+	return updated, removed
+}
+
+// @ADR
+// Context: Removing every tag before adding the new ones leaves the resource without tags for a moment, which breaks
+// the IAM conditions and the cost reports based on tags.
+// Decision: We only tag the added or changed tags and untag the removed keys, in that order, and read the tags back
+// to check that the update was applied.
+// Consequences: Updating tags requires an additional ListTagsForResource call.
+func UpdatesTags(client mediaTailorClient, oldTags map[string]string, newTags map[string]string, resourceArn string) error {
+	updated, removed := diffTags(oldTags, newTags)
+	if len(updated) == 0 && len(removed) == 0 {
+		return nil
+	}
+	if err := tag(client, updated, resourceArn); err != nil {
+		return err
+	}
+	if err := untag(client, removed, resourceArn); err != nil {
+		return err
+	}
+	return checkTags(client, newTags, removed, resourceArn)
 }
 
-func tagsEqual(a, b map[string]string) bool {
-	if len(a) != len(b) {
-		return false
+// checkTags reads the tags of the resource back and checks that they contain the expected tags and none of the removed
+// keys. Other tags, such as the ignored ones, are allowed.
+func checkTags(client mediaTailorClient, expected map[string]string, removed []string, resourceArn string) error {
+	output, err := client.ListTagsForResource(context.TODO(), &mediatailorV2.ListTagsForResourceInput{ResourceArn: &resourceArn})
+	if err != nil {
+		return fmt.Errorf("error while reading the tags of %s: %w", resourceArn, err)
 	}
-	for k, v := range a {
-		if bv, ok := b[k]; !ok || bv != v {
-			return false
+	for k, v := range expected {
+		if actual, ok := output.Tags[k]; !ok || actual != v {
+			return fmt.Errorf("tag %s of %s was not updated: expected %q, got %q", k, resourceArn, v, actual)
 		}
 	}
-	return true
+	for _, k := range removed {
+		if _, ok := output.Tags[k]; ok {
+			return fmt.Errorf("tag %s of %s was not removed", k, resourceArn)
+		}
+	}
+	return nil
 }
 
-func importStateForContentSources(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	idParts := strings.Split(req.ID, ",")
+func importStateForContentSources(ctx context.Context, resourceType string, region string, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" && req.Identity != nil {
+		importStateForContentSourcesIdentity(ctx, region, req, resp)
+		return
+	}
+
+	var idParts []string
+	if arn.IsARN(req.ID) {
+		parsed, err := parseImportArn(req.ID, resourceType)
+		if err == nil {
+			err = checkIdentityRegion(types.StringValue(parsed.Region), region)
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+			return
+		}
+		idParts = parsed.Names
+	} else {
+		idParts = strings.Split(req.ID, ",")
+	}
 
 	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
 		resp.Diagnostics.AddError(
 			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: source_location_name,name. Got: %q", req.ID),
+			fmt.Sprintf("Expected import identifier with format: source_location_name,name or the ARN of the %s. Got: %q", resourceType, req.ID),
 		)
 		return
 	}
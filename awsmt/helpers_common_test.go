@@ -0,0 +1,253 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
+	awsTypes "github.com/aws/aws-sdk-go-v2/service/mediatailor/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"maps"
+	"slices"
+	"terraform-provider-mediatailor/awsmt/fake"
+	"testing"
+)
+
+func TestMergeTags(t *testing.T) {
+	c := tagsConfig{defaultTags: map[string]string{"CostCenter": "ott", "Environment": "dev"}}
+
+	merged := c.mergeTags(map[string]string{"Environment": "prod", "Team": "video"})
+	expected := map[string]string{"CostCenter": "ott", "Environment": "prod", "Team": "video"}
+	if !maps.Equal(merged, expected) {
+		t.Errorf("expected %v, got %v", expected, merged)
+	}
+
+	if merged := (tagsConfig{}).mergeTags(nil); len(merged) != 0 {
+		t.Errorf("expected no tags, got %v", merged)
+	}
+}
+
+func TestResourceTags(t *testing.T) {
+	c := tagsConfig{defaultTags: map[string]string{"CostCenter": "ott", "Environment": "dev"}}
+
+	t.Run("removes the default tags that are not configured", func(t *testing.T) {
+		tags, _ := c.readTags(map[string]string{"CostCenter": "ott", "Environment": "dev", "Team": "video"}, map[string]string{"Team": "video"})
+		if !maps.Equal(tags, map[string]string{"Team": "video"}) {
+			t.Errorf("unexpected tags %v", tags)
+		}
+	})
+
+	t.Run("keeps the default tags that are also configured", func(t *testing.T) {
+		tags, _ := c.readTags(map[string]string{"CostCenter": "ott", "Environment": "dev"}, map[string]string{"Environment": "dev"})
+		if !maps.Equal(tags, map[string]string{"Environment": "dev"}) {
+			t.Errorf("unexpected tags %v", tags)
+		}
+	})
+
+	t.Run("keeps the tags overriding a default tag", func(t *testing.T) {
+		tags, _ := c.readTags(map[string]string{"CostCenter": "finance"}, nil)
+		if !maps.Equal(tags, map[string]string{"CostCenter": "finance"}) {
+			t.Errorf("unexpected tags %v", tags)
+		}
+	})
+
+	t.Run("keeps the configured empty tags", func(t *testing.T) {
+		if tags, _ := c.readTags(map[string]string{"CostCenter": "ott"}, nil); tags != nil {
+			t.Errorf("expected nil tags, got %v", tags)
+		}
+		if tags, _ := c.readTags(nil, map[string]string{}); tags == nil || len(tags) != 0 {
+			t.Errorf("expected empty tags, got %v", tags)
+		}
+	})
+}
+
+func TestTagsAllValue(t *testing.T) {
+	if v := tagsAllValue(nil); !v.IsNull() {
+		t.Errorf("expected a null value, got %v", v)
+	}
+	tags := map[string]string{"Environment": "dev"}
+	if v := tagsAllToMap(tagsAllValue(tags)); !maps.Equal(v, tags) {
+		t.Errorf("expected %v, got %v", tags, v)
+	}
+}
+
+func TestIgnoreTags(t *testing.T) {
+	c := tagsConfig{
+		defaultTags:       map[string]string{"Environment": "dev"},
+		ignoreKeys:        []string{"Owner"},
+		ignoreKeyPrefixes: []string{"cost:"},
+	}
+	remote := map[string]string{"Environment": "dev", "Owner": "finops", "cost:center": "ott", "cost:project": "video", "Team": "video"}
+
+	tags, tagsAll := c.readTags(remote, map[string]string{"Team": "video"})
+	if !maps.Equal(tags, map[string]string{"Team": "video"}) {
+		t.Errorf("unexpected tags %v", tags)
+	}
+	if !maps.Equal(tagsAllToMap(tagsAll), map[string]string{"Environment": "dev", "Team": "video"}) {
+		t.Errorf("unexpected tags_all %v", tagsAll)
+	}
+
+	tags, tagsAll = c.readTags(remote, map[string]string{"Owner": "video", "Team": "video"})
+	if !maps.Equal(tags, map[string]string{"Owner": "video", "Team": "video"}) {
+		t.Errorf("expected the configured ignored tag to be kept, got %v", tags)
+	}
+	if !maps.Equal(tagsAllToMap(tagsAll), map[string]string{"Environment": "dev", "Team": "video"}) {
+		t.Errorf("unexpected tags_all %v", tagsAll)
+	}
+
+	tags, _ = c.dataSourceTags(remote)
+	if !maps.Equal(tags, map[string]string{"Environment": "dev", "Team": "video"}) {
+		t.Errorf("unexpected data source tags %v", tags)
+	}
+	if tags, tagsAll := c.dataSourceTags(map[string]string{"Owner": "finops"}); tags != nil || !tagsAll.IsNull() {
+		t.Errorf("expected no tags, got %v and %v", tags, tagsAll)
+	}
+}
+
+func TestDiffTags(t *testing.T) {
+	updated, removed := diffTags(
+		map[string]string{"Environment": "dev", "Team": "video", "Obsolete": "yes", "Legacy": "true"},
+		map[string]string{"Environment": "prod", "Team": "video", "Owner": "ott"},
+	)
+	if expected := map[string]string{"Environment": "prod", "Owner": "ott"}; !maps.Equal(updated, expected) {
+		t.Errorf("expected %v to be updated, got %v", expected, updated)
+	}
+	if expected := []string{"Legacy", "Obsolete"}; !slices.Equal(removed, expected) {
+		t.Errorf("expected %v to be removed, got %v", expected, removed)
+	}
+
+	updated, removed = diffTags(map[string]string{"Team": "video"}, map[string]string{"Team": "video"})
+	if len(updated) != 0 || len(removed) != 0 {
+		t.Errorf("expected no change, got %v and %v", updated, removed)
+	}
+}
+
+// taggingClient records the tagging calls made to the fake.
+type taggingClient struct {
+	*fake.Client
+	tagged   []map[string]string
+	untagged [][]string
+}
+
+func (c *taggingClient) TagResource(ctx context.Context, params *mediatailor.TagResourceInput, optFns ...func(*mediatailor.Options)) (*mediatailor.TagResourceOutput, error) {
+	c.tagged = append(c.tagged, params.Tags)
+	return c.Client.TagResource(ctx, params, optFns...)
+}
+
+func (c *taggingClient) UntagResource(ctx context.Context, params *mediatailor.UntagResourceInput, optFns ...func(*mediatailor.Options)) (*mediatailor.UntagResourceOutput, error) {
+	c.untagged = append(c.untagged, params.TagKeys)
+	return c.Client.UntagResource(ctx, params, optFns...)
+}
+
+func TestUpdatesTags(t *testing.T) {
+	client := &taggingClient{Client: fake.NewClient()}
+	oldTags := map[string]string{"Environment": "dev", "Team": "video", "Obsolete": "yes"}
+	sourceLocation, err := client.CreateSourceLocation(context.TODO(), &mediatailor.CreateSourceLocationInput{
+		SourceLocationName: aws.String("test"),
+		HttpConfiguration:  &awsTypes.HttpConfiguration{BaseUrl: aws.String("https://example.com")},
+		Tags:               oldTags,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newTags := map[string]string{"Environment": "prod", "Team": "video", "Owner": "ott"}
+	if err := UpdatesTags(client, oldTags, newTags, *sourceLocation.Arn); err != nil {
+		t.Fatal(err)
+	}
+	if len(client.tagged) != 1 || !maps.Equal(client.tagged[0], map[string]string{"Environment": "prod", "Owner": "ott"}) {
+		t.Errorf("expected only the added and changed tags to be tagged, got %v", client.tagged)
+	}
+	if len(client.untagged) != 1 || !slices.Equal(client.untagged[0], []string{"Obsolete"}) {
+		t.Errorf("expected only the removed keys to be untagged, got %v", client.untagged)
+	}
+
+	output, err := client.ListTagsForResource(context.TODO(), &mediatailor.ListTagsForResourceInput{ResourceArn: sourceLocation.Arn})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !maps.Equal(output.Tags, newTags) {
+		t.Errorf("expected %v, got %v", newTags, output.Tags)
+	}
+
+	client.tagged, client.untagged = nil, nil
+	if err := UpdatesTags(client, newTags, newTags, *sourceLocation.Arn); err != nil {
+		t.Fatal(err)
+	}
+	if len(client.tagged) != 0 || len(client.untagged) != 0 {
+		t.Errorf("expected no tagging call when the tags are unchanged, got %v and %v", client.tagged, client.untagged)
+	}
+}
+
+func TestIgnoredTagPlanAndApply(t *testing.T) {
+	providerType := providerConfigType()
+	server := configureTestServer(t, fake.NewClient(), objectValue(providerType, map[string]tftypes.Value{
+		"ignore_tags": objectValue(providerType.AttributeTypes["ignore_tags"].(tftypes.Object), map[string]tftypes.Value{
+			"keys": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "Owner")}),
+		}),
+	}))
+
+	r := ResourceSourceLocation()
+	resourceType := resourceConfigType(r)
+	tagsType := tftypes.Map{ElementType: tftypes.String}
+	planned, applied := createResource(t, server, r, objectValue(resourceType, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "tf-acc-ignored-tags"),
+		"http_configuration": objectValue(resourceType.AttributeTypes["http_configuration"].(tftypes.Object), map[string]tftypes.Value{
+			"base_url": tftypes.NewValue(tftypes.String, "https://example.com"),
+		}),
+		"tags": tftypes.NewValue(tagsType, map[string]tftypes.Value{
+			"Owner": tftypes.NewValue(tftypes.String, "video"),
+			"Team":  tftypes.NewValue(tftypes.String, "video"),
+		}),
+	}))
+
+	for _, name := range []string{"tags", "tags_all"} {
+		if !planned[name].IsFullyKnown() || !planned[name].Equal(applied[name]) {
+			t.Errorf("expected the applied %s to be %s, got %s", name, planned[name], applied[name])
+		}
+	}
+	expectedTagsAll := tftypes.NewValue(tagsType, map[string]tftypes.Value{"Team": tftypes.NewValue(tftypes.String, "video")})
+	if !applied["tags_all"].Equal(expectedTagsAll) {
+		t.Errorf("expected tags_all to leave out the ignored tag, got %s", applied["tags_all"])
+	}
+}
+
+func TestDataSourceIgnoredTags(t *testing.T) {
+	client := fake.NewClient()
+	if _, err := client.CreateSourceLocation(context.Background(), &mediatailor.CreateSourceLocationInput{
+		SourceLocationName: aws.String("tf-acc-ignored-tags"),
+		HttpConfiguration:  &awsTypes.HttpConfiguration{BaseUrl: aws.String("https://example.com")},
+		Tags:               map[string]string{"Owner": "video", "Team": "video", "Env": "dev"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	providerType := providerConfigType()
+	server := configureTestServer(t, client, objectValue(providerType, map[string]tftypes.Value{
+		"ignore_tags": objectValue(providerType.AttributeTypes["ignore_tags"].(tftypes.Object), map[string]tftypes.Value{
+			"keys": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "Owner")}),
+		}),
+	}))
+
+	tagsType := tftypes.Map{ElementType: tftypes.String}
+	readTags := tftypes.NewValue(tagsType, map[string]tftypes.Value{
+		"Env":  tftypes.NewValue(tftypes.String, "dev"),
+		"Team": tftypes.NewValue(tftypes.String, "video"),
+	})
+	lookupTags := tftypes.NewValue(tagsType, map[string]tftypes.Value{"Team": tftypes.NewValue(tftypes.String, "video")})
+	for name, tc := range map[string]struct {
+		config map[string]tftypes.Value
+		tags   tftypes.Value
+	}{
+		"by name": {config: map[string]tftypes.Value{"name": tftypes.NewValue(tftypes.String, "tf-acc-ignored-tags")}, tags: readTags},
+		"by tags": {config: map[string]tftypes.Value{"tags": lookupTags}, tags: lookupTags},
+	} {
+		t.Run(name, func(t *testing.T) {
+			state := readDataSource(t, server, DataSourceSourceLocation(), tc.config)
+			if !state["tags"].Equal(tc.tags) {
+				t.Errorf("expected the tags %s, got %s", tc.tags, state["tags"])
+			}
+			if !state["tags_all"].Equal(readTags) {
+				t.Errorf("expected tags_all to leave out the ignored tag, got %s", state["tags_all"])
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package awsmt
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// taggedObject is an object returned by a List operation, identified by its names, for example the source location
+// name and the vod source name of a vod source.
+type taggedObject struct {
+	names []string
+	tags  map[string]string
+}
+
+// namesFromArn returns the names of the object of a data source looked up by ARN.
+func namesFromArn(arn types.String, resourceType string) ([]string, error) {
+	parsed, err := parseImportArn(arn.ValueString(), resourceType)
+	if err != nil {
+		return nil, err
+	}
+	return parsed.Names, nil
+}
+
+// @ADR
+// Context: The data sources required the name of the object they read, which forced the shared modules to know the
+// naming conventions of every environment to find the objects of an environment.
+// Decision: The data sources can also look up their object by ARN, or by tags that must match exactly one object, in
+// which case the objects are listed with the paginated List operations and filtered by tags. The name, the ARN and
+// the tags are exclusive.
+// Consequences: Looking up an object by tags lists every object of its type, and the sources of every source location
+// unless the source location name is set. The tags attribute keeps the configured tags, and tags_all holds the tags of
+// the object.
+func lookupNames(kind string, resourceType string, arn types.String, tags map[string]string, objects func() iter.Seq2[taggedObject, error]) ([]string, error) {
+	if !arn.IsNull() {
+		return namesFromArn(arn, resourceType)
+	}
+	return namesFromTags(kind, tags, objects())
+}
+
+// namesFromTags returns the names of the only listed object whose tags contain the tags of a data source.
+func namesFromTags(kind string, tags map[string]string, objects iter.Seq2[taggedObject, error]) ([]string, error) {
+	var matches [][]string
+	for object, err := range objects {
+		if err != nil {
+			return nil, err
+		}
+		if containsTags(object.tags, tags) {
+			matches = append(matches, object.names)
+		}
+	}
+
+	switch len(matches) {
+	case 1:
+		return matches[0], nil
+	case 0:
+		return nil, fmt.Errorf("no %s has the tags %s", kind, formatTags(tags))
+	default:
+		names := make([]string, 0, len(matches))
+		for _, match := range matches {
+			names = append(names, strings.Join(match, ","))
+		}
+		slices.Sort(names)
+		return nil, fmt.Errorf("%d %ss have the tags %s, expected exactly one: %s", len(matches), kind, formatTags(tags), strings.Join(names, ", "))
+	}
+}
+
+// formatTags formats tags for the error messages, sorted by key.
+func formatTags(tags map[string]string) string {
+	pairs := make([]string, 0, len(tags))
+	for _, k := range slices.Sorted(maps.Keys(tags)) {
+		pairs = append(pairs, k+"="+tags[k])
+	}
+	return strings.Join(pairs, ", ")
+}
+
+func listTaggedChannels(ctx context.Context, client mediaTailorClient) iter.Seq2[taggedObject, error] {
+	return func(yield func(taggedObject, error) bool) {
+		paginator := mediatailor.NewListChannelsPaginator(client, &mediatailor.ListChannelsInput{})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				yield(taggedObject{}, err)
+				return
+			}
+			for _, channel := range page.Items {
+				if !yield(taggedObject{names: []string{*channel.ChannelName}, tags: channel.Tags}, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func listTaggedPlaybackConfigurations(ctx context.Context, client mediaTailorClient) iter.Seq2[taggedObject, error] {
+	return func(yield func(taggedObject, error) bool) {
+		paginator := mediatailor.NewListPlaybackConfigurationsPaginator(client, &mediatailor.ListPlaybackConfigurationsInput{})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				yield(taggedObject{}, err)
+				return
+			}
+			for _, playbackConfiguration := range page.Items {
+				if !yield(taggedObject{names: []string{*playbackConfiguration.Name}, tags: playbackConfiguration.Tags}, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func listTaggedSourceLocations(ctx context.Context, client mediaTailorClient) iter.Seq2[taggedObject, error] {
+	return func(yield func(taggedObject, error) bool) {
+		paginator := mediatailor.NewListSourceLocationsPaginator(client, &mediatailor.ListSourceLocationsInput{})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				yield(taggedObject{}, err)
+				return
+			}
+			for _, sourceLocation := range page.Items {
+				if !yield(taggedObject{names: []string{*sourceLocation.SourceLocationName}, tags: sourceLocation.Tags}, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// listTaggedVodSources lists the vod sources of the given source location, or of every source location when it is
+// null.
+func listTaggedVodSources(ctx context.Context, client mediaTailorClient, sourceLocationName types.String) iter.Seq2[taggedObject, error] {
+	return func(yield func(taggedObject, error) bool) {
+		for name, err := range listSourceLocationNames(ctx, client, sourceLocationName) {
+			if err != nil {
+				yield(taggedObject{}, err)
+				return
+			}
+			paginator := mediatailor.NewListVodSourcesPaginator(client, &mediatailor.ListVodSourcesInput{SourceLocationName: &name})
+			for paginator.HasMorePages() {
+				page, err := paginator.NextPage(ctx)
+				if err != nil {
+					yield(taggedObject{}, err)
+					return
+				}
+				for _, vodSource := range page.Items {
+					if !yield(taggedObject{names: []string{name, *vodSource.VodSourceName}, tags: vodSource.Tags}, nil) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// listTaggedLiveSources lists the live sources of the given source location, or of every source location when it is
+// null.
+func listTaggedLiveSources(ctx context.Context, client mediaTailorClient, sourceLocationName types.String) iter.Seq2[taggedObject, error] {
+	return func(yield func(taggedObject, error) bool) {
+		for name, err := range listSourceLocationNames(ctx, client, sourceLocationName) {
+			if err != nil {
+				yield(taggedObject{}, err)
+				return
+			}
+			paginator := mediatailor.NewListLiveSourcesPaginator(client, &mediatailor.ListLiveSourcesInput{SourceLocationName: &name})
+			for paginator.HasMorePages() {
+				page, err := paginator.NextPage(ctx)
+				if err != nil {
+					yield(taggedObject{}, err)
+					return
+				}
+				for _, liveSource := range page.Items {
+					if !yield(taggedObject{names: []string{name, *liveSource.LiveSourceName}, tags: liveSource.Tags}, nil) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
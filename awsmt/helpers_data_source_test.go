@@ -0,0 +1,104 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"iter"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLookupNames(t *testing.T) {
+	ctx := context.Background()
+	client := newListTestClient(t)
+	sourceLocations := func() iter.Seq2[taggedObject, error] { return listTaggedSourceLocations(ctx, client) }
+
+	names, err := lookupNames("source location", arnResourceSourceLocation, types.StringNull(), map[string]string{"Team": "test_b"}, sourceLocations)
+	if err != nil || !slices.Equal(names, []string{"test_b"}) {
+		t.Errorf("expected the source location having the tags, got %v, %v", names, err)
+	}
+
+	_, err = lookupNames("source location", arnResourceSourceLocation, types.StringNull(), map[string]string{"Team": "none"}, sourceLocations)
+	if err == nil || err.Error() != "no source location has the tags Team=none" {
+		t.Errorf("expected an error when no source location has the tags, got %v", err)
+	}
+
+	names, err = lookupNames("source location", arnResourceSourceLocation, types.StringValue("arn:aws:mediatailor:eu-central-1:123456789012:sourceLocation/test_a"), nil, sourceLocations)
+	if err != nil || !slices.Equal(names, []string{"test_a"}) {
+		t.Errorf("expected the names of the ARN, got %v, %v", names, err)
+	}
+
+	_, err = lookupNames("source location", arnResourceSourceLocation, types.StringValue("arn:aws:mediatailor:eu-central-1:123456789012:channel/test_a"), nil, sourceLocations)
+	if err == nil || !strings.Contains(err.Error(), "expected the ARN of a sourceLocation") {
+		t.Errorf("expected an error for the ARN of another resource type, got %v", err)
+	}
+}
+
+func TestLookupNamesOfSources(t *testing.T) {
+	ctx := context.Background()
+	client := newListTestClient(t)
+	for _, sourceLocationName := range []string{"test_a", "test_b"} {
+		vodSource, err := client.DescribeVodSource(ctx, &mediatailor.DescribeVodSourceInput{SourceLocationName: aws.String(sourceLocationName), VodSourceName: aws.String("vod")})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := client.TagResource(ctx, &mediatailor.TagResourceInput{ResourceArn: vodSource.Arn, Tags: map[string]string{"Role": "slate"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tags := map[string]string{"Role": "slate"}
+
+	_, err := lookupNames("vod source", arnResourceVodSource, types.StringNull(), tags, func() iter.Seq2[taggedObject, error] {
+		return listTaggedVodSources(ctx, client, types.StringNull())
+	})
+	if err == nil || err.Error() != "2 vod sources have the tags Role=slate, expected exactly one: test_a,vod, test_b,vod" {
+		t.Errorf("expected an error when several vod sources have the tags, got %v", err)
+	}
+
+	names, err := lookupNames("vod source", arnResourceVodSource, types.StringNull(), tags, func() iter.Seq2[taggedObject, error] {
+		return listTaggedVodSources(ctx, client, types.StringValue("test_b"))
+	})
+	if err != nil || !slices.Equal(names, []string{"test_b", "vod"}) {
+		t.Errorf("expected the vod source of the source location, got %v, %v", names, err)
+	}
+
+	_, err = lookupNames("live source", arnResourceLiveSource, types.StringNull(), tags, func() iter.Seq2[taggedObject, error] {
+		return listTaggedLiveSources(ctx, client, types.StringNull())
+	})
+	if err == nil || !strings.HasPrefix(err.Error(), "no live source has the tags") {
+		t.Errorf("expected an error when no live source has the tags, got %v", err)
+	}
+}
+
+func TestDataSourceLookupValidation(t *testing.T) {
+	tags := tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{"Team": tftypes.NewValue(tftypes.String, "video")})
+	name := tftypes.NewValue(tftypes.String, "test")
+	arn := tftypes.NewValue(tftypes.String, "arn:aws:mediatailor:eu-central-1:123456789012:vodSource/test/test")
+
+	for description, testCase := range map[string]struct {
+		config   map[string]tftypes.Value
+		expected string
+	}{
+		"name":                  {map[string]tftypes.Value{"name": name, "source_location_name": name}, ""},
+		"arn":                   {map[string]tftypes.Value{"arn": arn}, ""},
+		"tags":                  {map[string]tftypes.Value{"tags": tags}, ""},
+		"tags in a location":    {map[string]tftypes.Value{"tags": tags, "source_location_name": name}, ""},
+		"nothing":               {map[string]tftypes.Value{}, "Invalid Attribute Combination"},
+		"name and arn":          {map[string]tftypes.Value{"name": name, "source_location_name": name, "arn": arn}, "Invalid Attribute Combination"},
+		"name without location": {map[string]tftypes.Value{"name": name}, "Invalid Attribute Combination"},
+		"arn and location":      {map[string]tftypes.Value{"arn": arn, "source_location_name": name}, "Invalid Attribute Combination"},
+		"empty tags":            {map[string]tftypes.Value{"tags": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{})}, "Invalid Attribute Value"},
+	} {
+		diags := validateDataSourceConfig(t, DataSourceVodSource(), testCase.config)
+		if testCase.expected == "" && len(diags) != 0 {
+			t.Errorf("%s: unexpected diagnostics %v", description, diags[0].Detail)
+		}
+		if testCase.expected != "" && (len(diags) == 0 || diags[0].Summary != testCase.expected) {
+			t.Errorf("%s: expected %q, got %v", description, testCase.expected, diags)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package awsmt
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-mediatailor/awsmt/models"
+)
+
+// @ADR
+// Context: The resources only know the region of the provider they are managed with, while the identity of a resource
+// must hold its region.
+// Decision: The identity is built from the ARN returned by MediaTailor, which holds the region and the names of the
+// resource.
+// Consequences: The identity of a resource is only set once its ARN is known, which is always the case after Create
+// and Read.
+func setIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, arn types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+	// the identity is nil when Terraform does not support resource identities
+	if identity == nil || arn.IsNull() || arn.IsUnknown() {
+		return diags
+	}
+
+	parsed, err := parseMediaTailorArn(arn.ValueString())
+	if err != nil {
+		diags.AddError("Error while setting the resource identity "+err.Error(), err.Error())
+		return diags
+	}
+
+	region := types.StringValue(parsed.Region)
+	switch parsed.ResourceType {
+	case arnResourceVodSource, arnResourceLiveSource:
+		return identity.Set(ctx, models.ContentSourceIdentityModel{
+			Region:             region,
+			SourceLocationName: types.StringValue(parsed.Names[0]),
+			Name:               types.StringValue(parsed.Names[1]),
+		})
+	default:
+		return identity.Set(ctx, models.NameIdentityModel{
+			Region: region,
+			Name:   types.StringValue(parsed.Names[0]),
+		})
+	}
+}
+
+// checkIdentityRegion makes sure that an imported resource lives in the region of the provider, as the provider cannot
+// read resources from other regions.
+func checkIdentityRegion(identityRegion types.String, region string) error {
+	if identityRegion.IsNull() || identityRegion.IsUnknown() || identityRegion.ValueString() == region {
+		return nil
+	}
+	return fmt.Errorf("the resource is in region %s, but the provider is configured for region %s", identityRegion.ValueString(), region)
+}
+
+func importStateByNameIdentity(ctx context.Context, region string, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var identity models.NameIdentityModel
+	resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := checkIdentityRegion(identity.Region, region); err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identity", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), identity.Name)...)
+}
+
+func importStateForContentSourcesIdentity(ctx context.Context, region string, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var identity models.ContentSourceIdentityModel
+	resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := checkIdentityRegion(identity.Region, region); err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identity", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("source_location_name"), identity.SourceLocationName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), identity.Name)...)
+}
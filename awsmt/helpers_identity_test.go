@@ -0,0 +1,61 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"terraform-provider-mediatailor/awsmt/models"
+	"testing"
+)
+
+func emptyIdentity(ctx context.Context, schema identityschema.Schema) *tfsdk.ResourceIdentity {
+	return &tfsdk.ResourceIdentity{
+		Schema: schema,
+		Raw:    tftypes.NewValue(schema.Type().TerraformType(ctx), nil),
+	}
+}
+
+func TestSetIdentity(t *testing.T) {
+	ctx := context.Background()
+
+	identity := emptyIdentity(ctx, nameIdentitySchema)
+	if diags := setIdentity(ctx, identity, types.StringValue("arn:aws:mediatailor:eu-west-1:123456789012:channel/test")); diags.HasError() {
+		t.Fatal(diags)
+	}
+	var nameIdentity models.NameIdentityModel
+	identity.Get(ctx, &nameIdentity)
+	if nameIdentity.Region.ValueString() != "eu-west-1" || nameIdentity.Name.ValueString() != "test" {
+		t.Errorf("unexpected identity %+v", nameIdentity)
+	}
+
+	identity = emptyIdentity(ctx, contentSourceIdentitySchema)
+	if diags := setIdentity(ctx, identity, types.StringValue("arn:aws:mediatailor:eu-central-1:123456789012:vodSource/location/vod")); diags.HasError() {
+		t.Fatal(diags)
+	}
+	var contentSourceIdentity models.ContentSourceIdentityModel
+	identity.Get(ctx, &contentSourceIdentity)
+	if contentSourceIdentity.Region.ValueString() != "eu-central-1" || contentSourceIdentity.SourceLocationName.ValueString() != "location" || contentSourceIdentity.Name.ValueString() != "vod" {
+		t.Errorf("unexpected identity %+v", contentSourceIdentity)
+	}
+
+	if diags := setIdentity(ctx, nil, types.StringValue("arn:aws:mediatailor:eu-central-1:123456789012:channel/test")); diags.HasError() {
+		t.Errorf("expected no error without identity support, got %v", diags)
+	}
+	if diags := setIdentity(ctx, emptyIdentity(ctx, nameIdentitySchema), types.StringValue("test")); !diags.HasError() {
+		t.Error("expected an error for an invalid ARN")
+	}
+}
+
+func TestCheckIdentityRegion(t *testing.T) {
+	if err := checkIdentityRegion(types.StringNull(), "eu-central-1"); err != nil {
+		t.Errorf("expected no error without region, got %v", err)
+	}
+	if err := checkIdentityRegion(types.StringValue("eu-central-1"), "eu-central-1"); err != nil {
+		t.Errorf("expected no error for the provider region, got %v", err)
+	}
+	if err := checkIdentityRegion(types.StringValue("us-east-1"), "eu-central-1"); err == nil {
+		t.Error("expected an error for another region")
+	}
+}
@@ -0,0 +1,108 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"iter"
+	"strings"
+	"terraform-provider-mediatailor/awsmt/models"
+)
+
+// matchesListFilter returns whether a listed resource matches the name prefix and the tags of the list configuration.
+func matchesListFilter(namePrefix types.String, tags map[string]string, name *string, resourceTags map[string]string) bool {
+	if name == nil || !strings.HasPrefix(*name, namePrefix.ValueString()) {
+		return false
+	}
+	return containsTags(resourceTags, tags)
+}
+
+// containsTags returns whether the tags of a resource contain all the given tags.
+func containsTags(resourceTags map[string]string, tags map[string]string) bool {
+	for k, v := range tags {
+		if value, ok := resourceTags[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
+
+// limitResults stops the stream of list results once the limit of the request is reached.
+func limitResults(limit int64, push func(list.ListResult) bool) func(list.ListResult) bool {
+	var count int64
+	return func(result list.ListResult) bool {
+		if !push(result) {
+			return false
+		}
+		count++
+		return limit <= 0 || count < limit
+	}
+}
+
+// listErrorResult is the result returned when a List operation fails.
+func listErrorResult(summary string, err error) list.ListResult {
+	var result list.ListResult
+	result.Diagnostics.AddError(summary+" "+err.Error(), err.Error())
+	return result
+}
+
+// listSourceLocationNames yields the name of the source location of the list configuration, or the names of every
+// source location when none is configured.
+func listSourceLocationNames(ctx context.Context, client mediaTailorClient, sourceLocationName types.String) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		if !sourceLocationName.IsNull() {
+			yield(sourceLocationName.ValueString(), nil)
+			return
+		}
+		paginator := mediatailor.NewListSourceLocationsPaginator(client, &mediatailor.ListSourceLocationsInput{})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				yield("", err)
+				return
+			}
+			for _, sourceLocation := range page.Items {
+				if !yield(*sourceLocation.SourceLocationName, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// @ADR
+// Context: The items returned by the List operations of MediaTailor do not always hold every attribute of the
+// resources, for example the policy of the channels, and their types differ from the outputs the resources are read
+// from.
+// Decision: The list resources describe each listed resource with the same operations as Read when the full resource
+// object is requested.
+// Consequences: Listing resources with `include_resource` performs one or more requests per resource, but the
+// generated configuration matches what the resources read.
+func newListResult(ctx context.Context, req list.ListRequest, displayName string, arn *string, read func() (any, error)) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = displayName
+	result.Diagnostics.Append(setIdentity(ctx, result.Identity, types.StringPointerValue(arn))...)
+	if !req.IncludeResource || result.Diagnostics.HasError() {
+		return result
+	}
+
+	model, err := read()
+	if err != nil {
+		result.Diagnostics.AddError("Error while reading "+displayName+" "+err.Error(), err.Error())
+		return result
+	}
+	result.Diagnostics.Append(result.Resource.Set(ctx, model)...)
+	return result
+}
+
+// getListFilter reads the list configuration, and makes the stream return the diagnostics when it is invalid.
+func getListFilter[T models.ListFilterModel | models.ContentSourceListFilterModel](ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) (T, bool) {
+	var filter T
+	diags := req.Config.Get(ctx, &filter)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return filter, false
+	}
+	return filter, true
+}
@@ -3,6 +3,7 @@ package awsmt
 import (
 	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
 	awsTypes "github.com/aws/aws-sdk-go-v2/service/mediatailor/types"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"terraform-provider-mediatailor/awsmt/models"
 )
@@ -12,8 +13,8 @@ func getCreateLiveSourceInput(model models.LiveSourceModel) *mediatailor.CreateL
 
 	input.HttpPackageConfigurations, input.LiveSourceName, input.SourceLocationName = getSharedLiveSourceInput(&model)
 
-	if len(model.Tags) > 0 {
-		input.Tags = model.Tags
+	if tags := tagsAllToMap(model.TagsAll); len(tags) > 0 {
+		input.Tags = tags
 	}
 
 	return &input
@@ -59,13 +60,13 @@ func readLiveSource(model models.LiveSourceModel, liveSource mediatailor.CreateL
 	}
 
 	if liveSource.CreationTime != nil {
-		model.CreationTime = types.StringValue(liveSource.CreationTime.String())
+		model.CreationTime = timetypes.NewRFC3339TimeValue(*liveSource.CreationTime)
 	}
 
 	model.HttpPackageConfigurations = readHttpPackageConfigurations(liveSource.HttpPackageConfigurations)
 
 	if liveSource.LastModifiedTime != nil {
-		model.LastModifiedTime = types.StringValue(liveSource.LastModifiedTime.String())
+		model.LastModifiedTime = timetypes.NewRFC3339TimeValue(*liveSource.LastModifiedTime)
 	}
 
 	if liveSource.LiveSourceName != nil {
@@ -79,6 +80,7 @@ func readLiveSource(model models.LiveSourceModel, liveSource mediatailor.CreateL
 	if len(liveSource.Tags) > 0 {
 		model.Tags = liveSource.Tags
 	}
+	model.TagsAll = tagsAllValue(liveSource.Tags)
 
 	return model
 }
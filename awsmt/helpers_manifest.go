@@ -0,0 +1,384 @@
+package awsmt
+
+import (
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// manifestAnalysis holds the timing of the segments and of the ad break opportunities of a manifest, in milliseconds
+// from the start of the manifest.
+type manifestAnalysis struct {
+	format                 string
+	variants               []string
+	segmentDurationsMillis []int64
+	totalDurationMillis    int64
+	adBreakOffsetsMillis   []int64
+}
+
+// @ADR
+// Context: The ad break opportunities of a vod source are only known once MediaTailor has ingested it, which is too
+// late to validate the ad breaks and the clip ranges of the programs referencing it.
+// Decision: The provider parses HLS playlists and DASH MPDs itself, and finds the ad break opportunities from the same
+// markers as MediaTailor: the EXT-X-CUE-OUT, EXT-X-SCTE35 and EXT-X-DATERANGE tags with a SCTE-35 out signal in HLS, and
+// the events of the SCTE-35 event streams in DASH.
+// Consequences: Only the manifest passed to the function is parsed: the media playlists of a multivariant playlist are
+// listed but not fetched, and the DASH segment durations are read from the first video adaptation set. The offsets are
+// rounded to the millisecond.
+func analyzeManifest(content string) (manifestAnalysis, error) {
+	trimmed := strings.TrimSpace(strings.TrimPrefix(content, "\ufeff"))
+	switch {
+	case strings.HasPrefix(trimmed, "#EXTM3U"):
+		return analyzeHlsPlaylist(trimmed)
+	case strings.HasPrefix(trimmed, "<"):
+		return analyzeDashMpd(trimmed)
+	default:
+		return manifestAnalysis{}, errors.New("expected an HLS playlist starting with #EXTM3U or a DASH MPD")
+	}
+}
+
+// secondsToMillis converts a duration in seconds to milliseconds.
+func secondsToMillis(seconds float64) int64 {
+	return int64(math.Round(seconds * 1000))
+}
+
+// scaleToMillis converts a time in the units of a DASH timescale to milliseconds.
+func scaleToMillis(value, timescale int64) int64 {
+	return (value*1000 + timescale/2) / timescale
+}
+
+// appendOffset appends an ad break offset, unless the previous marker was at the same offset, for example an
+// EXT-X-CUE-OUT tag and the EXT-X-DATERANGE tag of the same SCTE-35 signal.
+func appendOffset(offsets []int64, offset int64) []int64 {
+	if len(offsets) > 0 && offsets[len(offsets)-1] == offset {
+		return offsets
+	}
+	return append(offsets, offset)
+}
+
+func analyzeHlsPlaylist(content string) (manifestAnalysis, error) {
+	analysis := manifestAnalysis{format: "HLS", variants: []string{}, segmentDurationsMillis: []int64{}, adBreakOffsetsMillis: []int64{}}
+
+	var segmentDuration *int64
+	var streamInf bool
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanner.Buffer(nil, 1024*1024)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		tag, value, _ := strings.Cut(line, ":")
+		switch {
+		case line == "":
+		case tag == "#EXTINF":
+			seconds, err := strconv.ParseFloat(strings.TrimSpace(strings.SplitN(value, ",", 2)[0]), 64)
+			if err != nil || seconds < 0 {
+				return manifestAnalysis{}, fmt.Errorf("line %d: invalid segment duration %q", lineNumber, value)
+			}
+			millis := secondsToMillis(seconds)
+			segmentDuration = &millis
+		case tag == "#EXT-X-STREAM-INF":
+			streamInf = true
+		case tag == "#EXT-X-CUE-OUT",
+			tag == "#EXT-X-SCTE35" && strings.Contains(value, "CUE-OUT=YES"),
+			tag == "#EXT-X-DATERANGE" && strings.Contains(value, "SCTE35-OUT="):
+			analysis.adBreakOffsetsMillis = appendOffset(analysis.adBreakOffsetsMillis, analysis.totalDurationMillis)
+		case strings.HasPrefix(line, "#"):
+			// the other tags and the comments do not change the timing
+		case streamInf:
+			analysis.variants = append(analysis.variants, line)
+			streamInf = false
+		case segmentDuration != nil:
+			analysis.segmentDurationsMillis = append(analysis.segmentDurationsMillis, *segmentDuration)
+			analysis.totalDurationMillis += *segmentDuration
+			segmentDuration = nil
+		default:
+			return manifestAnalysis{}, fmt.Errorf("line %d: the URI %q follows no EXTINF or EXT-X-STREAM-INF tag", lineNumber, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return manifestAnalysis{}, err
+	}
+	if len(analysis.variants) > 0 && len(analysis.segmentDurationsMillis) > 0 {
+		return manifestAnalysis{}, errors.New("the playlist has both variant streams and media segments")
+	}
+	return analysis, nil
+}
+
+type dashMpd struct {
+	MediaPresentationDuration string       `xml:"mediaPresentationDuration,attr"`
+	Periods                   []dashPeriod `xml:"Period"`
+}
+
+type dashPeriod struct {
+	Start          string               `xml:"start,attr"`
+	Duration       string               `xml:"duration,attr"`
+	EventStreams   []dashEventStream    `xml:"EventStream"`
+	AdaptationSets []dashAdaptationSet  `xml:"AdaptationSet"`
+	Template       *dashSegmentTemplate `xml:"SegmentTemplate"`
+}
+
+type dashEventStream struct {
+	SchemeIdUri            string `xml:"schemeIdUri,attr"`
+	Timescale              *int64 `xml:"timescale,attr"`
+	PresentationTimeOffset int64  `xml:"presentationTimeOffset,attr"`
+	Events                 []struct {
+		PresentationTime int64 `xml:"presentationTime,attr"`
+	} `xml:"Event"`
+}
+
+type dashAdaptationSet struct {
+	ContentType     string               `xml:"contentType,attr"`
+	MimeType        string               `xml:"mimeType,attr"`
+	Template        *dashSegmentTemplate `xml:"SegmentTemplate"`
+	Representations []struct {
+		MimeType string               `xml:"mimeType,attr"`
+		Template *dashSegmentTemplate `xml:"SegmentTemplate"`
+	} `xml:"Representation"`
+}
+
+type dashSegmentTemplate struct {
+	Timescale              *int64 `xml:"timescale,attr"`
+	Duration               *int64 `xml:"duration,attr"`
+	PresentationTimeOffset int64  `xml:"presentationTimeOffset,attr"`
+	Timeline               *struct {
+		Segments []struct {
+			T *int64 `xml:"t,attr"`
+			D int64  `xml:"d,attr"`
+			R int64  `xml:"r,attr"`
+		} `xml:"S"`
+	} `xml:"SegmentTimeline"`
+}
+
+// isDashScte35Scheme reports whether an event stream carries SCTE-35 signals.
+func isDashScte35Scheme(schemeIdUri string) bool {
+	return strings.HasPrefix(schemeIdUri, "urn:scte:scte35:")
+}
+
+func analyzeDashMpd(content string) (manifestAnalysis, error) {
+	var mpd dashMpd
+	if err := xml.Unmarshal([]byte(content), &mpd); err != nil {
+		return manifestAnalysis{}, fmt.Errorf("invalid MPD: %w", err)
+	}
+	if len(mpd.Periods) == 0 {
+		return manifestAnalysis{}, errors.New("the MPD has no period")
+	}
+
+	analysis := manifestAnalysis{format: "DASH", variants: []string{}, segmentDurationsMillis: []int64{}, adBreakOffsetsMillis: []int64{}}
+	var presentationDuration *int64
+	if mpd.MediaPresentationDuration != "" {
+		millis, err := parseIsoDuration(mpd.MediaPresentationDuration)
+		if err != nil {
+			return manifestAnalysis{}, fmt.Errorf("mediaPresentationDuration: %w", err)
+		}
+		presentationDuration = &millis
+	}
+
+	var periodStart int64
+	for i, period := range mpd.Periods {
+		if period.Start != "" {
+			start, err := parseIsoDuration(period.Start)
+			if err != nil {
+				return manifestAnalysis{}, fmt.Errorf("period %d: start: %w", i+1, err)
+			}
+			periodStart = start
+		}
+
+		// the duration of a period is its duration attribute, or the time until the next period or the end of the MPD
+		var periodDuration *int64
+		switch {
+		case period.Duration != "":
+			duration, err := parseIsoDuration(period.Duration)
+			if err != nil {
+				return manifestAnalysis{}, fmt.Errorf("period %d: duration: %w", i+1, err)
+			}
+			periodDuration = &duration
+		case i+1 < len(mpd.Periods) && mpd.Periods[i+1].Start != "":
+			nextStart, err := parseIsoDuration(mpd.Periods[i+1].Start)
+			if err != nil {
+				return manifestAnalysis{}, fmt.Errorf("period %d: start: %w", i+2, err)
+			}
+			duration := nextStart - periodStart
+			periodDuration = &duration
+		case i+1 == len(mpd.Periods) && presentationDuration != nil:
+			duration := *presentationDuration - periodStart
+			periodDuration = &duration
+		}
+
+		for _, eventStream := range period.EventStreams {
+			if !isDashScte35Scheme(eventStream.SchemeIdUri) {
+				continue
+			}
+			timescale := int64(1)
+			if eventStream.Timescale != nil && *eventStream.Timescale > 0 {
+				timescale = *eventStream.Timescale
+			}
+			for _, event := range eventStream.Events {
+				analysis.adBreakOffsetsMillis = append(analysis.adBreakOffsetsMillis, periodStart+scaleToMillis(event.PresentationTime-eventStream.PresentationTimeOffset, timescale))
+			}
+		}
+
+		segments, err := dashSegmentDurations(period, periodDuration, maxDashSegments-len(analysis.segmentDurationsMillis))
+		if err != nil {
+			return manifestAnalysis{}, fmt.Errorf("period %d: %w", i+1, err)
+		}
+		analysis.segmentDurationsMillis = append(analysis.segmentDurationsMillis, segments...)
+
+		if periodDuration == nil {
+			var duration int64
+			for _, segment := range segments {
+				duration += segment
+			}
+			periodDuration = &duration
+		}
+		periodStart += *periodDuration
+	}
+
+	analysis.totalDurationMillis = periodStart
+	if presentationDuration != nil {
+		analysis.totalDurationMillis = *presentationDuration
+	}
+	slices.Sort(analysis.adBreakOffsetsMillis)
+	analysis.adBreakOffsetsMillis = slices.Compact(analysis.adBreakOffsetsMillis)
+	return analysis, nil
+}
+
+// dashSegmentTemplateOf returns the segment template of the first video adaptation set of a period, or of its first
+// adaptation set when none is a video one.
+func dashSegmentTemplateOf(period dashPeriod) *dashSegmentTemplate {
+	adaptationSets := slices.Clone(period.AdaptationSets)
+	slices.SortStableFunc(adaptationSets, func(a, b dashAdaptationSet) int {
+		isVideo := func(set dashAdaptationSet) bool {
+			if set.ContentType == "video" || strings.HasPrefix(set.MimeType, "video/") {
+				return true
+			}
+			return len(set.Representations) > 0 && strings.HasPrefix(set.Representations[0].MimeType, "video/")
+		}
+		switch {
+		case isVideo(a) && !isVideo(b):
+			return -1
+		case isVideo(b) && !isVideo(a):
+			return 1
+		default:
+			return 0
+		}
+	})
+	for _, set := range adaptationSets {
+		for _, representation := range set.Representations {
+			if representation.Template != nil {
+				return representation.Template
+			}
+		}
+		if set.Template != nil {
+			return set.Template
+		}
+	}
+	return period.Template
+}
+
+// maxDashSegments bounds the number of segments read from an MPD, so that a malformed repeat count or segment
+// duration fails the analysis instead of exhausting the memory.
+const maxDashSegments = 200000
+
+// dashSegmentDurations returns the durations of the segments of a period, in milliseconds, failing when there are more
+// than limit segments or when the segments go past the end of the period.
+func dashSegmentDurations(period dashPeriod, periodDuration *int64, limit int) ([]int64, error) {
+	template := dashSegmentTemplateOf(period)
+	if template == nil {
+		return nil, errors.New("no SegmentTemplate found, the segment durations cannot be read")
+	}
+	timescale := int64(1)
+	if template.Timescale != nil && *template.Timescale > 0 {
+		timescale = *template.Timescale
+	}
+
+	tooManySegments := fmt.Errorf("the MPD has more than %d segments", maxDashSegments)
+	var durations []int64
+	switch {
+	case template.Timeline != nil:
+		// the segments are converted from their start times, so that the rounding errors do not add up
+		time := template.PresentationTimeOffset
+		var millis int64
+		segments := template.Timeline.Segments
+		for i, s := range segments {
+			if s.T != nil {
+				// an explicit start time skips the gaps in the timeline
+				time = *s.T
+				millis = scaleToMillis(time-template.PresentationTimeOffset, timescale)
+			}
+			if s.D <= 0 {
+				return nil, fmt.Errorf("invalid segment duration %d in the SegmentTimeline", s.D)
+			}
+			repeat := s.R
+			if repeat < 0 {
+				// a negative repeat count repeats the segment until the next S element or the end of the period
+				var end int64
+				switch {
+				case i+1 < len(segments) && segments[i+1].T != nil:
+					end = *segments[i+1].T
+				case periodDuration != nil:
+					end = template.PresentationTimeOffset + *periodDuration*timescale/1000
+				default:
+					return nil, errors.New("a segment repeats until the end of a period without duration")
+				}
+				repeat = (end-time+s.D-1)/s.D - 1
+			}
+			// the last segment starts at the end of the period at the latest, give or take a segment for the rounding
+			if periodDuration != nil && repeat > (template.PresentationTimeOffset+*periodDuration*timescale/1000-time)/s.D+1 {
+				return nil, errors.New("the SegmentTimeline goes past the end of the period")
+			}
+			if repeat >= int64(limit-len(durations)) {
+				return nil, tooManySegments
+			}
+			for range repeat + 1 {
+				time += s.D
+				end := scaleToMillis(time-template.PresentationTimeOffset, timescale)
+				durations = append(durations, end-millis)
+				millis = end
+			}
+		}
+	case template.Duration != nil && *template.Duration > 0:
+		if periodDuration == nil {
+			return nil, errors.New("the segments have a fixed duration in a period without duration")
+		}
+		segmentDuration := scaleToMillis(*template.Duration, timescale)
+		if segmentDuration <= 0 {
+			return nil, fmt.Errorf("the segment duration %d/%d s is shorter than a millisecond", *template.Duration, timescale)
+		}
+		if (*periodDuration+segmentDuration-1)/segmentDuration > int64(limit) {
+			return nil, tooManySegments
+		}
+		for remaining := *periodDuration; remaining > 0; remaining -= segmentDuration {
+			durations = append(durations, min(segmentDuration, remaining))
+		}
+	default:
+		return nil, errors.New("the SegmentTemplate has neither a SegmentTimeline nor a duration")
+	}
+	return durations, nil
+}
+
+var isoDurationRegexp = regexp.MustCompile(`^P(?:(\d+(?:\.\d+)?)D)?(?:T(?:(\d+(?:\.\d+)?)H)?(?:(\d+(?:\.\d+)?)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// parseIsoDuration parses the ISO 8601 durations used by the MPDs, for example PT1M30.5S, to milliseconds.
+func parseIsoDuration(duration string) (int64, error) {
+	matches := isoDurationRegexp.FindStringSubmatch(strings.TrimSpace(duration))
+	if matches == nil || duration == "P" || strings.HasSuffix(duration, "T") {
+		return 0, fmt.Errorf("invalid duration %q", duration)
+	}
+	var seconds float64
+	for i, unit := range []float64{86400, 3600, 60, 1} {
+		if matches[i+1] == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(matches[i+1], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", duration)
+		}
+		seconds += value * unit
+	}
+	return secondsToMillis(seconds), nil
+}
@@ -0,0 +1,180 @@
+package awsmt
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+const testHlsMediaPlaylist = `#EXTM3U
+#EXT-X-VERSION:3
+#EXT-X-TARGETDURATION:7
+#EXTINF:6.006,
+segment0.ts
+#EXTINF:6.006,
+segment1.ts
+#EXT-X-CUE-OUT:30
+#EXT-X-DATERANGE:ID="1",START-DATE="2024-05-13T09:12:45Z",SCTE35-OUT=0xFC30
+#EXTINF:4.5,
+segment2.ts
+#EXT-X-CUE-IN
+#EXTINF:6,
+segment3.ts
+#EXT-X-SCTE35:CUE="/DAl",CUE-OUT=YES
+#EXTINF:2.002,
+segment4.ts
+#EXT-X-ENDLIST
+`
+
+const testHlsMultivariantPlaylist = `#EXTM3U
+#EXT-X-STREAM-INF:BANDWIDTH=1280000,RESOLUTION=640x360
+low/index.m3u8
+#EXT-X-STREAM-INF:BANDWIDTH=2560000,RESOLUTION=1280x720
+high/index.m3u8
+`
+
+const testDashMpd = `<?xml version="1.0" encoding="UTF-8"?>
+<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" type="static" mediaPresentationDuration="PT30S">
+  <Period id="1" start="PT0S">
+    <EventStream schemeIdUri="urn:scte:scte35:2013:xml" timescale="90000">
+      <Event presentationTime="900000" duration="1350000" id="1"/>
+    </EventStream>
+    <EventStream schemeIdUri="urn:example:other" timescale="1">
+      <Event presentationTime="3"/>
+    </EventStream>
+    <AdaptationSet contentType="audio" mimeType="audio/mp4">
+      <SegmentTemplate timescale="48000" duration="96000" media="audio_$Number$.mp4"/>
+      <Representation id="audio" bandwidth="128000"/>
+    </AdaptationSet>
+    <AdaptationSet mimeType="video/mp4">
+      <Representation id="video" bandwidth="2000000">
+        <SegmentTemplate timescale="90000" media="video_$Time$.mp4">
+          <SegmentTimeline>
+            <S t="0" d="540000" r="2"/>
+            <S d="270000"/>
+          </SegmentTimeline>
+        </SegmentTemplate>
+      </Representation>
+    </AdaptationSet>
+  </Period>
+  <Period id="2" start="PT21S">
+    <EventStream schemeIdUri="urn:scte:scte35:2014:xml+bin" timescale="90000" presentationTimeOffset="1800000">
+      <Event presentationTime="1980000"/>
+    </EventStream>
+    <AdaptationSet contentType="video">
+      <SegmentTemplate timescale="1000" duration="4000" media="video_$Number$.mp4"/>
+      <Representation id="video" bandwidth="2000000"/>
+    </AdaptationSet>
+  </Period>
+</MPD>
+`
+
+func TestAnalyzeHlsMediaPlaylist(t *testing.T) {
+	analysis, err := analyzeManifest(testHlsMediaPlaylist)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if analysis.format != "HLS" || len(analysis.variants) != 0 {
+		t.Errorf("unexpected format %s and variants %v", analysis.format, analysis.variants)
+	}
+	if expected := []int64{6006, 6006, 4500, 6000, 2002}; !slices.Equal(analysis.segmentDurationsMillis, expected) {
+		t.Errorf("expected the segment durations %v, got %v", expected, analysis.segmentDurationsMillis)
+	}
+	if analysis.totalDurationMillis != 24514 {
+		t.Errorf("expected a total duration of 24514ms, got %d", analysis.totalDurationMillis)
+	}
+	// the EXT-X-CUE-OUT and EXT-X-DATERANGE tags of the first break are a single opportunity
+	if expected := []int64{12012, 22512}; !slices.Equal(analysis.adBreakOffsetsMillis, expected) {
+		t.Errorf("expected the ad break offsets %v, got %v", expected, analysis.adBreakOffsetsMillis)
+	}
+}
+
+func TestAnalyzeHlsMultivariantPlaylist(t *testing.T) {
+	analysis, err := analyzeManifest(testHlsMultivariantPlaylist)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(analysis.variants, []string{"low/index.m3u8", "high/index.m3u8"}) {
+		t.Errorf("unexpected variants %v", analysis.variants)
+	}
+	if len(analysis.segmentDurationsMillis) != 0 || analysis.totalDurationMillis != 0 || len(analysis.adBreakOffsetsMillis) != 0 {
+		t.Errorf("expected no segment, got %+v", analysis)
+	}
+}
+
+func TestAnalyzeDashMpd(t *testing.T) {
+	analysis, err := analyzeManifest(testDashMpd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if analysis.format != "DASH" {
+		t.Errorf("unexpected format %s", analysis.format)
+	}
+	// the segments of the video adaptation sets, the last segment of the second period being cut by the end of the MPD
+	if expected := []int64{6000, 6000, 6000, 3000, 4000, 4000, 1000}; !slices.Equal(analysis.segmentDurationsMillis, expected) {
+		t.Errorf("expected the segment durations %v, got %v", expected, analysis.segmentDurationsMillis)
+	}
+	if analysis.totalDurationMillis != 30000 {
+		t.Errorf("expected a total duration of 30000ms, got %d", analysis.totalDurationMillis)
+	}
+	// the event of the second period is 2s after its start, once its presentationTimeOffset is subtracted
+	if expected := []int64{10000, 23000}; !slices.Equal(analysis.adBreakOffsetsMillis, expected) {
+		t.Errorf("expected the ad break offsets %v, got %v", expected, analysis.adBreakOffsetsMillis)
+	}
+}
+
+func TestAnalyzeDashSegmentTimelineRepeatedUntilTheEnd(t *testing.T) {
+	analysis, err := analyzeManifest(`<MPD mediaPresentationDuration="PT10S"><Period>
+		<AdaptationSet mimeType="video/mp4"><SegmentTemplate timescale="1000">
+			<SegmentTimeline><S t="0" d="4000" r="-1"/></SegmentTimeline>
+		</SegmentTemplate></AdaptationSet>
+	</Period></MPD>`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []int64{4000, 4000, 4000}; !slices.Equal(analysis.segmentDurationsMillis, expected) {
+		t.Errorf("expected the segment durations %v, got %v", expected, analysis.segmentDurationsMillis)
+	}
+}
+
+func TestAnalyzeManifestErrors(t *testing.T) {
+	for _, tc := range []struct {
+		manifest string
+		expected string
+	}{
+		{"not a manifest", "expected an HLS playlist starting with #EXTM3U or a DASH MPD"},
+		{"#EXTM3U\n#EXTINF:abc,\nsegment.ts\n", `line 2: invalid segment duration "abc,"`},
+		{"#EXTM3U\nsegment.ts\n", `line 2: the URI "segment.ts" follows no EXTINF`},
+		{"<MPD><Period>", "invalid MPD"},
+		{"<MPD></MPD>", "the MPD has no period"},
+		{`<MPD mediaPresentationDuration="1 hour"><Period/></MPD>`, `mediaPresentationDuration: invalid duration "1 hour"`},
+		{`<MPD mediaPresentationDuration="PT1S"><Period><AdaptationSet/></Period></MPD>`, "period 1: no SegmentTemplate found"},
+		{`<MPD mediaPresentationDuration="PT1S"><Period><SegmentTemplate timescale="90000" duration="1"/></Period></MPD>`, "period 1: the segment duration 1/90000 s is shorter than a millisecond"},
+		{`<MPD mediaPresentationDuration="PT10S"><Period><SegmentTemplate timescale="1000"><SegmentTimeline><S d="1" r="300000000"/></SegmentTimeline></SegmentTemplate></Period></MPD>`, "period 1: the SegmentTimeline goes past the end of the period"},
+		{`<MPD><Period><SegmentTemplate timescale="1000"><SegmentTimeline><S d="1" r="300000000"/></SegmentTimeline></SegmentTemplate></Period></MPD>`, "period 1: the MPD has more than 200000 segments"},
+		{`<MPD mediaPresentationDuration="PT100000S"><Period><SegmentTemplate timescale="1000" duration="1"/></Period></MPD>`, "period 1: the MPD has more than 200000 segments"},
+	} {
+		_, err := analyzeManifest(tc.manifest)
+		if err == nil || !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("expected an error containing %q for %q, got %v", tc.expected, tc.manifest, err)
+		}
+	}
+}
+
+func TestParseIsoDuration(t *testing.T) {
+	for duration, expected := range map[string]int64{
+		"PT0S":       0,
+		"PT1M30.5S":  90500,
+		"PT1H":       3600000,
+		"P1DT0.001S": 86400001,
+	} {
+		if millis, err := parseIsoDuration(duration); err != nil || millis != expected {
+			t.Errorf("expected %s to be %dms, got %d and %v", duration, expected, millis, err)
+		}
+	}
+	for _, duration := range []string{"P", "PT", "1S", "PT-1S"} {
+		if _, err := parseIsoDuration(duration); err == nil {
+			t.Errorf("expected an error for %q", duration)
+		}
+	}
+}
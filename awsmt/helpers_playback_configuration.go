@@ -16,6 +16,11 @@ type putPlaybackConfigurationInputBuilder struct {
 	input *mediatailor.PutPlaybackConfigurationInput
 }
 
+// hlsConfigurationAttributeTypes are the attributes of the computed hls_configuration attribute.
+var hlsConfigurationAttributeTypes = map[string]attr.Type{
+	"manifest_endpoint_prefix": types.StringType,
+}
+
 type putPlaybackConfigurationModelbuilder struct {
 	model      *models.PlaybackConfigurationModel
 	output     mediatailor.PutPlaybackConfigurationOutput
@@ -161,8 +166,8 @@ func (i *putPlaybackConfigurationInputBuilder) addOptionalFieldsToInput() {
 		i.input.SlateAdUrl = i.model.SlateAdUrl
 	}
 
-	if i.model.Tags != nil {
-		i.input.Tags = i.model.Tags
+	if tags := tagsAllToMap(i.model.TagsAll); tags != nil {
+		i.input.Tags = tags
 	}
 
 	if i.model.TranscodeProfileName != nil {
@@ -298,8 +303,11 @@ func (m *putPlaybackConfigurationModelbuilder) addOptionalFieldsToModel() {
 		m.model.ConfigurationAliases = m.output.ConfigurationAliases
 	}
 
+	m.model.HlsConfiguration = types.ObjectNull(hlsConfigurationAttributeTypes)
 	if m.output.HlsConfiguration != nil && m.output.HlsConfiguration.ManifestEndpointPrefix != nil {
-		m.model.HlsConfigurationManifestEndpointPrefix = types.StringValue(*m.output.HlsConfiguration.ManifestEndpointPrefix)
+		m.model.HlsConfiguration = types.ObjectValueMust(hlsConfigurationAttributeTypes, map[string]attr.Value{
+			"manifest_endpoint_prefix": types.StringValue(*m.output.HlsConfiguration.ManifestEndpointPrefix),
+		})
 	}
 
 	if m.output.LogConfiguration != nil {
@@ -341,10 +349,11 @@ func (m *putPlaybackConfigurationModelbuilder) addOptionalFieldsToModel() {
 	if len(m.output.Tags) > 0 {
 		m.model.Tags = m.output.Tags
 	}
+	m.model.TagsAll = tagsAllValue(m.output.Tags)
 }
 
 // Log percentage & strategies configuration helper
-func configureLogging(client *mediatailor.Client, model models.PlaybackConfigurationModel) (*mediatailor.GetPlaybackConfigurationOutput, error) {
+func configureLogging(client mediaTailorClient, model models.PlaybackConfigurationModel) (*mediatailor.GetPlaybackConfigurationOutput, error) {
 	input := &mediatailor.ConfigureLogsForPlaybackConfigurationInput{
 		PlaybackConfigurationName: model.Name,
 		PercentEnabled:            int32(model.LogConfigurationPercentEnabled.ValueInt64()),
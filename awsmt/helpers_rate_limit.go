@@ -0,0 +1,93 @@
+package awsmt
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/smithy-go/middleware"
+	"golang.org/x/time/rate"
+	"math"
+	"strings"
+)
+
+// apiFamily groups the MediaTailor operations sharing a rate limit.
+type apiFamily string
+
+const (
+	apiFamilyRead  apiFamily = "read"
+	apiFamilyWrite apiFamily = "write"
+	apiFamilyTag   apiFamily = "tag"
+)
+
+// apiFamilyOf returns the family of a MediaTailor operation.
+func apiFamilyOf(operation string) apiFamily {
+	switch {
+	case operation == "TagResource" || operation == "UntagResource" || operation == "ListTagsForResource":
+		return apiFamilyTag
+	case strings.HasPrefix(operation, "Describe") || strings.HasPrefix(operation, "Get") || strings.HasPrefix(operation, "List"):
+		return apiFamilyRead
+	default:
+		return apiFamilyWrite
+	}
+}
+
+// @ADR
+// Context: Applying configurations with hundreds of objects and a high parallelism exceeds the TPS quotas of
+// MediaTailor, and the retries with backoff of the throttled requests stretch the applies to tens of minutes.
+// Decision: The provider limits the requests on the client side, with a token bucket for each family of operations
+// (read, write and tag) and a limit of requests in flight, in a middleware of the MediaTailor client. The client is
+// created once by the provider and shared by every resource and data source through the provider data.
+// Consequences: Every attempt of a request, retries included, waits for a token. The limits are disabled unless they
+// are configured in the provider block, and do not apply to the in-memory fake used by the tests.
+type apiLimiter struct {
+	buckets map[apiFamily]*rate.Limiter
+	// inFlight holds a token for each request in flight, it is nil when the concurrency is not limited.
+	inFlight chan struct{}
+}
+
+// newApiLimiter returns a limiter allowing the given number of requests per second in each API family, and the given
+// number of requests in flight. A limit of 0 disables it.
+func newApiLimiter(requestsPerSecond float64, maxConcurrentRequests int) *apiLimiter {
+	limiter := &apiLimiter{buckets: map[apiFamily]*rate.Limiter{}}
+	if requestsPerSecond > 0 {
+		// the buckets hold the tokens of a second, so that short bursts are not delayed
+		burst := int(math.Max(1, math.Ceil(requestsPerSecond)))
+		for _, family := range []apiFamily{apiFamilyRead, apiFamilyWrite, apiFamilyTag} {
+			limiter.buckets[family] = rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+		}
+	}
+	if maxConcurrentRequests > 0 {
+		limiter.inFlight = make(chan struct{}, maxConcurrentRequests)
+	}
+	return limiter
+}
+
+// wait blocks until the operation can be sent, and returns the function to call once its response is received.
+func (l *apiLimiter) wait(ctx context.Context, operation string) (func(), error) {
+	if bucket, ok := l.buckets[apiFamilyOf(operation)]; ok {
+		if err := bucket.Wait(ctx); err != nil {
+			return nil, fmt.Errorf("rate limit of the %s operations: %w", apiFamilyOf(operation), err)
+		}
+	}
+	if l.inFlight == nil {
+		return func() {}, nil
+	}
+	select {
+	case l.inFlight <- struct{}{}:
+		return func() { <-l.inFlight }, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("limit of concurrent requests: %w", ctx.Err())
+	}
+}
+
+// addMiddleware adds the limiter to the stack of a MediaTailor operation, after the retry middleware so that every
+// attempt is limited, and before the signing of the request.
+func (l *apiLimiter) addMiddleware(stack *middleware.Stack) error {
+	return stack.Finalize.Insert(middleware.FinalizeMiddlewareFunc("ApiLimiter", func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+		release, err := l.wait(ctx, middleware.GetOperationName(ctx))
+		if err != nil {
+			return middleware.FinalizeOutput{}, middleware.Metadata{}, err
+		}
+		defer release()
+		return next.HandleFinalize(ctx, in)
+	}), "Retry", middleware.After)
+}
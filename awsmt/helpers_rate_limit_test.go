@@ -0,0 +1,131 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
+	"github.com/aws/smithy-go/middleware"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestApiFamilyOf(t *testing.T) {
+	for operation, expected := range map[string]apiFamily{
+		"DescribeChannel":          apiFamilyRead,
+		"GetPlaybackConfiguration": apiFamilyRead,
+		"ListVodSources":           apiFamilyRead,
+		"CreateVodSource":          apiFamilyWrite,
+		"PutChannelPolicy":         apiFamilyWrite,
+		"StopChannel":              apiFamilyWrite,
+		"TagResource":              apiFamilyTag,
+		"UntagResource":            apiFamilyTag,
+		"ListTagsForResource":      apiFamilyTag,
+	} {
+		if family := apiFamilyOf(operation); family != expected {
+			t.Errorf("expected %s to be a %s operation, got %s", operation, expected, family)
+		}
+	}
+}
+
+func TestApiLimiterRate(t *testing.T) {
+	limiter := newApiLimiter(20, 0)
+	ctx := context.Background()
+
+	start := time.Now()
+	// the first second of tokens is available at once, the following requests wait for 50ms each
+	for range 25 {
+		release, err := limiter.wait(ctx, "CreateVodSource")
+		if err != nil {
+			t.Fatal(err)
+		}
+		release()
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("expected the write operations to be limited, took %s", elapsed)
+	}
+
+	// the other families have their own bucket
+	start = time.Now()
+	if _, err := limiter.wait(ctx, "DescribeVodSource"); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 20*time.Millisecond {
+		t.Errorf("expected the read operations not to wait for the write operations, took %s", elapsed)
+	}
+}
+
+func TestApiLimiterConcurrency(t *testing.T) {
+	limiter := newApiLimiter(0, 2)
+	var inFlight, maxInFlight atomic.Int32
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := limiter.wait(context.Background(), "DescribeChannel")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			current := inFlight.Add(1)
+			for {
+				previous := maxInFlight.Load()
+				if current <= previous || maxInFlight.CompareAndSwap(previous, current) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			inFlight.Add(-1)
+			release()
+		}()
+	}
+	wg.Wait()
+	if maxInFlight.Load() != 2 {
+		t.Errorf("expected 2 requests in flight at most, got %d", maxInFlight.Load())
+	}
+}
+
+// stubHttpClient answers every request with an empty list.
+type stubHttpClient struct {
+	requests atomic.Int32
+}
+
+func (c *stubHttpClient) Do(*http.Request) (*http.Response, error) {
+	c.requests.Add(1)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`{"Items": []}`)),
+	}, nil
+}
+
+func TestApiLimiterMiddleware(t *testing.T) {
+	httpClient := &stubHttpClient{}
+	limiter := newApiLimiter(0.1, 0)
+	client := mediatailor.New(mediatailor.Options{
+		Region:      "eu-central-1",
+		Credentials: aws.AnonymousCredentials{},
+		HTTPClient:  httpClient,
+		APIOptions:  []func(*middleware.Stack) error{limiter.addMiddleware},
+	})
+
+	if _, err := client.ListChannels(context.Background(), &mediatailor.ListChannelsInput{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the bucket is empty for the next 10 seconds, which exceeds the deadline of the request
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := client.ListChannels(ctx, &mediatailor.ListChannelsInput{})
+	if err == nil || !strings.Contains(err.Error(), "rate limit of the read operations") {
+		t.Errorf("expected the request to be limited, got %v", err)
+	}
+	if requests := httpClient.requests.Load(); requests != 1 {
+		t.Errorf("expected a single request to be sent, got %d", requests)
+	}
+}
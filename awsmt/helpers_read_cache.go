@@ -0,0 +1,225 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
+	awsTypes "github.com/aws/aws-sdk-go-v2/service/mediatailor/types"
+	"sync"
+)
+
+// @ADR
+// Context: Refreshing a workspace with thousands of vod sources made one DescribeVodSource call per resource, which
+// took tens of minutes with the TPS quotas of MediaTailor.
+// Decision: When the read cache is enabled in the provider block, the first read of a vod or live source lists every
+// source of its source location with the paginated List operation, and the following reads of the same source location
+// are served from memory. The cache wraps the MediaTailor client shared through the provider data, so that every write
+// made through it drops the cached sources of the source location it changes, and the tag writes drop every entry.
+// Consequences: The cache lives as long as the provider process, which is a single Terraform operation. The List
+// operations do not return the ad break opportunities of the vod sources, so the reads served from the cache keep the
+// ones found in the state, and the sources read without prior state, for example when imported, are described.
+// Changes made outside Terraform during the operation are not seen.
+type readCache struct {
+	mediaTailorClient
+
+	mu          sync.Mutex
+	vodSources  map[string]*cachedSources[awsTypes.VodSource]
+	liveSources map[string]*cachedSources[awsTypes.LiveSource]
+}
+
+// cachedSources holds the sources of a source location, keyed by name. done is closed once they are listed, and
+// invalidated is set when the sources of the source location were dropped while they were listed.
+type cachedSources[T any] struct {
+	done        chan struct{}
+	sources     map[string]T
+	err         error
+	invalidated bool
+}
+
+func newReadCache(client mediaTailorClient) *readCache {
+	return &readCache{
+		mediaTailorClient: client,
+		vodSources:        map[string]*cachedSources[awsTypes.VodSource]{},
+		liveSources:       map[string]*cachedSources[awsTypes.LiveSource]{},
+	}
+}
+
+// getSources returns the cached sources of a source location, listing them if they are not cached yet. The concurrent
+// reads of a source location wait for a single listing, and a failed listing is not cached. A listing made while the
+// sources of the source location are dropped may miss the write that dropped them, so its waiters list them again.
+// The listing does not stop when the read that started it is canceled, so that it does not fail the other reads.
+func getSources[T any](ctx context.Context, c *readCache, entries map[string]*cachedSources[T], sourceLocationName string, list func(context.Context) (map[string]T, error)) (map[string]T, error) {
+	for {
+		c.mu.Lock()
+		entry, ok := entries[sourceLocationName]
+		if !ok {
+			entry = &cachedSources[T]{done: make(chan struct{})}
+			entries[sourceLocationName] = entry
+			go listSources(context.WithoutCancel(ctx), c, entries, sourceLocationName, entry, list)
+		}
+		c.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-entry.done:
+		}
+		if !entry.invalidated {
+			return entry.sources, entry.err
+		}
+	}
+}
+
+// listSources lists the sources of an entry of the cache, and drops the entry when the listing failed.
+func listSources[T any](ctx context.Context, c *readCache, entries map[string]*cachedSources[T], sourceLocationName string, entry *cachedSources[T], list func(context.Context) (map[string]T, error)) {
+	sources, err := list(ctx)
+
+	c.mu.Lock()
+	entry.sources, entry.err = sources, err
+	entry.invalidated = entries[sourceLocationName] != entry
+	if err != nil && !entry.invalidated {
+		delete(entries, sourceLocationName)
+	}
+	c.mu.Unlock()
+	close(entry.done)
+}
+
+// describeVodSource returns the vod source from the cache, and whether it was found there. It describes the vod source
+// when the cache is disabled or does not hold it, for example when it was created during the operation.
+func (c *readCache) describeVodSource(ctx context.Context, client mediaTailorClient, input *mediatailor.DescribeVodSourceInput) (*mediatailor.DescribeVodSourceOutput, bool, error) {
+	if c == nil {
+		output, err := client.DescribeVodSource(ctx, input)
+		return output, false, err
+	}
+	sources, err := getSources(ctx, c, c.vodSources, *input.SourceLocationName, func(ctx context.Context) (map[string]awsTypes.VodSource, error) {
+		sources := map[string]awsTypes.VodSource{}
+		paginator := mediatailor.NewListVodSourcesPaginator(c.mediaTailorClient, &mediatailor.ListVodSourcesInput{SourceLocationName: input.SourceLocationName})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, err
+			}
+			for _, vodSource := range page.Items {
+				sources[*vodSource.VodSourceName] = vodSource
+			}
+		}
+		return sources, nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	vodSource, ok := sources[*input.VodSourceName]
+	if !ok {
+		output, err := c.mediaTailorClient.DescribeVodSource(ctx, input)
+		return output, false, err
+	}
+	return &mediatailor.DescribeVodSourceOutput{
+		Arn:                       vodSource.Arn,
+		CreationTime:              vodSource.CreationTime,
+		HttpPackageConfigurations: vodSource.HttpPackageConfigurations,
+		LastModifiedTime:          vodSource.LastModifiedTime,
+		SourceLocationName:        vodSource.SourceLocationName,
+		Tags:                      vodSource.Tags,
+		VodSourceName:             vodSource.VodSourceName,
+	}, true, nil
+}
+
+// describeLiveSource returns the live source from the cache, or describes it when the cache is disabled or does not
+// hold it.
+func (c *readCache) describeLiveSource(ctx context.Context, client mediaTailorClient, input *mediatailor.DescribeLiveSourceInput) (*mediatailor.DescribeLiveSourceOutput, error) {
+	if c == nil {
+		return client.DescribeLiveSource(ctx, input)
+	}
+	sources, err := getSources(ctx, c, c.liveSources, *input.SourceLocationName, func(ctx context.Context) (map[string]awsTypes.LiveSource, error) {
+		sources := map[string]awsTypes.LiveSource{}
+		paginator := mediatailor.NewListLiveSourcesPaginator(c.mediaTailorClient, &mediatailor.ListLiveSourcesInput{SourceLocationName: input.SourceLocationName})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, err
+			}
+			for _, liveSource := range page.Items {
+				sources[*liveSource.LiveSourceName] = liveSource
+			}
+		}
+		return sources, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	liveSource, ok := sources[*input.LiveSourceName]
+	if !ok {
+		return c.mediaTailorClient.DescribeLiveSource(ctx, input)
+	}
+	return &mediatailor.DescribeLiveSourceOutput{
+		Arn:                       liveSource.Arn,
+		CreationTime:              liveSource.CreationTime,
+		HttpPackageConfigurations: liveSource.HttpPackageConfigurations,
+		LastModifiedTime:          liveSource.LastModifiedTime,
+		LiveSourceName:            liveSource.LiveSourceName,
+		SourceLocationName:        liveSource.SourceLocationName,
+		Tags:                      liveSource.Tags,
+	}, nil
+}
+
+// invalidate drops the cached sources of a source location, or of every source location when the name is nil.
+func (c *readCache) invalidate(sourceLocationName *string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if sourceLocationName == nil {
+		clear(c.vodSources)
+		clear(c.liveSources)
+		return
+	}
+	delete(c.vodSources, *sourceLocationName)
+	delete(c.liveSources, *sourceLocationName)
+}
+
+func (c *readCache) UpdateSourceLocation(ctx context.Context, params *mediatailor.UpdateSourceLocationInput, optFns ...func(*mediatailor.Options)) (*mediatailor.UpdateSourceLocationOutput, error) {
+	defer c.invalidate(params.SourceLocationName)
+	return c.mediaTailorClient.UpdateSourceLocation(ctx, params, optFns...)
+}
+
+func (c *readCache) DeleteSourceLocation(ctx context.Context, params *mediatailor.DeleteSourceLocationInput, optFns ...func(*mediatailor.Options)) (*mediatailor.DeleteSourceLocationOutput, error) {
+	defer c.invalidate(params.SourceLocationName)
+	return c.mediaTailorClient.DeleteSourceLocation(ctx, params, optFns...)
+}
+
+func (c *readCache) CreateVodSource(ctx context.Context, params *mediatailor.CreateVodSourceInput, optFns ...func(*mediatailor.Options)) (*mediatailor.CreateVodSourceOutput, error) {
+	defer c.invalidate(params.SourceLocationName)
+	return c.mediaTailorClient.CreateVodSource(ctx, params, optFns...)
+}
+
+func (c *readCache) UpdateVodSource(ctx context.Context, params *mediatailor.UpdateVodSourceInput, optFns ...func(*mediatailor.Options)) (*mediatailor.UpdateVodSourceOutput, error) {
+	defer c.invalidate(params.SourceLocationName)
+	return c.mediaTailorClient.UpdateVodSource(ctx, params, optFns...)
+}
+
+func (c *readCache) DeleteVodSource(ctx context.Context, params *mediatailor.DeleteVodSourceInput, optFns ...func(*mediatailor.Options)) (*mediatailor.DeleteVodSourceOutput, error) {
+	defer c.invalidate(params.SourceLocationName)
+	return c.mediaTailorClient.DeleteVodSource(ctx, params, optFns...)
+}
+
+func (c *readCache) CreateLiveSource(ctx context.Context, params *mediatailor.CreateLiveSourceInput, optFns ...func(*mediatailor.Options)) (*mediatailor.CreateLiveSourceOutput, error) {
+	defer c.invalidate(params.SourceLocationName)
+	return c.mediaTailorClient.CreateLiveSource(ctx, params, optFns...)
+}
+
+func (c *readCache) UpdateLiveSource(ctx context.Context, params *mediatailor.UpdateLiveSourceInput, optFns ...func(*mediatailor.Options)) (*mediatailor.UpdateLiveSourceOutput, error) {
+	defer c.invalidate(params.SourceLocationName)
+	return c.mediaTailorClient.UpdateLiveSource(ctx, params, optFns...)
+}
+
+func (c *readCache) DeleteLiveSource(ctx context.Context, params *mediatailor.DeleteLiveSourceInput, optFns ...func(*mediatailor.Options)) (*mediatailor.DeleteLiveSourceOutput, error) {
+	defer c.invalidate(params.SourceLocationName)
+	return c.mediaTailorClient.DeleteLiveSource(ctx, params, optFns...)
+}
+
+func (c *readCache) TagResource(ctx context.Context, params *mediatailor.TagResourceInput, optFns ...func(*mediatailor.Options)) (*mediatailor.TagResourceOutput, error) {
+	defer c.invalidate(nil)
+	return c.mediaTailorClient.TagResource(ctx, params, optFns...)
+}
+
+func (c *readCache) UntagResource(ctx context.Context, params *mediatailor.UntagResourceInput, optFns ...func(*mediatailor.Options)) (*mediatailor.UntagResourceOutput, error) {
+	defer c.invalidate(nil)
+	return c.mediaTailorClient.UntagResource(ctx, params, optFns...)
+}
@@ -0,0 +1,235 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
+	awsTypes "github.com/aws/aws-sdk-go-v2/service/mediatailor/types"
+	"sync"
+	"sync/atomic"
+	"terraform-provider-mediatailor/awsmt/fake"
+	"testing"
+)
+
+// countingClient counts the List and Describe calls of the vod and live sources made to the fake.
+type countingClient struct {
+	*fake.Client
+	lists     atomic.Int32
+	describes atomic.Int32
+}
+
+func (c *countingClient) ListVodSources(ctx context.Context, params *mediatailor.ListVodSourcesInput, optFns ...func(*mediatailor.Options)) (*mediatailor.ListVodSourcesOutput, error) {
+	c.lists.Add(1)
+	return c.Client.ListVodSources(ctx, params, optFns...)
+}
+
+func (c *countingClient) DescribeVodSource(ctx context.Context, params *mediatailor.DescribeVodSourceInput, optFns ...func(*mediatailor.Options)) (*mediatailor.DescribeVodSourceOutput, error) {
+	c.describes.Add(1)
+	return c.Client.DescribeVodSource(ctx, params, optFns...)
+}
+
+func (c *countingClient) ListLiveSources(ctx context.Context, params *mediatailor.ListLiveSourcesInput, optFns ...func(*mediatailor.Options)) (*mediatailor.ListLiveSourcesOutput, error) {
+	c.lists.Add(1)
+	return c.Client.ListLiveSources(ctx, params, optFns...)
+}
+
+func describeVodSourceInput(sourceLocationName, name string) *mediatailor.DescribeVodSourceInput {
+	return &mediatailor.DescribeVodSourceInput{SourceLocationName: aws.String(sourceLocationName), VodSourceName: aws.String(name)}
+}
+
+func TestReadCacheListsEachSourceLocationOnce(t *testing.T) {
+	client := &countingClient{Client: newListTestClient(t)}
+	cache := newReadCache(client)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for range 10 {
+		for _, sourceLocationName := range []string{"test_a", "test_b"} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				vodSource, cached, err := cache.describeVodSource(ctx, client, describeVodSourceInput(sourceLocationName, "vod"))
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if !cached || *vodSource.SourceLocationName != sourceLocationName || len(vodSource.HttpPackageConfigurations) != 1 {
+					t.Errorf("unexpected vod source %+v", vodSource)
+				}
+			}()
+		}
+	}
+	wg.Wait()
+
+	liveSource, err := cache.describeLiveSource(ctx, client, &mediatailor.DescribeLiveSourceInput{SourceLocationName: aws.String("test_a"), LiveSourceName: aws.String("live")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *liveSource.LiveSourceName != "live" || liveSource.Arn == nil {
+		t.Errorf("unexpected live source %+v", liveSource)
+	}
+
+	if lists, describes := client.lists.Load(), client.describes.Load(); lists != 3 || describes != 0 {
+		t.Errorf("expected 3 List and no Describe calls, got %d and %d", lists, describes)
+	}
+}
+
+func TestReadCacheInvalidatesOnWrites(t *testing.T) {
+	client := &countingClient{Client: newListTestClient(t)}
+	cache := newReadCache(client)
+	ctx := context.Background()
+
+	if _, _, err := cache.describeVodSource(ctx, cache, describeVodSourceInput("test_a", "vod")); err != nil {
+		t.Fatal(err)
+	}
+	_, err := cache.UpdateVodSource(ctx, &mediatailor.UpdateVodSourceInput{
+		SourceLocationName: aws.String("test_a"),
+		VodSourceName:      aws.String("vod"),
+		HttpPackageConfigurations: []awsTypes.HttpPackageConfiguration{
+			{Path: aws.String("/updated"), SourceGroup: aws.String("default"), Type: awsTypes.TypeHls},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vodSource, _, err := cache.describeVodSource(ctx, cache, describeVodSourceInput("test_a", "vod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path := *vodSource.HttpPackageConfigurations[0].Path; path != "/updated" {
+		t.Errorf("expected the updated vod source, got the path %s", path)
+	}
+	if lists := client.lists.Load(); lists != 2 {
+		t.Errorf("expected the source location to be listed again, got %d List calls", lists)
+	}
+}
+
+func TestReadCacheDescribesUncachedSources(t *testing.T) {
+	client := &countingClient{Client: newListTestClient(t)}
+	cache := newReadCache(client)
+	ctx := context.Background()
+
+	if _, _, err := cache.describeVodSource(ctx, cache, describeVodSourceInput("test_a", "vod")); err != nil {
+		t.Fatal(err)
+	}
+	// created without going through the cache, so the cached sources do not hold it
+	if _, err := client.CreateVodSource(ctx, &mediatailor.CreateVodSourceInput{
+		SourceLocationName:        aws.String("test_a"),
+		VodSourceName:             aws.String("new"),
+		HttpPackageConfigurations: []awsTypes.HttpPackageConfiguration{{Path: aws.String("/"), SourceGroup: aws.String("default"), Type: awsTypes.TypeHls}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	vodSource, cached, err := cache.describeVodSource(ctx, cache, describeVodSourceInput("test_a", "new"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached || *vodSource.VodSourceName != "new" || client.describes.Load() != 1 {
+		t.Errorf("expected the new vod source to be described, got %+v", vodSource)
+	}
+
+	var disabled *readCache
+	if _, cached, err := disabled.describeVodSource(ctx, client, describeVodSourceInput("test_a", "vod")); err != nil || cached {
+		t.Errorf("expected the disabled cache to describe the vod source, got %v and %v", cached, err)
+	}
+}
+
+// blockingClient blocks the first ListVodSources call until it is released.
+type blockingClient struct {
+	*countingClient
+	started chan struct{}
+	release chan struct{}
+	once    sync.Once
+}
+
+func newBlockingClient(t *testing.T) *blockingClient {
+	return &blockingClient{
+		countingClient: &countingClient{Client: newListTestClient(t)},
+		started:        make(chan struct{}),
+		release:        make(chan struct{}),
+	}
+}
+
+func (c *blockingClient) ListVodSources(ctx context.Context, params *mediatailor.ListVodSourcesInput, optFns ...func(*mediatailor.Options)) (*mediatailor.ListVodSourcesOutput, error) {
+	first := false
+	c.once.Do(func() { first = true })
+	if first {
+		close(c.started)
+		<-c.release
+	}
+	return c.countingClient.ListVodSources(ctx, params, optFns...)
+}
+
+func TestReadCacheListsAgainWhenInvalidatedDuringListing(t *testing.T) {
+	client := newBlockingClient(t)
+	cache := newReadCache(client)
+	ctx := context.Background()
+
+	type result struct {
+		vodSource *mediatailor.DescribeVodSourceOutput
+		err       error
+	}
+	results := make(chan result)
+	go func() {
+		vodSource, _, err := cache.describeVodSource(ctx, cache, describeVodSourceInput("test_a", "vod"))
+		results <- result{vodSource, err}
+	}()
+	<-client.started
+
+	// the update is made after the listing started, which may or may not see it
+	if _, err := cache.UpdateVodSource(ctx, &mediatailor.UpdateVodSourceInput{
+		SourceLocationName:        aws.String("test_a"),
+		VodSourceName:             aws.String("vod"),
+		HttpPackageConfigurations: []awsTypes.HttpPackageConfiguration{{Path: aws.String("/updated"), SourceGroup: aws.String("default"), Type: awsTypes.TypeHls}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	close(client.release)
+
+	r := <-results
+	if r.err != nil {
+		t.Fatal(r.err)
+	}
+	if path := *r.vodSource.HttpPackageConfigurations[0].Path; path != "/updated" {
+		t.Errorf("expected the updated vod source, got the path %s", path)
+	}
+	if lists := client.lists.Load(); lists != 2 {
+		t.Errorf("expected the source location to be listed again, got %d List calls", lists)
+	}
+}
+
+func TestReadCacheListingOutlivesTheCanceledRead(t *testing.T) {
+	client := newBlockingClient(t)
+	cache := newReadCache(client)
+
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	canceled := make(chan error)
+	go func() {
+		_, _, err := cache.describeVodSource(canceledCtx, cache, describeVodSourceInput("test_a", "vod"))
+		canceled <- err
+	}()
+	<-client.started
+
+	waiting := make(chan error)
+	go func() {
+		_, cached, err := cache.describeVodSource(context.Background(), cache, describeVodSourceInput("test_a", "vod"))
+		if err == nil && !cached {
+			t.Error("expected the vod source to be read from the listing")
+		}
+		waiting <- err
+	}()
+
+	cancel()
+	if err := <-canceled; err != context.Canceled {
+		t.Errorf("expected the canceled read to fail, got %v", err)
+	}
+	close(client.release)
+	if err := <-waiting; err != nil {
+		t.Errorf("expected the other read to succeed, got %v", err)
+	}
+	if lists := client.lists.Load(); lists != 1 {
+		t.Errorf("expected a single List call, got %d", lists)
+	}
+}
@@ -0,0 +1,756 @@
+package awsmt
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// scte35Model is a SCTE-35 splice_info_section. The PTS and the durations are in ticks of the 90kHz clock.
+type scte35Model struct {
+	SapType                 *int64                              `tfsdk:"sap_type" json:"sap_type"`
+	ProtocolVersion         int64                               `tfsdk:"protocol_version" json:"protocol_version"`
+	PtsAdjustment           int64                               `tfsdk:"pts_adjustment" json:"pts_adjustment"`
+	CwIndex                 *int64                              `tfsdk:"cw_index" json:"cw_index"`
+	Tier                    *int64                              `tfsdk:"tier" json:"tier"`
+	SpliceCommandType       *string                             `tfsdk:"splice_command_type" json:"splice_command_type"`
+	SpliceInsert            *scte35SpliceInsertModel            `tfsdk:"splice_insert" json:"splice_insert"`
+	TimeSignal              *scte35TimeSignalModel              `tfsdk:"time_signal" json:"time_signal"`
+	SegmentationDescriptors []scte35SegmentationDescriptorModel `tfsdk:"segmentation_descriptors" json:"segmentation_descriptors"`
+	Descriptors             []scte35DescriptorModel             `tfsdk:"descriptors" json:"descriptors"`
+}
+
+type scte35SpliceInsertModel struct {
+	SpliceEventId              int64                        `tfsdk:"splice_event_id" json:"splice_event_id"`
+	SpliceEventCancelIndicator bool                         `tfsdk:"splice_event_cancel_indicator" json:"splice_event_cancel_indicator"`
+	OutOfNetworkIndicator      bool                         `tfsdk:"out_of_network_indicator" json:"out_of_network_indicator"`
+	SpliceImmediateFlag        bool                         `tfsdk:"splice_immediate_flag" json:"splice_immediate_flag"`
+	PtsTime                    *int64                       `tfsdk:"pts_time" json:"pts_time"`
+	Components                 []scte35SpliceComponentModel `tfsdk:"components" json:"components"`
+	BreakDuration              *scte35BreakDurationModel    `tfsdk:"break_duration" json:"break_duration"`
+	UniqueProgramId            int64                        `tfsdk:"unique_program_id" json:"unique_program_id"`
+	AvailNum                   int64                        `tfsdk:"avail_num" json:"avail_num"`
+	AvailsExpected             int64                        `tfsdk:"avails_expected" json:"avails_expected"`
+}
+
+type scte35SpliceComponentModel struct {
+	ComponentTag int64  `tfsdk:"component_tag" json:"component_tag"`
+	PtsTime      *int64 `tfsdk:"pts_time" json:"pts_time"`
+}
+
+type scte35BreakDurationModel struct {
+	AutoReturn bool  `tfsdk:"auto_return" json:"auto_return"`
+	Duration   int64 `tfsdk:"duration" json:"duration"`
+}
+
+type scte35TimeSignalModel struct {
+	PtsTime *int64 `tfsdk:"pts_time" json:"pts_time"`
+}
+
+type scte35SegmentationDescriptorModel struct {
+	SegmentationEventId              int64                              `tfsdk:"segmentation_event_id" json:"segmentation_event_id"`
+	SegmentationEventCancelIndicator bool                               `tfsdk:"segmentation_event_cancel_indicator" json:"segmentation_event_cancel_indicator"`
+	DeliveryRestrictions             *scte35DeliveryRestrictionsModel   `tfsdk:"delivery_restrictions" json:"delivery_restrictions"`
+	Components                       []scte35SegmentationComponentModel `tfsdk:"components" json:"components"`
+	SegmentationDuration             *int64                             `tfsdk:"segmentation_duration" json:"segmentation_duration"`
+	Upids                            []scte35UpidModel                  `tfsdk:"upids" json:"upids"`
+	SegmentationTypeId               int64                              `tfsdk:"segmentation_type_id" json:"segmentation_type_id"`
+	SegmentNum                       int64                              `tfsdk:"segment_num" json:"segment_num"`
+	SegmentsExpected                 int64                              `tfsdk:"segments_expected" json:"segments_expected"`
+	SubSegmentNum                    *int64                             `tfsdk:"sub_segment_num" json:"sub_segment_num"`
+	SubSegmentsExpected              *int64                             `tfsdk:"sub_segments_expected" json:"sub_segments_expected"`
+}
+
+type scte35DeliveryRestrictionsModel struct {
+	WebDeliveryAllowed bool  `tfsdk:"web_delivery_allowed" json:"web_delivery_allowed"`
+	NoRegionalBlackout bool  `tfsdk:"no_regional_blackout" json:"no_regional_blackout"`
+	ArchiveAllowed     bool  `tfsdk:"archive_allowed" json:"archive_allowed"`
+	DeviceRestrictions int64 `tfsdk:"device_restrictions" json:"device_restrictions"`
+}
+
+type scte35SegmentationComponentModel struct {
+	ComponentTag int64 `tfsdk:"component_tag" json:"component_tag"`
+	PtsOffset    int64 `tfsdk:"pts_offset" json:"pts_offset"`
+}
+
+// scte35UpidModel is a segmentation UPID. The value is the text of the UPIDs made of characters, and the hexadecimal
+// bytes of the others.
+type scte35UpidModel struct {
+	Type  int64  `tfsdk:"type" json:"type"`
+	Value string `tfsdk:"value" json:"value"`
+}
+
+// scte35DescriptorModel is a splice descriptor other than a segmentation descriptor, with its data in hexadecimal.
+type scte35DescriptorModel struct {
+	Tag        int64  `tfsdk:"tag" json:"tag"`
+	Identifier string `tfsdk:"identifier" json:"identifier"`
+	Data       string `tfsdk:"data" json:"data"`
+}
+
+const (
+	scte35TableId = 0xFC
+	// scte35Identifier is the CUEI identifier of the SCTE-35 splice descriptors
+	scte35Identifier = 0x43554549
+
+	scte35SegmentationDescriptorTag = 0x02
+	scte35MidUpidType               = 0x0D
+)
+
+var scte35SpliceCommandTypes = map[string]int64{
+	"splice_null":           0x00,
+	"splice_insert":         0x05,
+	"time_signal":           0x06,
+	"bandwidth_reservation": 0x07,
+}
+
+// scte35TextUpidTypes are the UPID types made of characters: ISCI, Ad-ID, TID, ADI, ADS information and URI.
+var scte35TextUpidTypes = []int64{0x02, 0x03, 0x07, 0x09, 0x0E, 0x0F}
+
+// scte35SubSegmentTypeIds are the segmentation types followed by the sub segment fields.
+var scte35SubSegmentTypeIds = []int64{0x34, 0x36, 0x38, 0x3A, 0x44, 0x46}
+
+// crc32Mpeg returns the CRC-32/MPEG-2 of the data, used by the splice_info_section.
+func crc32Mpeg(data []byte) uint32 {
+	crc := uint32(0xFFFFFFFF)
+	for _, b := range data {
+		crc ^= uint32(b) << 24
+		for range 8 {
+			if crc&0x80000000 != 0 {
+				crc = crc<<1 ^ 0x04C11DB7
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// bitReader reads the big-endian bit fields of a SCTE-35 message.
+type bitReader struct {
+	data []byte
+	// position is the position in bits
+	position int
+}
+
+func (r *bitReader) remaining() int {
+	return len(r.data)*8 - r.position
+}
+
+func (r *bitReader) read(bits int) (int64, error) {
+	if bits > r.remaining() {
+		return 0, fmt.Errorf("the message is truncated at byte %d", r.position/8)
+	}
+	var value int64
+	for range bits {
+		value = value<<1 | int64(r.data[r.position/8]>>(7-r.position%8)&1)
+		r.position++
+	}
+	return value, nil
+}
+
+// readFields reads consecutive fields, stopping at the first error.
+func (r *bitReader) readFields(fields ...any) error {
+	for i := 0; i < len(fields); i += 2 {
+		value, err := r.read(fields[i].(int))
+		if err != nil {
+			return err
+		}
+		switch field := fields[i+1].(type) {
+		case *int64:
+			*field = value
+		case *bool:
+			*field = value == 1
+		case nil:
+			// reserved bits
+		}
+	}
+	return nil
+}
+
+func (r *bitReader) bytes(n int) ([]byte, error) {
+	if r.position%8 != 0 || n*8 > r.remaining() {
+		return nil, fmt.Errorf("the message is truncated at byte %d", r.position/8)
+	}
+	b := r.data[r.position/8 : r.position/8+n]
+	r.position += n * 8
+	return b, nil
+}
+
+// bitWriter writes the big-endian bit fields of a SCTE-35 message.
+type bitWriter struct {
+	data     []byte
+	position int
+}
+
+func (w *bitWriter) write(bits int, value int64) {
+	for i := bits - 1; i >= 0; i-- {
+		if w.position%8 == 0 {
+			w.data = append(w.data, 0)
+		}
+		w.data[len(w.data)-1] |= byte(value>>i&1) << (7 - w.position%8)
+		w.position++
+	}
+}
+
+func (w *bitWriter) writeBool(value bool) {
+	if value {
+		w.write(1, 1)
+	} else {
+		w.write(1, 0)
+	}
+}
+
+// reserved writes reserved bits, which are set to 1.
+func (w *bitWriter) reserved(bits int) {
+	w.write(bits, 1<<bits-1)
+}
+
+func (w *bitWriter) writeBytes(b []byte) {
+	for _, v := range b {
+		w.write(8, int64(v))
+	}
+}
+
+// decodeScte35Payload decodes a base64 message, or a hexadecimal one starting with 0x as found in the HLS tags.
+func decodeScte35Payload(message string) ([]byte, error) {
+	message = strings.TrimSpace(message)
+	if strings.HasPrefix(message, "0x") || strings.HasPrefix(message, "0X") {
+		return hex.DecodeString(message[2:])
+	}
+	return base64.StdEncoding.DecodeString(message)
+}
+
+// @ADR
+// Context: The SCTE-35 messages of the program ad breaks were authored by hand, and the markers of the origins could
+// not be inspected from the configurations.
+// Decision: The provider decodes and encodes the splice_info_section of SCTE-35 itself, with the splice_null,
+// splice_insert, time_signal and bandwidth_reservation commands, the segmentation descriptors, and the other
+// descriptors as raw data. The CRC of the decoded messages is checked, and the encoded messages get their CRC.
+// Consequences: The encrypted messages, the splice_schedule and the private commands are rejected. Decoding and encoding
+// a message gives back the same message, except for the values of the reserved bits, which are always written as 1.
+func decodeScte35(message string) (scte35Model, error) {
+	data, err := decodeScte35Payload(message)
+	if err != nil {
+		return scte35Model{}, fmt.Errorf("expected a base64 or a 0x prefixed hexadecimal message: %w", err)
+	}
+	if len(data) < 3 || data[0] != scte35TableId {
+		return scte35Model{}, errors.New("expected a splice_info_section starting with the table ID 0xFC")
+	}
+
+	r := &bitReader{data: data}
+	var sapType, sectionLength int64
+	if err := r.readFields(8, nil, 1, nil, 1, nil, 2, &sapType, 12, &sectionLength); err != nil {
+		return scte35Model{}, err
+	}
+	if int(sectionLength)+3 != len(data) {
+		return scte35Model{}, fmt.Errorf("the section length %d does not match the %d bytes of the message", sectionLength, len(data)-3)
+	}
+	if len(data) < 4+14 {
+		return scte35Model{}, errors.New("the message is too short for a splice_info_section")
+	}
+	if expected, actual := crc32Mpeg(data[:len(data)-4]), uint32(data[len(data)-4])<<24|uint32(data[len(data)-3])<<16|uint32(data[len(data)-2])<<8|uint32(data[len(data)-1]); expected != actual {
+		return scte35Model{}, fmt.Errorf("invalid CRC 0x%08X, expected 0x%08X", actual, expected)
+	}
+	r.data = data[:len(data)-4]
+
+	model := scte35Model{SapType: &sapType, CwIndex: new(int64), Tier: new(int64)}
+	var encrypted bool
+	var commandLength, commandType int64
+	if err := r.readFields(8, &model.ProtocolVersion, 1, &encrypted, 6, nil, 33, &model.PtsAdjustment, 8, model.CwIndex, 12, model.Tier, 12, &commandLength, 8, &commandType); err != nil {
+		return scte35Model{}, err
+	}
+	if encrypted {
+		return scte35Model{}, errors.New("encrypted messages are not supported")
+	}
+
+	commandStart := r.position
+	for name, value := range scte35SpliceCommandTypes {
+		if value == commandType {
+			model.SpliceCommandType = &name
+		}
+	}
+	switch commandType {
+	case scte35SpliceCommandTypes["splice_insert"]:
+		model.SpliceInsert, err = decodeScte35SpliceInsert(r)
+	case scte35SpliceCommandTypes["time_signal"]:
+		model.TimeSignal = &scte35TimeSignalModel{}
+		model.TimeSignal.PtsTime, err = decodeScte35SpliceTime(r)
+	case scte35SpliceCommandTypes["splice_null"], scte35SpliceCommandTypes["bandwidth_reservation"]:
+	default:
+		return scte35Model{}, fmt.Errorf("the splice command type 0x%02X is not supported", commandType)
+	}
+	if err != nil {
+		return scte35Model{}, err
+	}
+	// a command length of 0xFFF is the unknown length of the legacy messages
+	if commandLength != 0xFFF && int64(r.position-commandStart) != commandLength*8 {
+		return scte35Model{}, fmt.Errorf("the splice command length %d does not match the %d bytes of the command", commandLength, (r.position-commandStart)/8)
+	}
+
+	var descriptorLoopLength int64
+	if err := r.readFields(16, &descriptorLoopLength); err != nil {
+		return scte35Model{}, err
+	}
+	descriptors, err := r.bytes(int(descriptorLoopLength))
+	if err != nil {
+		return scte35Model{}, err
+	}
+	if r.remaining() != 0 {
+		return scte35Model{}, fmt.Errorf("unexpected %d bytes after the splice descriptors", r.remaining()/8)
+	}
+	if err := decodeScte35Descriptors(&model, &bitReader{data: descriptors}); err != nil {
+		return scte35Model{}, err
+	}
+	return model, nil
+}
+
+func decodeScte35SpliceTime(r *bitReader) (*int64, error) {
+	var timeSpecified bool
+	if err := r.readFields(1, &timeSpecified); err != nil {
+		return nil, err
+	}
+	if !timeSpecified {
+		return nil, r.readFields(7, nil)
+	}
+	var ptsTime int64
+	return &ptsTime, r.readFields(6, nil, 33, &ptsTime)
+}
+
+func decodeScte35SpliceInsert(r *bitReader) (*scte35SpliceInsertModel, error) {
+	var insert scte35SpliceInsertModel
+	if err := r.readFields(32, &insert.SpliceEventId, 1, &insert.SpliceEventCancelIndicator, 7, nil); err != nil {
+		return nil, err
+	}
+	if insert.SpliceEventCancelIndicator {
+		return &insert, nil
+	}
+
+	var programSplice, durationFlag bool
+	if err := r.readFields(1, &insert.OutOfNetworkIndicator, 1, &programSplice, 1, &durationFlag, 1, &insert.SpliceImmediateFlag, 4, nil); err != nil {
+		return nil, err
+	}
+	var err error
+	if programSplice && !insert.SpliceImmediateFlag {
+		if insert.PtsTime, err = decodeScte35SpliceTime(r); err != nil {
+			return nil, err
+		}
+	}
+	if !programSplice {
+		var componentCount int64
+		if err := r.readFields(8, &componentCount); err != nil {
+			return nil, err
+		}
+		insert.Components = []scte35SpliceComponentModel{}
+		for range componentCount {
+			var component scte35SpliceComponentModel
+			if err := r.readFields(8, &component.ComponentTag); err != nil {
+				return nil, err
+			}
+			if !insert.SpliceImmediateFlag {
+				if component.PtsTime, err = decodeScte35SpliceTime(r); err != nil {
+					return nil, err
+				}
+			}
+			insert.Components = append(insert.Components, component)
+		}
+	}
+	if durationFlag {
+		insert.BreakDuration = &scte35BreakDurationModel{}
+		if err := r.readFields(1, &insert.BreakDuration.AutoReturn, 6, nil, 33, &insert.BreakDuration.Duration); err != nil {
+			return nil, err
+		}
+	}
+	return &insert, r.readFields(16, &insert.UniqueProgramId, 8, &insert.AvailNum, 8, &insert.AvailsExpected)
+}
+
+func decodeScte35Descriptors(model *scte35Model, r *bitReader) error {
+	model.SegmentationDescriptors = []scte35SegmentationDescriptorModel{}
+	model.Descriptors = []scte35DescriptorModel{}
+	for r.remaining() > 0 {
+		var tag, length, identifier int64
+		if err := r.readFields(8, &tag, 8, &length); err != nil {
+			return err
+		}
+		data, err := r.bytes(int(length))
+		if err != nil {
+			return err
+		}
+		if length < 4 {
+			return fmt.Errorf("the splice descriptor with the tag 0x%02X is too short for its identifier", tag)
+		}
+		descriptor := &bitReader{data: data}
+		_ = descriptor.readFields(32, &identifier)
+
+		if tag == scte35SegmentationDescriptorTag && identifier == scte35Identifier {
+			segmentation, err := decodeScte35SegmentationDescriptor(descriptor)
+			if err != nil {
+				return fmt.Errorf("segmentation descriptor %d: %w", len(model.SegmentationDescriptors)+1, err)
+			}
+			model.SegmentationDescriptors = append(model.SegmentationDescriptors, segmentation)
+			continue
+		}
+		model.Descriptors = append(model.Descriptors, scte35DescriptorModel{
+			Tag:        tag,
+			Identifier: string(data[:4]),
+			Data:       hex.EncodeToString(data[4:]),
+		})
+	}
+	return nil
+}
+
+func decodeScte35SegmentationDescriptor(r *bitReader) (scte35SegmentationDescriptorModel, error) {
+	var descriptor scte35SegmentationDescriptorModel
+	if err := r.readFields(32, &descriptor.SegmentationEventId, 1, &descriptor.SegmentationEventCancelIndicator, 7, nil); err != nil {
+		return descriptor, err
+	}
+	if descriptor.SegmentationEventCancelIndicator {
+		return descriptor, nil
+	}
+
+	var programSegmentation, durationFlag, deliveryNotRestricted bool
+	if err := r.readFields(1, &programSegmentation, 1, &durationFlag, 1, &deliveryNotRestricted); err != nil {
+		return descriptor, err
+	}
+	if deliveryNotRestricted {
+		if err := r.readFields(5, nil); err != nil {
+			return descriptor, err
+		}
+	} else {
+		descriptor.DeliveryRestrictions = &scte35DeliveryRestrictionsModel{}
+		restrictions := descriptor.DeliveryRestrictions
+		if err := r.readFields(1, &restrictions.WebDeliveryAllowed, 1, &restrictions.NoRegionalBlackout, 1, &restrictions.ArchiveAllowed, 2, &restrictions.DeviceRestrictions); err != nil {
+			return descriptor, err
+		}
+	}
+	if !programSegmentation {
+		var componentCount int64
+		if err := r.readFields(8, &componentCount); err != nil {
+			return descriptor, err
+		}
+		descriptor.Components = []scte35SegmentationComponentModel{}
+		for range componentCount {
+			var component scte35SegmentationComponentModel
+			if err := r.readFields(8, &component.ComponentTag, 7, nil, 33, &component.PtsOffset); err != nil {
+				return descriptor, err
+			}
+			descriptor.Components = append(descriptor.Components, component)
+		}
+	}
+	if durationFlag {
+		descriptor.SegmentationDuration = new(int64)
+		if err := r.readFields(40, descriptor.SegmentationDuration); err != nil {
+			return descriptor, err
+		}
+	}
+
+	var upidType, upidLength int64
+	if err := r.readFields(8, &upidType, 8, &upidLength); err != nil {
+		return descriptor, err
+	}
+	upid, err := r.bytes(int(upidLength))
+	if err != nil {
+		return descriptor, err
+	}
+	if descriptor.Upids, err = decodeScte35Upids(upidType, upid); err != nil {
+		return descriptor, err
+	}
+
+	if err := r.readFields(8, &descriptor.SegmentationTypeId, 8, &descriptor.SegmentNum, 8, &descriptor.SegmentsExpected); err != nil {
+		return descriptor, err
+	}
+	// the sub segment fields are optional, the length of the descriptor tells whether they are present
+	if slices.Contains(scte35SubSegmentTypeIds, descriptor.SegmentationTypeId) && r.remaining() >= 16 {
+		descriptor.SubSegmentNum, descriptor.SubSegmentsExpected = new(int64), new(int64)
+		if err := r.readFields(8, descriptor.SubSegmentNum, 8, descriptor.SubSegmentsExpected); err != nil {
+			return descriptor, err
+		}
+	}
+	return descriptor, nil
+}
+
+// decodeScte35Upids returns the UPIDs of a segmentation descriptor, which are the UPIDs of a MID UPID or a single UPID.
+func decodeScte35Upids(upidType int64, upid []byte) ([]scte35UpidModel, error) {
+	if upidType == 0 {
+		return []scte35UpidModel{}, nil
+	}
+	if upidType != scte35MidUpidType {
+		return []scte35UpidModel{decodeScte35Upid(upidType, upid)}, nil
+	}
+
+	upids := []scte35UpidModel{}
+	r := &bitReader{data: upid}
+	for r.remaining() > 0 {
+		var midType, midLength int64
+		if err := r.readFields(8, &midType, 8, &midLength); err != nil {
+			return nil, err
+		}
+		value, err := r.bytes(int(midLength))
+		if err != nil {
+			return nil, err
+		}
+		upids = append(upids, decodeScte35Upid(midType, value))
+	}
+	return upids, nil
+}
+
+func decodeScte35Upid(upidType int64, upid []byte) scte35UpidModel {
+	if slices.Contains(scte35TextUpidTypes, upidType) {
+		return scte35UpidModel{Type: upidType, Value: string(upid)}
+	}
+	return scte35UpidModel{Type: upidType, Value: hex.EncodeToString(upid)}
+}
+
+// encodeScte35 encodes a splice_info_section to base64, with its CRC.
+func encodeScte35(model scte35Model) (string, error) {
+	commandType, err := scte35CommandType(model)
+	if err != nil {
+		return "", err
+	}
+
+	command := &bitWriter{}
+	switch commandType {
+	case scte35SpliceCommandTypes["splice_insert"]:
+		if model.SpliceInsert == nil {
+			return "", errors.New("splice_insert must be set for the splice_insert command")
+		}
+		encodeScte35SpliceInsert(command, *model.SpliceInsert)
+	case scte35SpliceCommandTypes["time_signal"]:
+		if model.TimeSignal == nil {
+			return "", errors.New("time_signal must be set for the time_signal command")
+		}
+		encodeScte35SpliceTime(command, model.TimeSignal.PtsTime)
+	}
+
+	descriptors := &bitWriter{}
+	for i, segmentation := range model.SegmentationDescriptors {
+		data, err := encodeScte35SegmentationDescriptor(segmentation)
+		if err != nil {
+			return "", fmt.Errorf("segmentation descriptor %d: %w", i+1, err)
+		}
+		if err := writeScte35Descriptor(descriptors, scte35SegmentationDescriptorTag, data); err != nil {
+			return "", fmt.Errorf("segmentation descriptor %d: %w", i+1, err)
+		}
+	}
+	for i, descriptor := range model.Descriptors {
+		data, err := hex.DecodeString(descriptor.Data)
+		if err != nil {
+			return "", fmt.Errorf("descriptor %d: invalid hexadecimal data: %w", i+1, err)
+		}
+		if len(descriptor.Identifier) != 4 {
+			return "", fmt.Errorf("descriptor %d: expected an identifier of 4 characters, got %q", i+1, descriptor.Identifier)
+		}
+		if err := writeScte35Descriptor(descriptors, descriptor.Tag, append([]byte(descriptor.Identifier), data...)); err != nil {
+			return "", fmt.Errorf("descriptor %d: %w", i+1, err)
+		}
+	}
+	if len(descriptors.data) > 0xFFFF {
+		return "", errors.New("the splice descriptors exceed 65535 bytes")
+	}
+
+	w := &bitWriter{}
+	w.write(8, scte35TableId)
+	w.write(1, 0)
+	w.write(1, 0)
+	w.write(2, valueOrDefault(model.SapType, 3))
+	// the section length counts the bytes following it, up to and including the CRC
+	sectionLength := 11 + len(command.data) + 2 + len(descriptors.data) + 4
+	if sectionLength > 0xFFF {
+		return "", fmt.Errorf("the section length %d exceeds 4095 bytes", sectionLength)
+	}
+	w.write(12, int64(sectionLength))
+	w.write(8, model.ProtocolVersion)
+	w.write(1, 0)
+	w.write(6, 0)
+	w.write(33, model.PtsAdjustment)
+	w.write(8, valueOrDefault(model.CwIndex, 0xFF))
+	w.write(12, valueOrDefault(model.Tier, 0xFFF))
+	w.write(12, int64(len(command.data)))
+	w.write(8, commandType)
+	w.writeBytes(command.data)
+	w.write(16, int64(len(descriptors.data)))
+	w.writeBytes(descriptors.data)
+	w.write(32, int64(crc32Mpeg(w.data)))
+	return base64.StdEncoding.EncodeToString(w.data), nil
+}
+
+func valueOrDefault(value *int64, defaultValue int64) int64 {
+	if value == nil {
+		return defaultValue
+	}
+	return *value
+}
+
+// scte35CommandType returns the type of the splice command, which defaults to the command whose block is set.
+func scte35CommandType(model scte35Model) (int64, error) {
+	if model.SpliceCommandType != nil {
+		commandType, ok := scte35SpliceCommandTypes[*model.SpliceCommandType]
+		if !ok {
+			return 0, fmt.Errorf("unsupported splice_command_type %q, expected one of splice_null, splice_insert, time_signal and bandwidth_reservation", *model.SpliceCommandType)
+		}
+		return commandType, nil
+	}
+	switch {
+	case model.SpliceInsert != nil && model.TimeSignal != nil:
+		return 0, errors.New("only one of splice_insert and time_signal can be set")
+	case model.SpliceInsert != nil:
+		return scte35SpliceCommandTypes["splice_insert"], nil
+	case model.TimeSignal != nil:
+		return scte35SpliceCommandTypes["time_signal"], nil
+	default:
+		return scte35SpliceCommandTypes["splice_null"], nil
+	}
+}
+
+func encodeScte35SpliceTime(w *bitWriter, ptsTime *int64) {
+	if ptsTime == nil {
+		w.write(1, 0)
+		w.reserved(7)
+		return
+	}
+	w.write(1, 1)
+	w.reserved(6)
+	w.write(33, *ptsTime)
+}
+
+func encodeScte35SpliceInsert(w *bitWriter, insert scte35SpliceInsertModel) {
+	w.write(32, insert.SpliceEventId)
+	w.writeBool(insert.SpliceEventCancelIndicator)
+	w.reserved(7)
+	if insert.SpliceEventCancelIndicator {
+		return
+	}
+
+	w.writeBool(insert.OutOfNetworkIndicator)
+	w.writeBool(insert.Components == nil)
+	w.writeBool(insert.BreakDuration != nil)
+	w.writeBool(insert.SpliceImmediateFlag)
+	w.reserved(4)
+	if insert.Components == nil && !insert.SpliceImmediateFlag {
+		encodeScte35SpliceTime(w, insert.PtsTime)
+	}
+	if insert.Components != nil {
+		w.write(8, int64(len(insert.Components)))
+		for _, component := range insert.Components {
+			w.write(8, component.ComponentTag)
+			if !insert.SpliceImmediateFlag {
+				encodeScte35SpliceTime(w, component.PtsTime)
+			}
+		}
+	}
+	if insert.BreakDuration != nil {
+		w.writeBool(insert.BreakDuration.AutoReturn)
+		w.reserved(6)
+		w.write(33, insert.BreakDuration.Duration)
+	}
+	w.write(16, insert.UniqueProgramId)
+	w.write(8, insert.AvailNum)
+	w.write(8, insert.AvailsExpected)
+}
+
+func encodeScte35SegmentationDescriptor(descriptor scte35SegmentationDescriptorModel) ([]byte, error) {
+	w := &bitWriter{}
+	w.write(32, scte35Identifier)
+	w.write(32, descriptor.SegmentationEventId)
+	w.writeBool(descriptor.SegmentationEventCancelIndicator)
+	w.reserved(7)
+	if descriptor.SegmentationEventCancelIndicator {
+		return w.data, nil
+	}
+
+	w.writeBool(descriptor.Components == nil)
+	w.writeBool(descriptor.SegmentationDuration != nil)
+	w.writeBool(descriptor.DeliveryRestrictions == nil)
+	if restrictions := descriptor.DeliveryRestrictions; restrictions != nil {
+		w.writeBool(restrictions.WebDeliveryAllowed)
+		w.writeBool(restrictions.NoRegionalBlackout)
+		w.writeBool(restrictions.ArchiveAllowed)
+		w.write(2, restrictions.DeviceRestrictions)
+	} else {
+		w.reserved(5)
+	}
+	if descriptor.Components != nil {
+		w.write(8, int64(len(descriptor.Components)))
+		for _, component := range descriptor.Components {
+			w.write(8, component.ComponentTag)
+			w.reserved(7)
+			w.write(33, component.PtsOffset)
+		}
+	}
+	if descriptor.SegmentationDuration != nil {
+		w.write(40, *descriptor.SegmentationDuration)
+	}
+
+	upidType, upid, err := encodeScte35Upids(descriptor.Upids)
+	if err != nil {
+		return nil, err
+	}
+	w.write(8, upidType)
+	w.write(8, int64(len(upid)))
+	w.writeBytes(upid)
+
+	w.write(8, descriptor.SegmentationTypeId)
+	w.write(8, descriptor.SegmentNum)
+	w.write(8, descriptor.SegmentsExpected)
+	if (descriptor.SubSegmentNum == nil) != (descriptor.SubSegmentsExpected == nil) {
+		return nil, errors.New("sub_segment_num and sub_segments_expected must be set together")
+	}
+	if descriptor.SubSegmentNum != nil {
+		if !slices.Contains(scte35SubSegmentTypeIds, descriptor.SegmentationTypeId) {
+			return nil, fmt.Errorf("the segmentation type 0x%02X has no sub segments", descriptor.SegmentationTypeId)
+		}
+		w.write(8, *descriptor.SubSegmentNum)
+		w.write(8, *descriptor.SubSegmentsExpected)
+	}
+	return w.data, nil
+}
+
+// encodeScte35Upids returns the type and the bytes of the UPIDs of a segmentation descriptor, several UPIDs being
+// encoded in a MID UPID.
+func encodeScte35Upids(upids []scte35UpidModel) (int64, []byte, error) {
+	if len(upids) == 0 {
+		return 0, nil, nil
+	}
+	var encoded [][]byte
+	for i, upid := range upids {
+		if upid.Type <= 0 || upid.Type > 0xFF || upid.Type == scte35MidUpidType {
+			return 0, nil, fmt.Errorf("upid %d: invalid type %d, several UPIDs are encoded in a MID UPID", i+1, upid.Type)
+		}
+		value := []byte(upid.Value)
+		if !slices.Contains(scte35TextUpidTypes, upid.Type) {
+			var err error
+			if value, err = hex.DecodeString(upid.Value); err != nil {
+				return 0, nil, fmt.Errorf("upid %d: invalid hexadecimal value: %w", i+1, err)
+			}
+		}
+		if len(value) > 0xFF {
+			return 0, nil, fmt.Errorf("upid %d: the value exceeds 255 bytes", i+1)
+		}
+		encoded = append(encoded, value)
+	}
+	if len(upids) == 1 {
+		return upids[0].Type, encoded[0], nil
+	}
+
+	var mid []byte
+	for i, value := range encoded {
+		mid = append(mid, byte(upids[i].Type), byte(len(value)))
+		mid = append(mid, value...)
+	}
+	if len(mid) > 0xFF {
+		return 0, nil, errors.New("the UPIDs exceed 255 bytes")
+	}
+	return scte35MidUpidType, mid, nil
+}
+
+func writeScte35Descriptor(w *bitWriter, tag int64, data []byte) error {
+	if len(data) > 0xFF {
+		return fmt.Errorf("the descriptor exceeds 255 bytes")
+	}
+	w.write(8, tag)
+	w.write(8, int64(len(data)))
+	w.writeBytes(data)
+	return nil
+}
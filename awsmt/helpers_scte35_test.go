@@ -0,0 +1,159 @@
+package awsmt
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// the time_signal and splice_insert samples of the SCTE-35 specification
+const (
+	testScte35TimeSignal   = "/DA0AAAAAAAA///wBQb+cr0AUAAeAhxDVUVJSAAAjn/PAAGlmbAICAAAAAAsoKGKNAIAmsnRfg=="
+	testScte35SpliceInsert = "/DAvAAAAAAAA///wFAVIAACPf+/+c2nALv4AUsz1AAAAAAAKAAhDVUVJAAABNWLbowo="
+)
+
+func TestCrc32Mpeg(t *testing.T) {
+	if crc := crc32Mpeg([]byte("123456789")); crc != 0x0376E6E7 {
+		t.Errorf("expected the CRC-32/MPEG-2 check value 0x0376E6E7, got 0x%08X", crc)
+	}
+}
+
+func TestDecodeScte35TimeSignal(t *testing.T) {
+	model, err := decodeScte35(testScte35TimeSignal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *model.SpliceCommandType != "time_signal" || model.TimeSignal == nil || *model.TimeSignal.PtsTime != 1924989008 {
+		t.Errorf("unexpected splice command %v %v", *model.SpliceCommandType, model.TimeSignal)
+	}
+	if len(model.SegmentationDescriptors) != 1 {
+		t.Fatalf("expected a segmentation descriptor, got %v", model.SegmentationDescriptors)
+	}
+	descriptor := model.SegmentationDescriptors[0]
+	if descriptor.SegmentationEventId != 0x4800008E || descriptor.SegmentationTypeId != 0x34 || *descriptor.SegmentationDuration != 27630000 || descriptor.SegmentNum != 2 {
+		t.Errorf("unexpected segmentation descriptor %+v", descriptor)
+	}
+	if !reflect.DeepEqual(descriptor.Upids, []scte35UpidModel{{Type: 0x08, Value: "000000002ca0a18a"}}) {
+		t.Errorf("unexpected UPIDs %v", descriptor.Upids)
+	}
+	if descriptor.DeliveryRestrictions == nil || !descriptor.DeliveryRestrictions.NoRegionalBlackout || descriptor.DeliveryRestrictions.DeviceRestrictions != 3 {
+		t.Errorf("unexpected delivery restrictions %v", descriptor.DeliveryRestrictions)
+	}
+}
+
+func TestDecodeScte35SpliceInsert(t *testing.T) {
+	model, err := decodeScte35(testScte35SpliceInsert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	insert := model.SpliceInsert
+	if insert == nil || insert.SpliceEventId != 0x4800008F || !insert.OutOfNetworkIndicator || *insert.PtsTime != 1936310318 || insert.Components != nil {
+		t.Fatalf("unexpected splice insert %+v", insert)
+	}
+	if *insert.BreakDuration != (scte35BreakDurationModel{AutoReturn: true, Duration: 5426421}) {
+		t.Errorf("unexpected break duration %v", insert.BreakDuration)
+	}
+	if !reflect.DeepEqual(model.Descriptors, []scte35DescriptorModel{{Tag: 0, Identifier: "CUEI", Data: "00000135"}}) {
+		t.Errorf("unexpected avail descriptor %v", model.Descriptors)
+	}
+}
+
+func TestDecodeScte35Hex(t *testing.T) {
+	data, _ := base64.StdEncoding.DecodeString(testScte35TimeSignal)
+	model, err := decodeScte35("0x" + strings.ToUpper(hex.EncodeToString(data)))
+	if err != nil || *model.TimeSignal.PtsTime != 1924989008 {
+		t.Errorf("expected the hexadecimal message to be decoded, got %v %v", model.TimeSignal, err)
+	}
+}
+
+func TestDecodeScte35Errors(t *testing.T) {
+	data, _ := base64.StdEncoding.DecodeString(testScte35SpliceInsert)
+	corrupted := append([]byte{}, data...)
+	corrupted[20] ^= 0x01
+
+	for message, expected := range map[string]string{
+		"not base64": "expected a base64 or a 0x prefixed hexadecimal message",
+		base64.StdEncoding.EncodeToString(corrupted):          "invalid CRC",
+		base64.StdEncoding.EncodeToString(data[:len(data)-1]): "the section length 47 does not match the 46 bytes",
+		"0xFD3000": "table ID 0xFC",
+	} {
+		if _, err := decodeScte35(message); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected the error %q for %s, got %v", expected, message, err)
+		}
+	}
+}
+
+func TestEncodeScte35RoundTrip(t *testing.T) {
+	for _, message := range []string{testScte35TimeSignal, testScte35SpliceInsert} {
+		model, err := decodeScte35(message)
+		if err != nil {
+			t.Fatal(err)
+		}
+		encoded, err := encodeScte35(model)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if encoded != message {
+			t.Errorf("expected %s to be encoded again as is, got %s", message, encoded)
+		}
+	}
+}
+
+func TestEncodeScte35(t *testing.T) {
+	pts, duration, subSegmentNum, subSegmentsExpected := int64(900000), int64(2700000), int64(1), int64(2)
+	model := scte35Model{
+		SpliceInsert: &scte35SpliceInsertModel{
+			SpliceEventId: 7,
+			Components:    []scte35SpliceComponentModel{{ComponentTag: 1, PtsTime: &pts}, {ComponentTag: 2}},
+			BreakDuration: &scte35BreakDurationModel{Duration: duration},
+		},
+		SegmentationDescriptors: []scte35SegmentationDescriptorModel{{
+			SegmentationEventId:  8,
+			Components:           []scte35SegmentationComponentModel{{ComponentTag: 1, PtsOffset: 90}},
+			SegmentationDuration: &duration,
+			Upids:                []scte35UpidModel{{Type: 0x03, Value: "ABCD01234567"}, {Type: 0x0C, Value: "cafe"}},
+			SegmentationTypeId:   0x34,
+			SubSegmentNum:        &subSegmentNum,
+			SubSegmentsExpected:  &subSegmentsExpected,
+		}, {
+			SegmentationEventId:              9,
+			SegmentationEventCancelIndicator: true,
+		}},
+	}
+
+	encoded, err := encodeScte35(model)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := decodeScte35(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *decoded.SpliceCommandType != "splice_insert" || !reflect.DeepEqual(decoded.SpliceInsert, model.SpliceInsert) {
+		t.Errorf("unexpected splice insert %+v", decoded.SpliceInsert)
+	}
+	if !reflect.DeepEqual(decoded.SegmentationDescriptors, model.SegmentationDescriptors) {
+		t.Errorf("unexpected segmentation descriptors %+v", decoded.SegmentationDescriptors)
+	}
+	if *decoded.SapType != 3 || *decoded.Tier != 0xFFF || *decoded.CwIndex != 0xFF {
+		t.Errorf("expected the default sap type, tier and cw index, got %v %v %v", *decoded.SapType, *decoded.Tier, *decoded.CwIndex)
+	}
+}
+
+func TestEncodeScte35Errors(t *testing.T) {
+	unknown := "splice_schedule"
+	subSegmentNum := int64(1)
+	for expected, model := range map[string]scte35Model{
+		`unsupported splice_command_type "splice_schedule"`: {SpliceCommandType: &unknown},
+		"only one of splice_insert and time_signal":         {SpliceInsert: &scte35SpliceInsertModel{}, TimeSignal: &scte35TimeSignalModel{}},
+		"upid 1: invalid hexadecimal value":                 {SegmentationDescriptors: []scte35SegmentationDescriptorModel{{Upids: []scte35UpidModel{{Type: 0x08, Value: "xyz"}}}}},
+		"sub_segment_num and sub_segments_expected":         {SegmentationDescriptors: []scte35SegmentationDescriptorModel{{SubSegmentNum: &subSegmentNum}}},
+		"expected an identifier of 4 characters":            {Descriptors: []scte35DescriptorModel{{Identifier: "CUE"}}},
+	} {
+		if _, err := encodeScte35(model); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected the error %q, got %v", expected, err)
+		}
+	}
+}
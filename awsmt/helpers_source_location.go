@@ -3,6 +3,7 @@ package awsmt
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
 	"terraform-provider-mediatailor/awsmt/models"
 
 	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
@@ -35,8 +36,8 @@ func getCreateSourceLocationInput(model models.SourceLocationModel) mediatailor.
 	}
 
 	// Tags
-	if len(model.Tags) > 0 && model.Tags != nil {
-		params.Tags = model.Tags
+	if tags := tagsAllToMap(model.TagsAll); len(tags) > 0 {
+		params.Tags = tags
 	}
 
 	return params
@@ -156,6 +157,7 @@ func writeSourceLocationToPlan(model models.SourceLocationModel, sourceLocation
 	if len(sourceLocation.Tags) > 0 {
 		model.Tags = sourceLocation.Tags
 	}
+	model.TagsAll = tagsAllValue(sourceLocation.Tags)
 
 	return model
 }
@@ -168,11 +170,11 @@ func readSourceLocationComputedValues(model models.SourceLocationModel, sourceLo
 	}
 
 	if sourceLocation.CreationTime != nil {
-		model.CreationTime = types.StringValue(sourceLocation.CreationTime.String())
+		model.CreationTime = timetypes.NewRFC3339TimeValue(*sourceLocation.CreationTime)
 	}
 
 	if sourceLocation.LastModifiedTime != nil {
-		model.LastModifiedTime = types.StringValue(sourceLocation.LastModifiedTime.String())
+		model.LastModifiedTime = timetypes.NewRFC3339TimeValue(*sourceLocation.LastModifiedTime)
 	}
 
 	if sourceLocation.SourceLocationName != nil && *sourceLocation.SourceLocationName != "" {
@@ -263,7 +265,7 @@ func readHttpConfiguration(model models.SourceLocationModel, sourceLocation medi
 	return model
 }
 
-func deleteSourceLocation(client *mediatailor.Client, name *string) error {
+func deleteSourceLocation(client mediaTailorClient, name *string) error {
 	vodSourcesList, err := client.ListVodSources(context.TODO(), &mediatailor.ListVodSourcesInput{SourceLocationName: name})
 	if err != nil {
 		return err
@@ -293,7 +295,7 @@ func deleteSourceLocation(client *mediatailor.Client, name *string) error {
 	return nil
 }
 
-func recreateSourceLocation(client *mediatailor.Client, plan models.SourceLocationModel) (*models.SourceLocationModel, error) {
+func recreateSourceLocation(client mediaTailorClient, plan models.SourceLocationModel) (*mediatailor.CreateSourceLocationOutput, error) {
 	err := deleteSourceLocation(client, plan.Name)
 	if err != nil {
 		return nil, err
@@ -304,6 +306,5 @@ func recreateSourceLocation(client *mediatailor.Client, plan models.SourceLocati
 	if err != nil {
 		return nil, fmt.Errorf("error while creating new source location with new access configuration %v", err.Error())
 	}
-	model := writeSourceLocationToPlan(plan, *sourceLocation)
-	return &model, nil
+	return sourceLocation, nil
 }
@@ -0,0 +1,121 @@
+package awsmt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"strings"
+	"time"
+)
+
+// stateUpgrade upgrades the JSON state of a resource from a version of its schema to the next one.
+type stateUpgrade func(state map[string]any) error
+
+// @ADR
+// Context: The framework upgrades the state of a resource from each prior version of its schema to the current one in
+// a single step, which requires every upgrader to know the prior and the current schemas, and to be rewritten each
+// time the schema changes.
+// Decision: Each resource declares the upgrades from each version of its schema to the next one, working on the JSON
+// of the state, and the upgrader of a prior version applies the upgrades of the following versions in sequence.
+// Consequences: Changing the shape of an attribute only requires bumping the version of the schema and appending an
+// upgrade, without keeping the prior schemas. The upgrades have to handle the JSON representation of the state.
+func stateUpgraders(ctx context.Context, r resource.Resource, upgrades ...stateUpgrade) map[int64]resource.StateUpgrader {
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	if int64(len(upgrades)) != schemaResp.Schema.Version {
+		return mismatchedStateUpgraders(schemaResp.Schema.Version, len(upgrades))
+	}
+	currentType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	upgraders := map[int64]resource.StateUpgrader{}
+	for version := range upgrades {
+		steps := upgrades[version:]
+		upgraders[int64(version)] = resource.StateUpgrader{
+			StateUpgrader: func(_ context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				upgradeRawState(currentType, steps, req, resp)
+			},
+		}
+	}
+	return upgraders
+}
+
+// mismatchedStateUpgraders returns upgraders failing with an error for every prior version of a schema whose upgrades
+// do not match its version, so that the mistake is reported by Terraform and by TestResourcesUpgradeEveryPriorVersion
+// rather than crashing the provider.
+func mismatchedStateUpgraders(schemaVersion int64, upgrades int) map[int64]resource.StateUpgrader {
+	err := fmt.Sprintf("expected %d state upgrades for version %d of the schema, got %d", schemaVersion, schemaVersion, upgrades)
+	upgraders := map[int64]resource.StateUpgrader{}
+	for version := range max(schemaVersion, int64(upgrades)) {
+		upgraders[version] = resource.StateUpgrader{
+			StateUpgrader: func(_ context.Context, _ resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				resp.Diagnostics.AddError("Error while upgrading the state "+err, err)
+			},
+		}
+	}
+	return upgraders
+}
+
+func upgradeRawState(currentType tftypes.Type, steps []stateUpgrade, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	if req.RawState == nil || req.RawState.JSON == nil {
+		resp.Diagnostics.AddError("Error while upgrading the state", "the state to upgrade is not in the JSON format")
+		return
+	}
+
+	var state map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(req.RawState.JSON))
+	// numbers are kept as they are, as converting them to float64 could lose the precision of large integers
+	decoder.UseNumber()
+	if err := decoder.Decode(&state); err != nil {
+		resp.Diagnostics.AddError("Error while upgrading the state "+err.Error(), err.Error())
+		return
+	}
+
+	for _, upgrade := range steps {
+		if err := upgrade(state); err != nil {
+			resp.Diagnostics.AddError("Error while upgrading the state "+err.Error(), err.Error())
+			return
+		}
+	}
+
+	upgraded, err := json.Marshal(state)
+	if err != nil {
+		resp.Diagnostics.AddError("Error while upgrading the state "+err.Error(), err.Error())
+		return
+	}
+	value, err := (&tfprotov6.RawState{JSON: upgraded}).Unmarshal(currentType)
+	if err != nil {
+		resp.Diagnostics.AddError("Error while upgrading the state "+err.Error(), err.Error())
+		return
+	}
+	resp.State.Raw = value
+}
+
+// goTimeLayout is the layout of time.Time.String, in which the timestamps were stored before the RFC3339 format.
+const goTimeLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+// upgradeTimestampsToRFC3339 upgrades the creation and last modification times from the format of time.Time.String
+// to the RFC3339 format. The timestamps that cannot be parsed are set to null, as they are read again on refresh.
+func upgradeTimestampsToRFC3339(state map[string]any) error {
+	for _, attribute := range []string{"creation_time", "last_modified_time"} {
+		value, ok := state[attribute].(string)
+		if !ok {
+			continue
+		}
+		if _, err := time.Parse(time.RFC3339, value); err == nil {
+			continue
+		}
+		// the monotonic clock reading, if any, is not part of the layout
+		value, _, _ = strings.Cut(value, " m=")
+		timestamp, err := time.Parse(goTimeLayout, value)
+		if err != nil {
+			state[attribute] = nil
+			continue
+		}
+		state[attribute] = timestamp.Format(time.RFC3339)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package awsmt
+
+import (
+	"context"
+	"errors"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+// upgradeTestResource is a resource whose schema is at version 2: version 0 named the id "identifier", and version 1
+// named the count "size".
+type upgradeTestResource struct{}
+
+func (r *upgradeTestResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "awsmt_upgrade_test"
+}
+
+func (r *upgradeTestResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: 2,
+		Attributes: map[string]schema.Attribute{
+			"id":    schema.StringAttribute{Computed: true},
+			"count": schema.NumberAttribute{Optional: true},
+		},
+	}
+}
+
+func (r *upgradeTestResource) Create(context.Context, resource.CreateRequest, *resource.CreateResponse) {
+}
+
+func (r *upgradeTestResource) Read(context.Context, resource.ReadRequest, *resource.ReadResponse) {}
+
+func (r *upgradeTestResource) Update(context.Context, resource.UpdateRequest, *resource.UpdateResponse) {
+}
+
+func (r *upgradeTestResource) Delete(context.Context, resource.DeleteRequest, *resource.DeleteResponse) {
+}
+
+func renameAttribute(from, to string) stateUpgrade {
+	return func(state map[string]any) error {
+		if value, ok := state[from]; ok {
+			state[to] = value
+			delete(state, from)
+		}
+		return nil
+	}
+}
+
+func runStateUpgrader(t *testing.T, upgraders map[int64]resource.StateUpgrader, version int64, state string) resource.UpgradeStateResponse {
+	t.Helper()
+	var schemaResp resource.SchemaResponse
+	(&upgradeTestResource{}).Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	upgrader, ok := upgraders[version]
+	if !ok {
+		t.Fatalf("no upgrader for version %d", version)
+	}
+	resp := resource.UpgradeStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	upgrader.StateUpgrader(context.Background(), resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(state)}}, &resp)
+	return resp
+}
+
+func TestStateUpgradersApplyTheFollowingUpgrades(t *testing.T) {
+	upgraders := stateUpgraders(context.Background(), &upgradeTestResource{}, renameAttribute("identifier", "id"), renameAttribute("size", "count"))
+	if len(upgraders) != 2 {
+		t.Fatalf("expected upgraders for versions 0 and 1, got %d", len(upgraders))
+	}
+
+	for version, state := range map[int64]string{
+		0: `{"identifier": "test", "size": 9007199254740993}`,
+		1: `{"id": "test", "size": 9007199254740993}`,
+	} {
+		resp := runStateUpgrader(t, upgraders, version, state)
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+
+		var upgraded map[string]tftypes.Value
+		if err := resp.State.Raw.As(&upgraded); err != nil {
+			t.Fatal(err)
+		}
+		var id string
+		count := new(big.Float)
+		_ = upgraded["id"].As(&id)
+		_ = upgraded["count"].As(&count)
+		// the count is above the largest integer a float64 holds exactly
+		if id != "test" || count.Text('f', 0) != "9007199254740993" {
+			t.Errorf("unexpected state upgraded from version %d: %v", version, upgraded)
+		}
+	}
+}
+
+func TestStateUpgradersErrors(t *testing.T) {
+	failing := func(map[string]any) error { return errors.New("cannot upgrade") }
+	upgraders := stateUpgraders(context.Background(), &upgradeTestResource{}, renameAttribute("identifier", "id"), failing)
+	if resp := runStateUpgrader(t, upgraders, 0, `{"identifier": "test"}`); !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics[0].Summary(), "cannot upgrade") {
+		t.Errorf("expected the error of the upgrade, got %v", resp.Diagnostics)
+	}
+
+	upgraders = stateUpgraders(context.Background(), &upgradeTestResource{}, renameAttribute("identifier", "id"), renameAttribute("size", "count"))
+	if resp := runStateUpgrader(t, upgraders, 1, `{"identifier": "test"}`); !resp.Diagnostics.HasError() {
+		t.Error("expected an error for an attribute missing from the current schema")
+	}
+
+	upgraders = stateUpgraders(context.Background(), &upgradeTestResource{}, renameAttribute("identifier", "id"))
+	for version := range int64(2) {
+		if resp := runStateUpgrader(t, upgraders, version, `{"id": "test"}`); !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics[0].Detail(), "expected 2 state upgrades") {
+			t.Errorf("expected an error for the upgrades not matching the version of the schema, got %v", resp.Diagnostics)
+		}
+	}
+}
+
+func TestResourcesUpgradeEveryPriorVersion(t *testing.T) {
+	ctx := context.Background()
+	for _, newResource := range New().Resources(ctx) {
+		r := newResource()
+		var metadata resource.MetadataResponse
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "awsmt"}, &metadata)
+		var schemaResp resource.SchemaResponse
+		r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+		withUpgrade, ok := r.(resource.ResourceWithUpgradeState)
+		if !ok {
+			t.Errorf("%s does not upgrade its state", metadata.TypeName)
+			continue
+		}
+		upgraders := withUpgrade.UpgradeState(ctx)
+		for version := range schemaResp.Schema.Version {
+			if _, ok := upgraders[version]; !ok {
+				t.Errorf("%s has no upgrader for version %d of its schema", metadata.TypeName, version)
+			}
+		}
+		if int64(len(upgraders)) != schemaResp.Schema.Version {
+			t.Errorf("%s has %d upgraders for version %d of its schema", metadata.TypeName, len(upgraders), schemaResp.Schema.Version)
+		}
+		for version, upgrader := range upgraders {
+			resp := resource.UpgradeStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+			upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(`{}`)}}, &resp)
+			for _, diag := range resp.Diagnostics {
+				if strings.Contains(diag.Detail(), "state upgrades for version") {
+					t.Errorf("%s cannot upgrade version %d of its schema: %s", metadata.TypeName, version, diag.Detail())
+				}
+			}
+		}
+	}
+}
+
+func TestUpgradeTimestampsToRFC3339(t *testing.T) {
+	for value, expected := range map[string]any{
+		"2024-05-13 09:12:45.123 +0000 UTC":                   "2024-05-13T09:12:45Z",
+		"2024-05-13 11:12:45 +0200 CEST":                      "2024-05-13T11:12:45+02:00",
+		"2024-05-13 09:12:45.123456789 +0000 UTC m=+0.000001": "2024-05-13T09:12:45Z",
+		"2024-05-13T09:12:45Z":                                "2024-05-13T09:12:45Z",
+		"yesterday":                                           nil,
+	} {
+		state := map[string]any{"creation_time": value, "last_modified_time": value, "name": value}
+		if err := upgradeTimestampsToRFC3339(state); err != nil {
+			t.Fatal(err)
+		}
+		if state["creation_time"] != expected || state["last_modified_time"] != expected {
+			t.Errorf("expected %q to be upgraded to %v, got %v", value, expected, state)
+		}
+		if state["name"] != value {
+			t.Errorf("expected the other attributes to be kept, got %v", state)
+		}
+	}
+
+	state := map[string]any{"creation_time": nil}
+	if err := upgradeTimestampsToRFC3339(state); err != nil || state["creation_time"] != nil {
+		t.Errorf("expected a null timestamp to stay null, got %v", state)
+	}
+}
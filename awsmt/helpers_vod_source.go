@@ -4,6 +4,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
 	awsTypes "github.com/aws/aws-sdk-go-v2/service/mediatailor/types"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"terraform-provider-mediatailor/awsmt/models"
@@ -14,8 +15,8 @@ func getCreateVodSourceInput(model models.VodSourceModel) *mediatailor.CreateVod
 
 	input.HttpPackageConfigurations, input.VodSourceName, input.SourceLocationName = getSharedVodSourceInput(&model)
 
-	if len(model.Tags) > 0 {
-		input.Tags = model.Tags
+	if tags := tagsAllToMap(model.TagsAll); len(tags) > 0 {
+		input.Tags = tags
 	}
 
 	return &input
@@ -61,7 +62,7 @@ func readVodSourceToPlan(model models.VodSourceModel, vodSource mediatailor.Crea
 	}
 
 	if vodSource.CreationTime != nil {
-		model.CreationTime = types.StringValue(vodSource.CreationTime.String())
+		model.CreationTime = timetypes.NewRFC3339TimeValue(*vodSource.CreationTime)
 	}
 
 	if len(vodSource.HttpPackageConfigurations) > 0 {
@@ -76,7 +77,7 @@ func readVodSourceToPlan(model models.VodSourceModel, vodSource mediatailor.Crea
 	}
 
 	if vodSource.LastModifiedTime != nil {
-		model.LastModifiedTime = types.StringValue(vodSource.LastModifiedTime.String())
+		model.LastModifiedTime = timetypes.NewRFC3339TimeValue(*vodSource.LastModifiedTime)
 	}
 
 	if vodSource.SourceLocationName != nil {
@@ -90,6 +91,7 @@ func readVodSourceToPlan(model models.VodSourceModel, vodSource mediatailor.Crea
 	if len(vodSource.Tags) > 0 {
 		model.Tags = vodSource.Tags
 	}
+	model.TagsAll = tagsAllValue(vodSource.Tags)
 
 	model.AdBreakOpportunitiesOffsetMillis, _ = types.ListValue(types.Int64Type, []attr.Value{})
 
@@ -0,0 +1,348 @@
+package awsmt
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"terraform-provider-mediatailor/awsmt/models"
+)
+
+// vodSourceSetFileItem is a vod source of the JSON files of the vod source sets.
+type vodSourceSetFileItem struct {
+	HttpPackageConfigurations []struct {
+		Path        string `json:"path"`
+		SourceGroup string `json:"source_group"`
+		Type        string `json:"type"`
+	} `json:"http_package_configurations"`
+}
+
+// vodSourceSetCsvHeader is the header expected in the CSV files of the vod source sets.
+var vodSourceSetCsvHeader = []string{"name", "path", "source_group", "type"}
+
+// loadVodSourceSetFile reads the vod sources of a JSON or CSV file, depending on the extension of the file.
+func loadVodSourceSetFile(name string) (map[string]models.VodSourceSetItemModel, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var vodSources map[string]models.VodSourceSetItemModel
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		vodSources, err = readVodSourceSetJson(f)
+	case ".csv":
+		vodSources, err = readVodSourceSetCsv(f)
+	default:
+		return nil, fmt.Errorf("expected a .json or .csv file, got %s", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return vodSources, nil
+}
+
+// readVodSourceSetJson reads an object whose keys are the names of the vod sources, and whose values hold their
+// http_package_configurations, like the vod_sources attribute.
+func readVodSourceSetJson(r io.Reader) (map[string]models.VodSourceSetItemModel, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	var items map[string]vodSourceSetFileItem
+	if err := decoder.Decode(&items); err != nil {
+		return nil, err
+	}
+
+	vodSources := map[string]models.VodSourceSetItemModel{}
+	for name, item := range items {
+		var vodSource models.VodSourceSetItemModel
+		for _, c := range item.HttpPackageConfigurations {
+			vodSource.HttpPackageConfigurations = append(vodSource.HttpPackageConfigurations, models.HttpPackageConfigurationsModel{
+				Path:        &c.Path,
+				SourceGroup: &c.SourceGroup,
+				Type:        &c.Type,
+			})
+		}
+		vodSources[name] = vodSource
+	}
+	return vodSources, validateVodSourceSet(vodSources)
+}
+
+// readVodSourceSetCsv reads the rows of a CSV file with the columns name, path, source_group and type. Each row is a
+// package configuration, the rows with the same name are the package configurations of a vod source.
+func readVodSourceSetCsv(r io.Reader) (map[string]models.VodSourceSetItemModel, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = len(vodSourceSetCsvHeader)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Equal(header, vodSourceSetCsvHeader) {
+		return nil, fmt.Errorf("expected the header %s, got %s", strings.Join(vodSourceSetCsvHeader, ","), strings.Join(header, ","))
+	}
+
+	vodSources := map[string]models.VodSourceSetItemModel{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		vodSource := vodSources[record[0]]
+		vodSource.HttpPackageConfigurations = append(vodSource.HttpPackageConfigurations, models.HttpPackageConfigurationsModel{
+			Path:        &record[1],
+			SourceGroup: &record[2],
+			Type:        &record[3],
+		})
+		vodSources[record[0]] = vodSource
+	}
+	return vodSources, validateVodSourceSet(vodSources)
+}
+
+// validateVodSourceSet applies the validations of the vod_sources attribute to the vod sources of a file.
+func validateVodSourceSet(vodSources map[string]models.VodSourceSetItemModel) error {
+	for _, name := range slices.Sorted(maps.Keys(vodSources)) {
+		if name == "" {
+			return errors.New("the name of a vod source is empty")
+		}
+		if len(vodSources[name].HttpPackageConfigurations) == 0 {
+			return fmt.Errorf("the vod source %s has no http package configuration", name)
+		}
+		for _, c := range vodSources[name].HttpPackageConfigurations {
+			if *c.Path == "" || *c.SourceGroup == "" {
+				return fmt.Errorf("the vod source %s has a package configuration without path or source group", name)
+			}
+			if *c.Type != "HLS" && *c.Type != "DASH" {
+				return fmt.Errorf("the vod source %s has a package configuration of type %q, expected HLS or DASH", name, *c.Type)
+			}
+		}
+	}
+	return nil
+}
+
+// listedVodSource is a vod source of the source location of a set, with its ARN and its tags that are not ignored.
+type listedVodSource struct {
+	models.VodSourceSetItemModel
+	arn  *string
+	tags map[string]string
+}
+
+// listVodSourceSet lists the vod sources of a source location with their package configurations and their tags.
+func listVodSourceSet(ctx context.Context, client mediaTailorClient, c tagsConfig, sourceLocationName *string) (map[string]listedVodSource, error) {
+	vodSources := map[string]listedVodSource{}
+	paginator := mediatailor.NewListVodSourcesPaginator(client, &mediatailor.ListVodSourcesInput{SourceLocationName: sourceLocationName})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, vodSource := range page.Items {
+			vodSources[*vodSource.VodSourceName] = listedVodSource{
+				VodSourceSetItemModel: models.VodSourceSetItemModel{HttpPackageConfigurations: readHttpPackageConfigurations(vodSource.HttpPackageConfigurations)},
+				arn:                   vodSource.Arn,
+				tags:                  c.withoutIgnored(vodSource.Tags),
+			}
+		}
+	}
+	return vodSources, nil
+}
+
+// managedVodSources returns the listed vod sources managed by a set, which are the ones of its state and of its plan.
+// The managed vod sources whose package configurations are only listed in another order are kept as they are.
+func managedVodSources(listed map[string]listedVodSource, managed ...map[string]models.VodSourceSetItemModel) map[string]models.VodSourceSetItemModel {
+	vodSources := map[string]models.VodSourceSetItemModel{}
+	for _, m := range managed {
+		for name, item := range m {
+			vodSource, ok := listed[name]
+			switch {
+			case !ok:
+			case equalHttpPackageConfigurations(vodSource.HttpPackageConfigurations, item.HttpPackageConfigurations):
+				vodSources[name] = item
+			default:
+				vodSources[name] = vodSource.VodSourceSetItemModel
+			}
+		}
+	}
+	return vodSources
+}
+
+// driftedVodSourceSetTags returns the tags of the first managed vod source, by name, whose tags differ from the tags of
+// the set, and whether there is one.
+func driftedVodSourceSetTags(listed map[string]listedVodSource, managed map[string]models.VodSourceSetItemModel, tags map[string]string) (map[string]string, bool) {
+	for _, name := range slices.Sorted(maps.Keys(managed)) {
+		if vodSource, ok := listed[name]; ok && !maps.Equal(vodSource.tags, tags) {
+			return vodSource.tags, true
+		}
+	}
+	return nil, false
+}
+
+type vodSourceSetOperation string
+
+const (
+	vodSourceSetCreate vodSourceSetOperation = "creating"
+	vodSourceSetUpdate vodSourceSetOperation = "updating"
+	vodSourceSetTag    vodSourceSetOperation = "tagging"
+	vodSourceSetDelete vodSourceSetOperation = "deleting"
+)
+
+// vodSourceSetChange is a vod source to create, update, tag or delete. The tags of the vod sources created, updated or
+// tagged are replaced by the tags of the set.
+type vodSourceSetChange struct {
+	operation vodSourceSetOperation
+	name      string
+	vodSource models.VodSourceSetItemModel
+	arn       *string
+	oldTags   map[string]string
+	tags      map[string]string
+}
+
+// vodSourceSetChanges returns the changes turning the current vod sources of a set into the planned ones with the
+// planned tags, sorted by name, and the names of the planned vod sources that exist without being managed by the set.
+// Those are left untouched, as the set would otherwise delete vod sources it did not create.
+func vodSourceSetChanges(current map[string]models.VodSourceSetItemModel, listed map[string]listedVodSource, planned map[string]models.VodSourceSetItemModel, tags map[string]string) ([]vodSourceSetChange, []string) {
+	var changes []vodSourceSetChange
+	var unmanaged []string
+	for _, name := range slices.Sorted(maps.Keys(planned)) {
+		vodSource, ok := listed[name]
+		_, managed := current[name]
+		change := vodSourceSetChange{name: name, vodSource: planned[name], arn: vodSource.arn, oldTags: vodSource.tags, tags: tags}
+		switch {
+		case !ok:
+			change.operation = vodSourceSetCreate
+		case !managed:
+			unmanaged = append(unmanaged, name)
+			continue
+		case !equalHttpPackageConfigurations(vodSource.HttpPackageConfigurations, planned[name].HttpPackageConfigurations):
+			change.operation = vodSourceSetUpdate
+		case !maps.Equal(vodSource.tags, tags):
+			change.operation = vodSourceSetTag
+		default:
+			continue
+		}
+		changes = append(changes, change)
+	}
+	for _, name := range slices.Sorted(maps.Keys(current)) {
+		if _, ok := planned[name]; !ok {
+			changes = append(changes, vodSourceSetChange{operation: vodSourceSetDelete, name: name})
+		}
+	}
+	return changes, unmanaged
+}
+
+// httpPackageConfigurationKey identifies a package configuration of a vod source.
+type httpPackageConfigurationKey struct {
+	path, sourceGroup, packageType string
+}
+
+// equalHttpPackageConfigurations compares the package configurations of vod sources regardless of their order, which
+// MediaTailor does not keep.
+func equalHttpPackageConfigurations(a, b []models.HttpPackageConfigurationsModel) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := map[httpPackageConfigurationKey]int{}
+	for _, c := range a {
+		counts[httpPackageConfigurationKey{*c.Path, *c.SourceGroup, *c.Type}]++
+	}
+	for _, c := range b {
+		key := httpPackageConfigurationKey{*c.Path, *c.SourceGroup, *c.Type}
+		if counts[key] == 0 {
+			return false
+		}
+		counts[key]--
+	}
+	return true
+}
+
+// @ADR
+// Context: Catalogues of tens of thousands of vod sources were managed with one awsmt_vod_source resource each, which
+// made the state huge and the plans slow.
+// Decision: The awsmt_vod_source_set resource manages the vod sources of a source location as a map of names to
+// package configurations. It lists the vod sources of the source location with the paginated List operation to find
+// the changes, and applies them concurrently, with at most `parallelism` requests at once.
+// Consequences: A failed vod source does not stop the others: every failure is reported with the name of its vod
+// source, and the state records the changes that succeeded, so that the next apply retries the failed ones only. The
+// tags of the set apply to each of its vod sources, whose ad break opportunities are not read.
+func applyVodSourceSetChanges(ctx context.Context, client mediaTailorClient, sourceLocationName *string, changes []vodSourceSetChange, parallelism int) []error {
+	errs := make([]error, len(changes))
+	slots := make(chan struct{}, max(parallelism, 1))
+	var wg sync.WaitGroup
+	for i, change := range changes {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			errs[i] = applyVodSourceSetChange(ctx, client, sourceLocationName, change)
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+func applyVodSourceSetChange(ctx context.Context, client mediaTailorClient, sourceLocationName *string, change vodSourceSetChange) error {
+	var err error
+	switch change.operation {
+	case vodSourceSetCreate:
+		params := &mediatailor.CreateVodSourceInput{
+			SourceLocationName:        sourceLocationName,
+			VodSourceName:             &change.name,
+			HttpPackageConfigurations: getHttpPackageConfigurations(change.vodSource.HttpPackageConfigurations),
+		}
+		if len(change.tags) > 0 {
+			params.Tags = change.tags
+		}
+		_, err = client.CreateVodSource(ctx, params)
+	case vodSourceSetUpdate:
+		_, err = client.UpdateVodSource(ctx, &mediatailor.UpdateVodSourceInput{
+			SourceLocationName:        sourceLocationName,
+			VodSourceName:             &change.name,
+			HttpPackageConfigurations: getHttpPackageConfigurations(change.vodSource.HttpPackageConfigurations),
+		})
+		if err == nil {
+			err = UpdatesTags(client, change.oldTags, change.tags, *change.arn)
+		}
+	case vodSourceSetTag:
+		err = UpdatesTags(client, change.oldTags, change.tags, *change.arn)
+	case vodSourceSetDelete:
+		_, err = client.DeleteVodSource(ctx, &mediatailor.DeleteVodSourceInput{
+			SourceLocationName: sourceLocationName,
+			VodSourceName:      &change.name,
+		})
+	}
+	return err
+}
+
+// recordVodSourceSetChanges returns the vod sources of the set once the changes are applied, keeping the current vod
+// sources of the failed changes.
+func recordVodSourceSetChanges(current map[string]models.VodSourceSetItemModel, changes []vodSourceSetChange, errs []error) map[string]models.VodSourceSetItemModel {
+	vodSources := maps.Clone(current)
+	for i, change := range changes {
+		if errs[i] != nil {
+			continue
+		}
+		switch change.operation {
+		case vodSourceSetDelete:
+			delete(vodSources, change.name)
+		case vodSourceSetCreate, vodSourceSetUpdate:
+			vodSources[change.name] = change.vodSource
+		}
+	}
+	return vodSources
+}
@@ -0,0 +1,202 @@
+package awsmt
+
+import (
+	"context"
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"terraform-provider-mediatailor/awsmt/fake"
+	"terraform-provider-mediatailor/awsmt/models"
+	"testing"
+)
+
+func vodSourceSetItem(paths ...string) models.VodSourceSetItemModel {
+	var item models.VodSourceSetItemModel
+	for _, p := range paths {
+		item.HttpPackageConfigurations = append(item.HttpPackageConfigurations, models.HttpPackageConfigurationsModel{
+			Path:        aws.String(p),
+			SourceGroup: aws.String("default"),
+			Type:        aws.String("HLS"),
+		})
+	}
+	return item
+}
+
+func writeVodSourceSetFile(t *testing.T, name, content string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestLoadVodSourceSetFile(t *testing.T) {
+	jsonFile := writeVodSourceSetFile(t, "assets.json", `{
+		"movie": {"http_package_configurations": [{"path": "/movie/hls", "source_group": "default", "type": "HLS"}, {"path": "/movie/dash", "source_group": "default", "type": "DASH"}]},
+		"trailer": {"http_package_configurations": [{"path": "/trailer", "source_group": "default", "type": "HLS"}]}
+	}`)
+	csvFile := writeVodSourceSetFile(t, "assets.CSV", "name,path,source_group,type\nmovie,/movie/hls,default,HLS\ntrailer, /trailer,default,HLS\nmovie,/movie/dash,default,DASH\n")
+
+	for _, file := range []string{jsonFile, csvFile} {
+		vodSources, err := loadVodSourceSetFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if names := slices.Sorted(maps.Keys(vodSources)); !slices.Equal(names, []string{"movie", "trailer"}) {
+			t.Errorf("unexpected vod sources %v in %s", names, file)
+		}
+		movie := vodSources["movie"].HttpPackageConfigurations
+		if len(movie) != 2 || *movie[0].Path != "/movie/hls" || *movie[1].Type != "DASH" {
+			t.Errorf("unexpected package configurations of the movie in %s: %+v", file, movie)
+		}
+		if path := *vodSources["trailer"].HttpPackageConfigurations[0].Path; path != "/trailer" {
+			t.Errorf("unexpected path %q of the trailer in %s", path, file)
+		}
+	}
+}
+
+func TestLoadVodSourceSetFileErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{"assets.yaml", "", "expected a .json or .csv file"},
+		{"assets.json", `{"movie": {"paths": []}}`, "unknown field"},
+		{"assets.json", `{"movie": {"http_package_configurations": []}}`, "the vod source movie has no http package configuration"},
+		{"assets.csv", "name,path,type\nmovie,/,HLS\n", "wrong number of fields"},
+		{"assets.csv", "name,path,group,type\nmovie,/,default,HLS\n", "expected the header name,path,source_group,type"},
+		{"assets.csv", "name,path,source_group,type\nmovie,/,default,MP4\n", `of type "MP4", expected HLS or DASH`},
+		{"assets.csv", "name,path,source_group,type\n,/,default,HLS\n", "the name of a vod source is empty"},
+	} {
+		_, err := loadVodSourceSetFile(writeVodSourceSetFile(t, tc.name, tc.content))
+		if err == nil || !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("expected an error containing %q for %s, got %v", tc.expected, tc.content, err)
+		}
+	}
+}
+
+func TestVodSourceSetChanges(t *testing.T) {
+	tags := map[string]string{"Team": "video"}
+	listed := map[string]listedVodSource{
+		"unchanged": {VodSourceSetItemModel: vodSourceSetItem("/"), tags: tags},
+		"changed":   {VodSourceSetItemModel: vodSourceSetItem("/"), tags: tags},
+		"reordered": {VodSourceSetItemModel: vodSourceSetItem("/dash", "/"), tags: tags},
+		"retagged":  {VodSourceSetItemModel: vodSourceSetItem("/"), tags: map[string]string{"Team": "news"}},
+		"removed":   {VodSourceSetItemModel: vodSourceSetItem("/"), tags: tags},
+		"unmanaged": {VodSourceSetItemModel: vodSourceSetItem("/")},
+		"existing":  {VodSourceSetItemModel: vodSourceSetItem("/")},
+	}
+	current := managedVodSources(listed, map[string]models.VodSourceSetItemModel{
+		"unchanged": vodSourceSetItem("/"),
+		"changed":   vodSourceSetItem("/"),
+		"reordered": vodSourceSetItem("/", "/dash"),
+		"retagged":  vodSourceSetItem("/"),
+		"removed":   vodSourceSetItem("/"),
+		"gone":      vodSourceSetItem("/"),
+	})
+	planned := map[string]models.VodSourceSetItemModel{
+		"unchanged": vodSourceSetItem("/"),
+		"changed":   vodSourceSetItem("/", "/dash"),
+		"reordered": vodSourceSetItem("/dash", "/"),
+		"retagged":  vodSourceSetItem("/"),
+		"existing":  vodSourceSetItem("/existing"),
+		"new":       vodSourceSetItem("/"),
+	}
+
+	// the package configurations listed in another order are kept in the order of the state
+	if path := *current["reordered"].HttpPackageConfigurations[0].Path; path != "/" {
+		t.Errorf("expected the reordered vod source to keep the order of the state, got %s first", path)
+	}
+
+	var changes []string
+	plannedChanges, unmanaged := vodSourceSetChanges(current, listed, planned, tags)
+	for _, change := range plannedChanges {
+		changes = append(changes, string(change.operation)+" "+change.name)
+	}
+	expected := []string{"updating changed", "creating new", "tagging retagged", "deleting removed"}
+	if !slices.Equal(changes, expected) {
+		t.Errorf("expected the changes %v, got %v", expected, changes)
+	}
+	if !slices.Equal(unmanaged, []string{"existing"}) {
+		t.Errorf("expected the existing vod source missing from the state to be reported, got %v", unmanaged)
+	}
+}
+
+// failingVodSourceClient fails the creation of the vod sources whose name starts with "broken".
+type failingVodSourceClient struct {
+	*fake.Client
+}
+
+func (c *failingVodSourceClient) CreateVodSource(ctx context.Context, params *mediatailor.CreateVodSourceInput, optFns ...func(*mediatailor.Options)) (*mediatailor.CreateVodSourceOutput, error) {
+	if strings.HasPrefix(*params.VodSourceName, "broken") {
+		return nil, errors.New("invalid package configuration")
+	}
+	return c.Client.CreateVodSource(ctx, params, optFns...)
+}
+
+func TestApplyVodSourceSetChanges(t *testing.T) {
+	client := &failingVodSourceClient{Client: newListTestClient(t)}
+	ctx := context.Background()
+	sourceLocationName := aws.String("test_a")
+
+	planned := map[string]models.VodSourceSetItemModel{"vod": vodSourceSetItem("/updated")}
+	for _, name := range []string{"a", "b", "c", "d", "e", "broken_a", "broken_b"} {
+		planned[name] = vodSourceSetItem("/" + name)
+	}
+	listed, err := listVodSourceSet(ctx, client, tagsConfig{}, sourceLocationName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	current := managedVodSources(listed, map[string]models.VodSourceSetItemModel{"vod": vodSourceSetItem("/")})
+	tags := map[string]string{"Team": "video"}
+	changes, unmanaged := vodSourceSetChanges(current, listed, planned, tags)
+	if len(unmanaged) != 0 {
+		t.Fatalf("expected no unmanaged vod source, got %v", unmanaged)
+	}
+	errs := applyVodSourceSetChanges(ctx, client, sourceLocationName, changes, 3)
+
+	var failed []string
+	for i, change := range changes {
+		if errs[i] != nil {
+			failed = append(failed, change.name)
+		}
+	}
+	if !slices.Equal(failed, []string{"broken_a", "broken_b"}) {
+		t.Errorf("expected the broken vod sources to fail, got %v", failed)
+	}
+
+	recorded := recordVodSourceSetChanges(current, changes, errs)
+	if names := slices.Sorted(maps.Keys(recorded)); !slices.Equal(names, []string{"a", "b", "c", "d", "e", "vod"}) {
+		t.Errorf("expected the vod sources that succeeded to be recorded, got %v", names)
+	}
+
+	listed, err = listVodSourceSet(ctx, client, tagsConfig{}, sourceLocationName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !maps.EqualFunc(listed, recorded, func(a listedVodSource, b models.VodSourceSetItemModel) bool {
+		return equalHttpPackageConfigurations(a.HttpPackageConfigurations, b.HttpPackageConfigurations)
+	}) {
+		t.Errorf("expected the recorded vod sources to match the listed ones, got %v and %v", recorded, listed)
+	}
+	for name, vodSource := range listed {
+		if !maps.Equal(vodSource.tags, tags) {
+			t.Errorf("expected the vod source %s to have the tags of the set, got %v", name, vodSource.tags)
+		}
+	}
+
+	changes, _ = vodSourceSetChanges(recorded, listed, nil, nil)
+	if errs := applyVodSourceSetChanges(ctx, client, sourceLocationName, changes, 3); errors.Join(errs...) != nil {
+		t.Fatal(errors.Join(errs...))
+	}
+	if listed, err = listVodSourceSet(ctx, client, tagsConfig{}, sourceLocationName); err != nil || len(listed) != 0 {
+		t.Errorf("expected the vod sources to be deleted, got %v and %v", listed, err)
+	}
+}
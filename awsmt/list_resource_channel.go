@@ -0,0 +1,96 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"strings"
+	"terraform-provider-mediatailor/awsmt/models"
+)
+
+var (
+	_ list.ListResource              = &listResourceChannel{}
+	_ list.ListResourceWithConfigure = &listResourceChannel{}
+)
+
+func ListResourceChannel() list.ListResource {
+	return &listResourceChannel{}
+}
+
+type listResourceChannel struct {
+	client mediaTailorClient
+	tags   tagsConfig
+}
+
+func (l *listResourceChannel) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_channel"
+}
+
+func (l *listResourceChannel) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listFilterSchema
+}
+
+func (l *listResourceChannel) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data := req.ProviderData.(*providerData)
+	l.client = data.client
+	l.tags = data.tags
+}
+
+func (l *listResourceChannel) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	filter, ok := getListFilter[models.ListFilterModel](ctx, req, stream)
+	if !ok {
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		push = limitResults(req.Limit, push)
+		paginator := mediatailor.NewListChannelsPaginator(l.client, &mediatailor.ListChannelsInput{})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				push(listErrorResult("Error while listing channels", err))
+				return
+			}
+			for _, channel := range page.Items {
+				if !matchesListFilter(filter.NamePrefix, filter.Tags, channel.ChannelName, channel.Tags) {
+					continue
+				}
+				result := newListResult(ctx, req, *channel.ChannelName, channel.Arn, func() (any, error) {
+					return l.read(ctx, channel.ChannelName)
+				})
+				if !push(result) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (l *listResourceChannel) read(ctx context.Context, name *string) (models.ChannelResourceModel, error) {
+	channel, err := l.client.DescribeChannel(ctx, &mediatailor.DescribeChannelInput{ChannelName: name})
+	if err != nil {
+		return models.ChannelResourceModel{}, err
+	}
+
+	policy, err := l.client.GetChannelPolicy(ctx, &mediatailor.GetChannelPolicyInput{ChannelName: name})
+	if err != nil && !strings.Contains(err.Error(), "NotFound") {
+		return models.ChannelResourceModel{}, err
+	}
+
+	model := writeChannelToState(models.ChannelModel{}, *channel)
+	model.Tags, model.TagsAll = l.tags.readTags(channel.Tags, nil)
+	channelState := string(channel.ChannelState)
+	model.ChannelState = &channelState
+	if policy != nil && policy.Policy != nil {
+		model.Policy = jsontypes.NewNormalizedPointerValue(policy.Policy)
+	} else {
+		model.Policy = jsontypes.NewNormalizedNull()
+	}
+	return models.ChannelResourceModel{ChannelModel: model, Outputs: readOutputsByManifestName(nil, channel.Outputs)}, nil
+}
@@ -0,0 +1,88 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"terraform-provider-mediatailor/awsmt/models"
+)
+
+var (
+	_ list.ListResource              = &listResourceLiveSource{}
+	_ list.ListResourceWithConfigure = &listResourceLiveSource{}
+)
+
+func ListResourceLiveSource() list.ListResource {
+	return &listResourceLiveSource{}
+}
+
+type listResourceLiveSource struct {
+	client mediaTailorClient
+	tags   tagsConfig
+}
+
+func (l *listResourceLiveSource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_live_source"
+}
+
+func (l *listResourceLiveSource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = contentSourceListFilterSchema
+}
+
+func (l *listResourceLiveSource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data := req.ProviderData.(*providerData)
+	l.client = data.client
+	l.tags = data.tags
+}
+
+func (l *listResourceLiveSource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	filter, ok := getListFilter[models.ContentSourceListFilterModel](ctx, req, stream)
+	if !ok {
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		push = limitResults(req.Limit, push)
+		for sourceLocationName, err := range listSourceLocationNames(ctx, l.client, filter.SourceLocationName) {
+			if err != nil {
+				push(listErrorResult("Error while listing source locations", err))
+				return
+			}
+			paginator := mediatailor.NewListLiveSourcesPaginator(l.client, &mediatailor.ListLiveSourcesInput{SourceLocationName: &sourceLocationName})
+			for paginator.HasMorePages() {
+				page, err := paginator.NextPage(ctx)
+				if err != nil {
+					push(listErrorResult("Error while listing live sources", err))
+					return
+				}
+				for _, liveSource := range page.Items {
+					if !matchesListFilter(filter.NamePrefix, filter.Tags, liveSource.LiveSourceName, liveSource.Tags) {
+						continue
+					}
+					result := newListResult(ctx, req, sourceLocationName+","+*liveSource.LiveSourceName, liveSource.Arn, func() (any, error) {
+						return l.read(ctx, liveSource.SourceLocationName, liveSource.LiveSourceName)
+					})
+					if !push(result) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+func (l *listResourceLiveSource) read(ctx context.Context, sourceLocationName, name *string) (models.LiveSourceModel, error) {
+	liveSource, err := l.client.DescribeLiveSource(ctx, &mediatailor.DescribeLiveSourceInput{SourceLocationName: sourceLocationName, LiveSourceName: name})
+	if err != nil {
+		return models.LiveSourceModel{}, err
+	}
+
+	model := readLiveSource(models.LiveSourceModel{}, mediatailor.CreateLiveSourceOutput(*liveSource))
+	model.Tags, model.TagsAll = l.tags.readTags(liveSource.Tags, nil)
+	return model, nil
+}
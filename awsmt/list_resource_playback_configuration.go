@@ -0,0 +1,83 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"terraform-provider-mediatailor/awsmt/models"
+)
+
+var (
+	_ list.ListResource              = &listResourcePlaybackConfiguration{}
+	_ list.ListResourceWithConfigure = &listResourcePlaybackConfiguration{}
+)
+
+func ListResourcePlaybackConfiguration() list.ListResource {
+	return &listResourcePlaybackConfiguration{}
+}
+
+type listResourcePlaybackConfiguration struct {
+	client mediaTailorClient
+	tags   tagsConfig
+}
+
+func (l *listResourcePlaybackConfiguration) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_playback_configuration"
+}
+
+func (l *listResourcePlaybackConfiguration) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listFilterSchema
+}
+
+func (l *listResourcePlaybackConfiguration) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data := req.ProviderData.(*providerData)
+	l.client = data.client
+	l.tags = data.tags
+}
+
+func (l *listResourcePlaybackConfiguration) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	filter, ok := getListFilter[models.ListFilterModel](ctx, req, stream)
+	if !ok {
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		push = limitResults(req.Limit, push)
+		paginator := mediatailor.NewListPlaybackConfigurationsPaginator(l.client, &mediatailor.ListPlaybackConfigurationsInput{})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				push(listErrorResult("Error while listing playback configurations", err))
+				return
+			}
+			for _, playbackConfiguration := range page.Items {
+				if !matchesListFilter(filter.NamePrefix, filter.Tags, playbackConfiguration.Name, playbackConfiguration.Tags) {
+					continue
+				}
+				result := newListResult(ctx, req, *playbackConfiguration.Name, playbackConfiguration.PlaybackConfigurationArn, func() (any, error) {
+					return l.read(ctx, playbackConfiguration.Name)
+				})
+				if !push(result) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (l *listResourcePlaybackConfiguration) read(ctx context.Context, name *string) (models.PlaybackConfigurationModel, error) {
+	playbackConfiguration, err := l.client.GetPlaybackConfiguration(ctx, &mediatailor.GetPlaybackConfigurationInput{Name: name})
+	if err != nil {
+		return models.PlaybackConfigurationModel{}, err
+	}
+
+	m := putPlaybackConfigurationModelbuilder{model: &models.PlaybackConfigurationModel{}, output: mediatailor.PutPlaybackConfigurationOutput(*playbackConfiguration), isResource: true}
+	model := m.getModel()
+	model.Tags, model.TagsAll = l.tags.readTags(playbackConfiguration.Tags, nil)
+	return model, nil
+}
@@ -0,0 +1,82 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"terraform-provider-mediatailor/awsmt/models"
+)
+
+var (
+	_ list.ListResource              = &listResourceSourceLocation{}
+	_ list.ListResourceWithConfigure = &listResourceSourceLocation{}
+)
+
+func ListResourceSourceLocation() list.ListResource {
+	return &listResourceSourceLocation{}
+}
+
+type listResourceSourceLocation struct {
+	client mediaTailorClient
+	tags   tagsConfig
+}
+
+func (l *listResourceSourceLocation) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_source_location"
+}
+
+func (l *listResourceSourceLocation) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listFilterSchema
+}
+
+func (l *listResourceSourceLocation) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data := req.ProviderData.(*providerData)
+	l.client = data.client
+	l.tags = data.tags
+}
+
+func (l *listResourceSourceLocation) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	filter, ok := getListFilter[models.ListFilterModel](ctx, req, stream)
+	if !ok {
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		push = limitResults(req.Limit, push)
+		paginator := mediatailor.NewListSourceLocationsPaginator(l.client, &mediatailor.ListSourceLocationsInput{})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				push(listErrorResult("Error while listing source locations", err))
+				return
+			}
+			for _, sourceLocation := range page.Items {
+				if !matchesListFilter(filter.NamePrefix, filter.Tags, sourceLocation.SourceLocationName, sourceLocation.Tags) {
+					continue
+				}
+				result := newListResult(ctx, req, *sourceLocation.SourceLocationName, sourceLocation.Arn, func() (any, error) {
+					return l.read(ctx, sourceLocation.SourceLocationName)
+				})
+				if !push(result) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (l *listResourceSourceLocation) read(ctx context.Context, name *string) (models.SourceLocationModel, error) {
+	sourceLocation, err := l.client.DescribeSourceLocation(ctx, &mediatailor.DescribeSourceLocationInput{SourceLocationName: name})
+	if err != nil {
+		return models.SourceLocationModel{}, err
+	}
+
+	model := writeSourceLocationToPlan(models.SourceLocationModel{}, mediatailor.CreateSourceLocationOutput(*sourceLocation))
+	model.Tags, model.TagsAll = l.tags.readTags(sourceLocation.Tags, nil)
+	return model, nil
+}
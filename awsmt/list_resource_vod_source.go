@@ -0,0 +1,88 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"terraform-provider-mediatailor/awsmt/models"
+)
+
+var (
+	_ list.ListResource              = &listResourceVodSource{}
+	_ list.ListResourceWithConfigure = &listResourceVodSource{}
+)
+
+func ListResourceVodSource() list.ListResource {
+	return &listResourceVodSource{}
+}
+
+type listResourceVodSource struct {
+	client mediaTailorClient
+	tags   tagsConfig
+}
+
+func (l *listResourceVodSource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vod_source"
+}
+
+func (l *listResourceVodSource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = contentSourceListFilterSchema
+}
+
+func (l *listResourceVodSource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data := req.ProviderData.(*providerData)
+	l.client = data.client
+	l.tags = data.tags
+}
+
+func (l *listResourceVodSource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	filter, ok := getListFilter[models.ContentSourceListFilterModel](ctx, req, stream)
+	if !ok {
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		push = limitResults(req.Limit, push)
+		for sourceLocationName, err := range listSourceLocationNames(ctx, l.client, filter.SourceLocationName) {
+			if err != nil {
+				push(listErrorResult("Error while listing source locations", err))
+				return
+			}
+			paginator := mediatailor.NewListVodSourcesPaginator(l.client, &mediatailor.ListVodSourcesInput{SourceLocationName: &sourceLocationName})
+			for paginator.HasMorePages() {
+				page, err := paginator.NextPage(ctx)
+				if err != nil {
+					push(listErrorResult("Error while listing vod sources", err))
+					return
+				}
+				for _, vodSource := range page.Items {
+					if !matchesListFilter(filter.NamePrefix, filter.Tags, vodSource.VodSourceName, vodSource.Tags) {
+						continue
+					}
+					result := newListResult(ctx, req, sourceLocationName+","+*vodSource.VodSourceName, vodSource.Arn, func() (any, error) {
+						return l.read(ctx, vodSource.SourceLocationName, vodSource.VodSourceName)
+					})
+					if !push(result) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+func (l *listResourceVodSource) read(ctx context.Context, sourceLocationName, name *string) (models.VodSourceModel, error) {
+	vodSource, err := l.client.DescribeVodSource(ctx, &mediatailor.DescribeVodSourceInput{SourceLocationName: sourceLocationName, VodSourceName: name})
+	if err != nil {
+		return models.VodSourceModel{}, err
+	}
+
+	model := readVodSourceToState(models.VodSourceModel{}, *vodSource)
+	model.Tags, model.TagsAll = l.tags.readTags(vodSource.Tags, nil)
+	return model, nil
+}
@@ -0,0 +1,197 @@
+package awsmt
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
+	awsTypes "github.com/aws/aws-sdk-go-v2/service/mediatailor/types"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"slices"
+	"terraform-provider-mediatailor/awsmt/fake"
+	"terraform-provider-mediatailor/awsmt/models"
+	"testing"
+)
+
+// listResults runs a list resource against the fake with the given configuration and returns its results.
+func listResults(t *testing.T, client *fake.Client, l list.ListResource, r resource.Resource, config map[string]tftypes.Value, limit int64) []list.ListResult {
+	t.Helper()
+	ctx := context.Background()
+	data := &providerData{client: client, region: fake.DefaultRegion}
+	l.(list.ListResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{ProviderData: data}, &resource.ConfigureResponse{})
+
+	var configSchema list.ListResourceSchemaResponse
+	l.ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, &configSchema)
+	var resourceSchema resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &resourceSchema)
+	var identitySchema resource.IdentitySchemaResponse
+	r.(resource.ResourceWithIdentity).IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &identitySchema)
+
+	configType := configSchema.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range configType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+		if value, ok := config[name]; ok {
+			values[name] = value
+		}
+	}
+
+	req := list.ListRequest{
+		Config:                 tfsdk.Config{Schema: configSchema.Schema, Raw: tftypes.NewValue(configType, values)},
+		IncludeResource:        true,
+		Limit:                  limit,
+		ResourceSchema:         resourceSchema.Schema,
+		ResourceIdentitySchema: identitySchema.IdentitySchema,
+	}
+	var stream list.ListResultsStream
+	l.List(ctx, req, &stream)
+
+	var results []list.ListResult
+	for result := range stream.Results {
+		if result.Diagnostics.HasError() {
+			t.Fatal(result.Diagnostics)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func displayNames(results []list.ListResult) []string {
+	var names []string
+	for _, result := range results {
+		names = append(names, result.DisplayName)
+	}
+	return names
+}
+
+func newListTestClient(t *testing.T) *fake.Client {
+	t.Helper()
+	client := fake.NewClient()
+	for _, name := range []string{"test_a", "test_b", "other"} {
+		if _, err := client.CreateSourceLocation(context.TODO(), &mediatailor.CreateSourceLocationInput{
+			SourceLocationName: aws.String(name),
+			HttpConfiguration:  &awsTypes.HttpConfiguration{BaseUrl: aws.String("https://example.com")},
+			Tags:               map[string]string{"Team": name},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		httpPackageConfigurations := []awsTypes.HttpPackageConfiguration{{Path: aws.String("/"), SourceGroup: aws.String("default"), Type: awsTypes.TypeHls}}
+		if _, err := client.CreateVodSource(context.TODO(), &mediatailor.CreateVodSourceInput{
+			SourceLocationName:        aws.String(name),
+			VodSourceName:             aws.String("vod"),
+			HttpPackageConfigurations: httpPackageConfigurations,
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := client.CreateLiveSource(context.TODO(), &mediatailor.CreateLiveSourceInput{
+			SourceLocationName:        aws.String(name),
+			LiveSourceName:            aws.String("live"),
+			HttpPackageConfigurations: httpPackageConfigurations,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return client
+}
+
+func TestListSourceLocations(t *testing.T) {
+	client := newListTestClient(t)
+
+	results := listResults(t, client, ListResourceSourceLocation(), ResourceSourceLocation(), nil, 0)
+	if names := displayNames(results); !slices.Equal(names, []string{"other", "test_a", "test_b"}) {
+		t.Errorf("unexpected source locations %v", names)
+	}
+
+	results = listResults(t, client, ListResourceSourceLocation(), ResourceSourceLocation(), map[string]tftypes.Value{
+		"name_prefix": tftypes.NewValue(tftypes.String, "test_"),
+		"tags":        tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{"Team": tftypes.NewValue(tftypes.String, "test_b")}),
+	}, 0)
+	if names := displayNames(results); !slices.Equal(names, []string{"test_b"}) {
+		t.Fatalf("unexpected source locations %v", names)
+	}
+
+	var identity models.NameIdentityModel
+	results[0].Identity.Get(context.Background(), &identity)
+	if identity.Region.ValueString() != fake.DefaultRegion || identity.Name.ValueString() != "test_b" {
+		t.Errorf("unexpected identity %+v", identity)
+	}
+	var sourceLocation models.SourceLocationModel
+	results[0].Resource.Get(context.Background(), &sourceLocation)
+	if *sourceLocation.Name != "test_b" || sourceLocation.Tags["Team"] != "test_b" {
+		t.Errorf("unexpected source location %+v", sourceLocation)
+	}
+
+	if results := listResults(t, client, ListResourceSourceLocation(), ResourceSourceLocation(), nil, 2); len(results) != 2 {
+		t.Errorf("expected the results to be limited to 2, got %d", len(results))
+	}
+}
+
+func TestListVodAndLiveSources(t *testing.T) {
+	client := newListTestClient(t)
+
+	results := listResults(t, client, ListResourceVodSource(), ResourceVodSource(), nil, 0)
+	if names := displayNames(results); !slices.Equal(names, []string{"other,vod", "test_a,vod", "test_b,vod"}) {
+		t.Errorf("unexpected vod sources %v", names)
+	}
+
+	results = listResults(t, client, ListResourceLiveSource(), ResourceLiveSource(), map[string]tftypes.Value{
+		"source_location_name": tftypes.NewValue(tftypes.String, "test_a"),
+	}, 0)
+	if names := displayNames(results); !slices.Equal(names, []string{"test_a,live"}) {
+		t.Fatalf("unexpected live sources %v", names)
+	}
+	var identity models.ContentSourceIdentityModel
+	results[0].Identity.Get(context.Background(), &identity)
+	if identity.SourceLocationName.ValueString() != "test_a" || identity.Name.ValueString() != "live" {
+		t.Errorf("unexpected identity %+v", identity)
+	}
+}
+
+func TestListChannelsAndPlaybackConfigurations(t *testing.T) {
+	client := newListTestClient(t)
+	if _, err := client.CreateChannel(context.TODO(), &mediatailor.CreateChannelInput{
+		ChannelName:  aws.String("channel"),
+		PlaybackMode: awsTypes.PlaybackModeLoop,
+		Tier:         awsTypes.TierBasic,
+		Outputs: []awsTypes.RequestOutputItem{
+			{ManifestName: aws.String("index"), SourceGroup: aws.String("default"), HlsPlaylistSettings: &awsTypes.HlsPlaylistSettings{}},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.PutPlaybackConfiguration(context.TODO(), &mediatailor.PutPlaybackConfigurationInput{
+		Name:                  aws.String("playback"),
+		AdDecisionServerUrl:   aws.String("https://example.com/ads"),
+		VideoContentSourceUrl: aws.String("https://example.com/content"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	results := listResults(t, client, ListResourceChannel(), ResourceChannel(), nil, 0)
+	if names := displayNames(results); !slices.Equal(names, []string{"channel"}) {
+		t.Fatalf("unexpected channels %v", names)
+	}
+	var channel models.ChannelResourceModel
+	results[0].Resource.Get(context.Background(), &channel)
+	if *channel.ChannelState != "STOPPED" || channel.Arn.ValueString() != "arn:aws:mediatailor:eu-central-1:123456789012:channel/channel" {
+		t.Errorf("unexpected channel %+v", channel)
+	}
+
+	results = listResults(t, client, ListResourcePlaybackConfiguration(), ResourcePlaybackConfiguration(), nil, 0)
+	if names := displayNames(results); !slices.Equal(names, []string{"playback"}) {
+		t.Fatalf("unexpected playback configurations %v", names)
+	}
+	var playbackConfiguration models.PlaybackConfigurationModel
+	results[0].Resource.Get(context.Background(), &playbackConfiguration)
+	if playbackConfiguration.AdDecisionServerUrl == nil || *playbackConfiguration.AdDecisionServerUrl != "https://example.com/ads" {
+		t.Errorf("unexpected playback configuration %+v", playbackConfiguration)
+	}
+
+	if results := listResults(t, client, ListResourceChannel(), ResourceChannel(), map[string]tftypes.Value{
+		"tags": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{"Team": tftypes.NewValue(tftypes.String, "video")}),
+	}, 0); len(results) != 0 {
+		t.Errorf("expected no channel with the Team tag, got %v", displayNames(results))
+	}
+}
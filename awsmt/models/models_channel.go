@@ -2,15 +2,16 @@ package models
 
 import (
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type ChannelModel struct {
-	ID           types.String `tfsdk:"id"`
-	Arn          types.String `tfsdk:"arn"`
-	Name         *string      `tfsdk:"name"`
-	ChannelState *string      `tfsdk:"channel_state"`
-	CreationTime types.String `tfsdk:"creation_time"`
+	ID           types.String      `tfsdk:"id"`
+	Arn          types.String      `tfsdk:"arn"`
+	Name         *string           `tfsdk:"name"`
+	ChannelState *string           `tfsdk:"channel_state"`
+	CreationTime timetypes.RFC3339 `tfsdk:"creation_time"`
 	// @ADR
 	// Context: Managing the enablement and disablement of logs requires a configuration structure in the SDK.
 	// Decision: As the only log type available for channels is AS_RUN, we simplified the configuration by
@@ -18,14 +19,49 @@ type ChannelModel struct {
 	// Consequences: The process for enabling and disabling logs differs slightly from the SDK's approach.
 	EnableAsRunLogs  types.Bool           `tfsdk:"enable_as_run_logs"`
 	FillerSlate      *FillerSlateModel    `tfsdk:"filler_slate"`
-	LastModifiedTime types.String         `tfsdk:"last_modified_time"`
-	Outputs          []OutputsModel       `tfsdk:"outputs"`
+	LastModifiedTime timetypes.RFC3339    `tfsdk:"last_modified_time"`
 	PlaybackMode     *string              `tfsdk:"playback_mode"`
 	Policy           jsontypes.Normalized `tfsdk:"policy"`
 	Tags             map[string]string    `tfsdk:"tags"`
+	TagsAll          types.Map            `tfsdk:"tags_all"`
 	Tier             *string              `tfsdk:"tier"`
 }
 
+// ChannelDataSourceModel is the model of the channel data source, which lists the outputs of the channel in the order
+// returned by the API.
+type ChannelDataSourceModel struct {
+	ChannelModel
+	Outputs []OutputsModel `tfsdk:"outputs"`
+}
+
+// ChannelResourceModel is the model of the channel resource, which adds the access policy rendered by the provider and
+// the outputs keyed by manifest name to the attributes shared with the channel data source.
+type ChannelResourceModel struct {
+	ChannelModel
+	AccessPolicy *AccessPolicyModel `tfsdk:"access_policy"`
+	// @ADR
+	// Context: The outputs of the channel were a list matched to the outputs returned by the API by position, so
+	// reordering the outputs caused spurious diffs.
+	// Decision: We decided to key the outputs of the channel resource by manifest name, which is unique in a channel,
+	// and to upgrade the list of version 0 of the schema to a map.
+	// Consequences: The outputs are matched by manifest name, and their order in the configuration does not matter.
+	// The data source keeps the list of outputs.
+	Outputs map[string]ChannelOutputModel `tfsdk:"outputs"`
+}
+
+type AccessPolicyModel struct {
+	AllowAnonymousGetManifest types.Bool                   `tfsdk:"allow_anonymous_get_manifest"`
+	AllowedPrincipals         []string                     `tfsdk:"allowed_principals"`
+	SourceIpCidrs             []string                     `tfsdk:"source_ip_cidrs"`
+	Conditions                []AccessPolicyConditionModel `tfsdk:"conditions"`
+}
+
+type AccessPolicyConditionModel struct {
+	Test     *string  `tfsdk:"test"`
+	Variable *string  `tfsdk:"variable"`
+	Values   []string `tfsdk:"values"`
+}
+
 type FillerSlateModel struct {
 	SourceLocationName *string `tfsdk:"source_location_name"`
 	VodSourceName      *string `tfsdk:"vod_source_name"`
@@ -39,6 +75,14 @@ type OutputsModel struct {
 	SourceGroup          *string                    `tfsdk:"source_group"`
 }
 
+// ChannelOutputModel is an output of the channel resource, whose manifest name is the key of the output.
+type ChannelOutputModel struct {
+	DashPlaylistSettings *DashPlaylistSettingsModel `tfsdk:"dash_playlist_settings"`
+	HlsPlaylistSettings  *HlsPlaylistSettingsModel  `tfsdk:"hls_playlist_settings"`
+	PlaybackUrl          types.String               `tfsdk:"playback_url"`
+	SourceGroup          *string                    `tfsdk:"source_group"`
+}
+
 type DashPlaylistSettingsModel struct {
 	ManifestWindowSeconds             *int64 `tfsdk:"manifest_window_seconds"`
 	MinBufferTimeSeconds              *int64 `tfsdk:"min_buffer_time_seconds"`
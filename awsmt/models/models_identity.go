@@ -0,0 +1,14 @@
+package models
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type NameIdentityModel struct {
+	Region types.String `tfsdk:"region"`
+	Name   types.String `tfsdk:"name"`
+}
+
+type ContentSourceIdentityModel struct {
+	Region             types.String `tfsdk:"region"`
+	SourceLocationName types.String `tfsdk:"source_location_name"`
+	Name               types.String `tfsdk:"name"`
+}
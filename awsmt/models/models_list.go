@@ -0,0 +1,14 @@
+package models
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type ListFilterModel struct {
+	NamePrefix types.String      `tfsdk:"name_prefix"`
+	Tags       map[string]string `tfsdk:"tags"`
+}
+
+type ContentSourceListFilterModel struct {
+	SourceLocationName types.String      `tfsdk:"source_location_name"`
+	NamePrefix         types.String      `tfsdk:"name_prefix"`
+	Tags               map[string]string `tfsdk:"tags"`
+}
@@ -12,22 +12,24 @@ type PlaybackConfigurationModel struct {
 	DashConfiguration    *DashConfigurationModel      `tfsdk:"dash_configuration"`
 	// @ADR
 	// Context: The Provider Framework does not allow computed blocks
-	// Decision: We decided to flatten the Log Configuration and the HLS Configuration blocks into the resource.
+	// Decision: We decided to flatten the Log Configuration block into the resource. The HLS Configuration block was
+	// flattened as well until version 1 of the schema, which reads it as a computed nested attribute.
 	// Consequences: The schema of the object differs from that of the SDK.
-	HlsConfigurationManifestEndpointPrefix      types.String                    `tfsdk:"hls_configuration_manifest_endpoint_prefix"`
-	LogConfigurationPercentEnabled              types.Int64                     `tfsdk:"log_configuration_percent_enabled"`
-	LogConfigurationEnabledLoggingStrategies    types.List                      `tfsdk:"log_configuration_enabled_logging_strategies"`
-	LivePreRollConfiguration                    *LivePreRollConfigurationModel  `tfsdk:"live_pre_roll_configuration"`
-	ManifestProcessingRules                     *ManifestProcessingRulesModel   `tfsdk:"manifest_processing_rules"`
-	Name                                        *string                         `tfsdk:"name"`
-	PersonalizationThresholdSeconds             *int32                          `tfsdk:"personalization_threshold_seconds"`
-	PlaybackConfigurationArn                    types.String                    `tfsdk:"playback_configuration_arn"`
-	PlaybackEndpointPrefix                      types.String                    `tfsdk:"playback_endpoint_prefix"`
-	SessionInitializationEndpointPrefix         types.String                    `tfsdk:"session_initialization_endpoint_prefix"`
-	SlateAdUrl                                  *string                         `tfsdk:"slate_ad_url"`
-	Tags                                        map[string]string               `tfsdk:"tags"`
-	TranscodeProfileName                        *string                         `tfsdk:"transcode_profile_name"`
-	VideoContentSourceUrl                       *string                         `tfsdk:"video_content_source_url"`
+	HlsConfiguration                         types.Object                   `tfsdk:"hls_configuration"`
+	LogConfigurationPercentEnabled           types.Int64                    `tfsdk:"log_configuration_percent_enabled"`
+	LogConfigurationEnabledLoggingStrategies types.List                     `tfsdk:"log_configuration_enabled_logging_strategies"`
+	LivePreRollConfiguration                 *LivePreRollConfigurationModel `tfsdk:"live_pre_roll_configuration"`
+	ManifestProcessingRules                  *ManifestProcessingRulesModel  `tfsdk:"manifest_processing_rules"`
+	Name                                     *string                        `tfsdk:"name"`
+	PersonalizationThresholdSeconds          *int32                         `tfsdk:"personalization_threshold_seconds"`
+	PlaybackConfigurationArn                 types.String                   `tfsdk:"playback_configuration_arn"`
+	PlaybackEndpointPrefix                   types.String                   `tfsdk:"playback_endpoint_prefix"`
+	SessionInitializationEndpointPrefix      types.String                   `tfsdk:"session_initialization_endpoint_prefix"`
+	SlateAdUrl                               *string                        `tfsdk:"slate_ad_url"`
+	Tags                                     map[string]string              `tfsdk:"tags"`
+	TagsAll                                  types.Map                      `tfsdk:"tags_all"`
+	TranscodeProfileName                     *string                        `tfsdk:"transcode_profile_name"`
+	VideoContentSourceUrl                    *string                        `tfsdk:"video_content_source_url"`
 }
 
 type AvailSuppressionModel struct {
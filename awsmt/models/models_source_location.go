@@ -1,18 +1,22 @@
 package models
 
-import "github.com/hashicorp/terraform-plugin-framework/types"
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
 
 type SourceLocationModel struct {
 	ID                                  types.String                              `tfsdk:"id"`
 	AccessConfiguration                 *AccessConfigurationModel                 `tfsdk:"access_configuration"`
 	Arn                                 types.String                              `tfsdk:"arn"`
-	CreationTime                        types.String                              `tfsdk:"creation_time"`
+	CreationTime                        timetypes.RFC3339                         `tfsdk:"creation_time"`
 	DefaultSegmentDeliveryConfiguration *DefaultSegmentDeliveryConfigurationModel `tfsdk:"default_segment_delivery_configuration"`
 	HttpConfiguration                   *HttpConfigurationModel                   `tfsdk:"http_configuration"`
-	LastModifiedTime                    types.String                              `tfsdk:"last_modified_time"`
+	LastModifiedTime                    timetypes.RFC3339                         `tfsdk:"last_modified_time"`
 	SegmentDeliveryConfigurations       []SegmentDeliveryConfigurationsModel      `tfsdk:"segment_delivery_configurations"`
 	Name                                *string                                   `tfsdk:"name"`
 	Tags                                map[string]string                         `tfsdk:"tags"`
+	TagsAll                             types.Map                                 `tfsdk:"tags_all"`
 }
 
 type AccessConfigurationModel struct {
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type VodSourceSetModel struct {
+	ID                 types.String                     `tfsdk:"id"`
+	SourceLocationName *string                          `tfsdk:"source_location_name"`
+	VodSources         map[string]VodSourceSetItemModel `tfsdk:"vod_sources"`
+	File               types.String                     `tfsdk:"file"`
+	Parallelism        types.Int64                      `tfsdk:"parallelism"`
+	Tags               map[string]string                `tfsdk:"tags"`
+	TagsAll            types.Map                        `tfsdk:"tags_all"`
+}
+
+type VodSourceSetItemModel struct {
+	HttpPackageConfigurations []HttpPackageConfigurationsModel `tfsdk:"http_package_configurations"`
+}
@@ -2,34 +2,128 @@ package awsmt
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/aws/retry"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
 	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	stsTypes "github.com/aws/aws-sdk-go-v2/service/sts/types"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"maps"
 	"os"
+	"slices"
+	"strings"
 	"time"
 )
 
 var (
-	_ provider.Provider = &awsmtProvider{}
+	_ provider.Provider                  = &awsmtProvider{}
+	_ provider.ProviderWithListResources = &awsmtProvider{}
+	_ provider.ProviderWithFunctions     = &awsmtProvider{}
 )
 
 func New() provider.Provider {
 	return &awsmtProvider{}
 }
 
-type awsmtProvider struct{}
+type awsmtProvider struct {
+	// client replaces the MediaTailor client created in Configure when set. It is used to run the acceptance tests
+	// against the in-memory fake.
+	client mediaTailorClient
+}
 
 type awsmtProviderModel struct {
-	Profile          types.String `tfsdk:"profile"`
-	Region           types.String `tfsdk:"region"`
-	MaxRetryAttempts types.Int64  `tfsdk:"max_retry_attempts"`
+	Profile              types.String    `tfsdk:"profile"`
+	Region               types.String    `tfsdk:"region"`
+	MaxRetryAttempts     types.Int64     `tfsdk:"max_retry_attempts"`
+	Endpoints            *endpointsModel `tfsdk:"endpoints"`
+	UseFipsEndpoint      types.Bool      `tfsdk:"use_fips_endpoint"`
+	UseDualstackEndpoint types.Bool      `tfsdk:"use_dualstack_endpoint"`
+
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+	ReadCache             types.Bool    `tfsdk:"read_cache"`
+
+	AssumeRole                *assumeRoleModel                `tfsdk:"assume_role"`
+	AssumeRoleWithWebIdentity *assumeRoleWithWebIdentityModel `tfsdk:"assume_role_with_web_identity"`
+
+	DefaultTags *defaultTagsModel `tfsdk:"default_tags"`
+	IgnoreTags  *ignoreTagsModel  `tfsdk:"ignore_tags"`
+}
+
+type defaultTagsModel struct {
+	Tags map[string]string `tfsdk:"tags"`
+}
+
+type ignoreTagsModel struct {
+	Keys        []string `tfsdk:"keys"`
+	KeyPrefixes []string `tfsdk:"key_prefixes"`
+}
+
+type endpointsModel struct {
+	MediaTailor types.String `tfsdk:"mediatailor"`
+	Sts         types.String `tfsdk:"sts"`
+}
+
+type assumeRoleModel struct {
+	RoleArn     types.String `tfsdk:"role_arn"`
+	SessionName types.String `tfsdk:"session_name"`
+	ExternalId  types.String `tfsdk:"external_id"`
+	Duration    types.String `tfsdk:"duration"`
+	Policy      types.String `tfsdk:"policy"`
+	Tags        types.Map    `tfsdk:"tags"`
+}
+
+type assumeRoleWithWebIdentityModel struct {
+	RoleArn              types.String `tfsdk:"role_arn"`
+	WebIdentityTokenFile types.String `tfsdk:"web_identity_token_file"`
+}
+
+// clientSettings holds the resolved provider configuration used to create the AWS clients.
+type clientSettings struct {
+	region               string
+	profile              string
+	maxAttempts          int
+	mediaTailorEndpoint  string
+	stsEndpoint          string
+	useFipsEndpoint      bool
+	useDualstackEndpoint bool
+
+	// requestsPerSecond and maxConcurrentRequests limit the requests of the MediaTailor client, 0 disabling the limit
+	requestsPerSecond     float64
+	maxConcurrentRequests int
+
+	assumeRole                *assumeRoleSettings
+	assumeRoleWithWebIdentity *assumeRoleWithWebIdentitySettings
+}
+
+type assumeRoleSettings struct {
+	roleArn     string
+	sessionName string
+	externalId  string
+	duration    time.Duration
+	policy      string
+	tags        map[string]string
+}
+
+type assumeRoleWithWebIdentitySettings struct {
+	roleArn              string
+	webIdentityTokenFile string
 }
 
 func (p *awsmtProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -51,6 +145,124 @@ func (p *awsmtProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 				Optional:    true,
 				Description: "The maximum number of times the provider will retry a failed aws operation. Defaults to 10",
 			},
+			"use_fips_endpoint": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Use the FIPS endpoints of the AWS services. Can also be set with the 'AWS_USE_FIPS_ENDPOINT' environment variable. Defaults to false.",
+			},
+			"use_dualstack_endpoint": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Use the dual-stack (IPv4 and IPv6) endpoints of the AWS services. Can also be set with the 'AWS_USE_DUALSTACK_ENDPOINT' environment variable. Defaults to false.",
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Optional:    true,
+				Description: "The maximum number of MediaTailor requests per second of each family of operations: reads, writes and tagging. Not limited by default.",
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0.1),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum number of MediaTailor requests in flight, shared by every resource and data source. Not limited by default.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"read_cache": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Read the vod and live sources of a source location with a single List operation, and serve the following reads of the operation from memory. Defaults to false.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"endpoints": schema.SingleNestedBlock{
+				Description: "Custom endpoints of the AWS services, for example VPC endpoints or local stand-ins of the AWS API.",
+				Attributes: map[string]schema.Attribute{
+					"mediatailor": schema.StringAttribute{
+						Optional:    true,
+						Description: "The endpoint of the MediaTailor API. Can also be set with the 'AWS_ENDPOINT_URL_MEDIATAILOR' environment variable.",
+					},
+					"sts": schema.StringAttribute{
+						Optional:    true,
+						Description: "The endpoint of the STS API, used when credentials are obtained by assuming a role. Can also be set with the 'AWS_ENDPOINT_URL_STS' environment variable.",
+					},
+				},
+			},
+			"assume_role": schema.SingleNestedBlock{
+				Description: "The role to assume with the credentials found by the provider. The temporary credentials of the role are used for every MediaTailor operation.",
+				Validators: []validator.Object{
+					objectvalidator.AlsoRequires(path.MatchRelative().AtName("role_arn")),
+				},
+				Attributes: map[string]schema.Attribute{
+					"role_arn": schema.StringAttribute{
+						Optional:    true,
+						Description: "The ARN of the role to assume. Required when the block is set.",
+					},
+					"session_name": schema.StringAttribute{
+						Optional:    true,
+						Description: "The name of the session. Defaults to a name generated by the AWS SDK.",
+					},
+					"external_id": schema.StringAttribute{
+						Optional:    true,
+						Description: "The external ID expected by the trust policy of the role.",
+					},
+					"duration": schema.StringAttribute{
+						Optional:    true,
+						Description: "The duration of the session, for example '1h' or '30m'. Defaults to 15 minutes.",
+					},
+					"policy": schema.StringAttribute{
+						Optional:    true,
+						Description: "An IAM policy in JSON format further restricting the permissions of the session.",
+					},
+					"tags": schema.MapAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+						Description: "The session tags.",
+					},
+				},
+			},
+			"default_tags": schema.SingleNestedBlock{
+				Description: "Tags applied to every resource of the provider. The tags configured in a resource take precedence over the default tags with the same key.",
+				Attributes: map[string]schema.Attribute{
+					"tags": schema.MapAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+						Description: "The default tags.",
+					},
+				},
+			},
+			"ignore_tags": schema.SingleNestedBlock{
+				Description: "Tags managed outside Terraform. The ignored tags are left out of the state of every resource and data source, and are never removed from the MediaTailor objects.",
+				Attributes: map[string]schema.Attribute{
+					"keys": schema.SetAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+						Description: "The keys of the ignored tags.",
+					},
+					"key_prefixes": schema.SetAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+						Description: "The key prefixes of the ignored tags.",
+					},
+				},
+			},
+			"assume_role_with_web_identity": schema.SingleNestedBlock{
+				Description: "The role to assume with a web identity token, for example the OIDC token of a CI pipeline. If 'assume_role' is also set, its role is assumed with the credentials of this one.",
+				Validators: []validator.Object{
+					objectvalidator.AlsoRequires(
+						path.MatchRelative().AtName("role_arn"),
+						path.MatchRelative().AtName("web_identity_token_file"),
+					),
+				},
+				Attributes: map[string]schema.Attribute{
+					"role_arn": schema.StringAttribute{
+						Optional:    true,
+						Description: "The ARN of the role to assume. Required when the block is set.",
+					},
+					"web_identity_token_file": schema.StringAttribute{
+						Optional:    true,
+						Description: "The path of the file containing the web identity token. Required when the block is set.",
+					},
+				},
+			},
 		},
 	}
 }
@@ -65,6 +277,30 @@ func (p *awsmtProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		return
 	}
 
+	var tags tagsConfig
+	if providerConfig.DefaultTags != nil {
+		tags.defaultTags = providerConfig.DefaultTags.Tags
+	}
+	if providerConfig.IgnoreTags != nil {
+		tags.ignoreKeys = providerConfig.IgnoreTags.Keys
+		tags.ignoreKeyPrefixes = providerConfig.IgnoreTags.KeyPrefixes
+	}
+
+	if p.client != nil {
+		region := providerConfig.Region.ValueString()
+		if region == "" {
+			region = "eu-central-1"
+		}
+		data := &providerData{client: p.client, region: region, tags: tags}
+		if providerConfig.ReadCache.ValueBool() {
+			data.enableReadCache()
+		}
+		resp.DataSourceData = data
+		resp.ResourceData = data
+		resp.ListResourceData = data
+		return
+	}
+
 	var region = "eu-central-1"
 	var profile = ""
 	var maxAttempts = 10
@@ -86,41 +322,205 @@ func (p *awsmtProvider) Configure(ctx context.Context, req provider.ConfigureReq
 	if !providerConfig.MaxRetryAttempts.IsUnknown() || !providerConfig.MaxRetryAttempts.IsNull() {
 		maxAttempts = int(providerConfig.MaxRetryAttempts.ValueInt64())
 	}
+
+	settings := clientSettings{
+		region:               region,
+		profile:              profile,
+		maxAttempts:          maxAttempts,
+		useFipsEndpoint:      boolWithEnvFallback(providerConfig.UseFipsEndpoint, "AWS_USE_FIPS_ENDPOINT"),
+		useDualstackEndpoint: boolWithEnvFallback(providerConfig.UseDualstackEndpoint, "AWS_USE_DUALSTACK_ENDPOINT"),
+
+		requestsPerSecond:     providerConfig.RequestsPerSecond.ValueFloat64(),
+		maxConcurrentRequests: int(providerConfig.MaxConcurrentRequests.ValueInt64()),
+	}
+	var endpoints endpointsModel
+	if providerConfig.Endpoints != nil {
+		endpoints = *providerConfig.Endpoints
+	}
+	settings.mediaTailorEndpoint = stringWithEnvFallback(endpoints.MediaTailor, "AWS_ENDPOINT_URL_MEDIATAILOR")
+	settings.stsEndpoint = stringWithEnvFallback(endpoints.Sts, "AWS_ENDPOINT_URL_STS")
+
+	if providerConfig.AssumeRole != nil {
+		settings.assumeRole, err = providerConfig.AssumeRole.settings(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid assume_role configuration", err.Error())
+			return
+		}
+	}
+	if providerConfig.AssumeRoleWithWebIdentity != nil {
+		settings.assumeRoleWithWebIdentity, err = providerConfig.AssumeRoleWithWebIdentity.settings()
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid assume_role_with_web_identity configuration", err.Error())
+			return
+		}
+	}
+
 	tflog.Debug(ctx, "Creating AWS client session")
-	cfg, err = p.getClientConfig(ctx, region, profile, maxAttempts)
+	cfg, err = p.getClientConfig(ctx, settings)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to Initialize Provider in Region", "unable to initialize provider in the specified region: "+err.Error())
 		return
 	}
 
-	c := mediatailor.NewFromConfig(cfg)
+	data := &providerData{client: newMediaTailorClient(cfg, settings), region: cfg.Region, tags: tags}
+	if providerConfig.ReadCache.ValueBool() {
+		data.enableReadCache()
+	}
 
-	resp.DataSourceData = c
-	resp.ResourceData = c
+	resp.DataSourceData = data
+	resp.ResourceData = data
+	resp.ListResourceData = data
 
 	tflog.Info(ctx, "AWS MediaTailor client configured", map[string]any{"success": true})
 }
 
-func (p *awsmtProvider) getClientConfig(ctx context.Context, region, profile string, maxAttempts int) (aws.Config, error) {
+func (p *awsmtProvider) getClientConfig(ctx context.Context, settings clientSettings) (aws.Config, error) {
 	backoff := customBackoff{
 		minDelay: 500 * time.Millisecond,
 	}
 	retryer := retry.NewStandard(func(o *retry.StandardOptions) {
 		o.Backoff = backoff
-		o.MaxAttempts = maxAttempts
+		o.MaxAttempts = settings.maxAttempts
 		o.MaxBackoff = 10 * time.Second
 	})
 
 	var optFns []func(*config.LoadOptions) error
-	optFns = append(optFns, config.WithRegion(region))
+	optFns = append(optFns, config.WithRegion(settings.region))
 	optFns = append(optFns, config.WithRetryer(func() aws.Retryer {
 		return retryer
 	}))
-	if profile != "" {
-		optFns = append(optFns, config.WithSharedConfigProfile(profile))
+	if settings.profile != "" {
+		optFns = append(optFns, config.WithSharedConfigProfile(settings.profile))
+	}
+	if settings.useFipsEndpoint {
+		optFns = append(optFns, config.WithUseFIPSEndpoint(aws.FIPSEndpointStateEnabled))
+	}
+	if settings.useDualstackEndpoint {
+		optFns = append(optFns, config.WithUseDualStackEndpoint(aws.DualStackEndpointStateEnabled))
+	}
+	if settings.stsEndpoint != "" {
+		// @ADR
+		// Context: The STS clients used by the credential providers are created while the configuration is loaded, so
+		// they cannot be given a base endpoint through the client options like the MediaTailor client.
+		// Decision: We replace the STS client of the assume role and web identity credential providers with a copy
+		// that uses the custom endpoint.
+		// Consequences: Credential providers that do not expose their STS client keep using the default endpoint.
+		optFns = append(optFns, config.WithAssumeRoleCredentialOptions(func(o *stscreds.AssumeRoleOptions) {
+			if client, ok := o.Client.(*sts.Client); ok {
+				o.Client = withStsEndpoint(client, settings.stsEndpoint)
+			}
+		}))
+		optFns = append(optFns, config.WithWebIdentityRoleCredentialOptions(func(o *stscreds.WebIdentityRoleOptions) {
+			if client, ok := o.Client.(*sts.Client); ok {
+				o.Client = withStsEndpoint(client, settings.stsEndpoint)
+			}
+		}))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, optFns...)
+	if err != nil {
+		return cfg, err
+	}
+
+	// the web identity role is assumed first, so that the role of assume_role can be assumed with its credentials
+	if s := settings.assumeRoleWithWebIdentity; s != nil {
+		cfg.Credentials = aws.NewCredentialsCache(stscreds.NewWebIdentityRoleProvider(newStsClient(cfg, settings), s.roleArn, stscreds.IdentityTokenFile(s.webIdentityTokenFile)))
+	}
+	if s := settings.assumeRole; s != nil {
+		cfg.Credentials = aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(newStsClient(cfg, settings), s.roleArn, func(o *stscreds.AssumeRoleOptions) {
+			o.RoleSessionName = s.sessionName
+			o.Duration = s.duration
+			if s.externalId != "" {
+				o.ExternalID = aws.String(s.externalId)
+			}
+			if s.policy != "" {
+				o.Policy = aws.String(s.policy)
+			}
+			for _, k := range slices.Sorted(maps.Keys(s.tags)) {
+				o.Tags = append(o.Tags, stsTypes.Tag{Key: aws.String(k), Value: aws.String(s.tags[k])})
+			}
+		}))
+	}
+	return cfg, nil
+}
+
+func newStsClient(cfg aws.Config, settings clientSettings) *sts.Client {
+	return sts.NewFromConfig(cfg, func(o *sts.Options) {
+		if settings.stsEndpoint != "" {
+			o.BaseEndpoint = aws.String(settings.stsEndpoint)
+		}
+	})
+}
+
+func (m *assumeRoleModel) settings(ctx context.Context) (*assumeRoleSettings, error) {
+	if m.RoleArn.ValueString() == "" {
+		return nil, errors.New("role_arn is required")
+	}
+	settings := &assumeRoleSettings{
+		roleArn:     m.RoleArn.ValueString(),
+		sessionName: m.SessionName.ValueString(),
+		externalId:  m.ExternalId.ValueString(),
+		policy:      m.Policy.ValueString(),
+	}
+	if d := m.Duration.ValueString(); d != "" {
+		duration, err := time.ParseDuration(d)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration %q: %w", d, err)
+		}
+		settings.duration = duration
+	}
+	if !m.Tags.IsNull() && !m.Tags.IsUnknown() {
+		if diags := m.Tags.ElementsAs(ctx, &settings.tags, false); diags.HasError() {
+			return nil, errors.New("invalid tags")
+		}
 	}
+	return settings, nil
+}
 
-	return config.LoadDefaultConfig(ctx, optFns...)
+func (m *assumeRoleWithWebIdentityModel) settings() (*assumeRoleWithWebIdentitySettings, error) {
+	if m.RoleArn.ValueString() == "" {
+		return nil, errors.New("role_arn is required")
+	}
+	if m.WebIdentityTokenFile.ValueString() == "" {
+		return nil, errors.New("web_identity_token_file is required")
+	}
+	return &assumeRoleWithWebIdentitySettings{
+		roleArn:              m.RoleArn.ValueString(),
+		webIdentityTokenFile: m.WebIdentityTokenFile.ValueString(),
+	}, nil
+}
+
+func withStsEndpoint(client *sts.Client, endpoint string) *sts.Client {
+	return sts.New(client.Options(), func(o *sts.Options) {
+		o.BaseEndpoint = aws.String(endpoint)
+	})
+}
+
+func newMediaTailorClient(cfg aws.Config, settings clientSettings) *mediatailor.Client {
+	limiter := newApiLimiter(settings.requestsPerSecond, settings.maxConcurrentRequests)
+	return mediatailor.NewFromConfig(cfg, func(o *mediatailor.Options) {
+		if settings.mediaTailorEndpoint != "" {
+			o.BaseEndpoint = aws.String(settings.mediaTailorEndpoint)
+		}
+		o.APIOptions = append(o.APIOptions, limiter.addMiddleware)
+	})
+}
+
+// stringWithEnvFallback returns the configured value, or the value of the environment variable if it is not set.
+func stringWithEnvFallback(value types.String, env string) string {
+	if !value.IsNull() && !value.IsUnknown() && value.ValueString() != "" {
+		return value.ValueString()
+	}
+	return os.Getenv(env)
+}
+
+// boolWithEnvFallback returns the configured value, or whether the environment variable is set to "true" if it is not
+// set.
+func boolWithEnvFallback(value types.Bool, env string) bool {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueBool()
+	}
+	return strings.EqualFold(os.Getenv(env), "true")
 }
 
 func (p *awsmtProvider) DataSources(_ context.Context) []func() datasource.DataSource {
@@ -141,6 +541,33 @@ func (p *awsmtProvider) Resources(_ context.Context) []func() resource.Resource
 		ResourcePlaybackConfiguration,
 		ResourceLiveSource,
 		ResourceVodSource,
+		ResourceVodSourceSet,
+	}
+}
+
+func (p *awsmtProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		ChannelArnFunction,
+		SourceLocationArnFunction,
+		VodSourceArnFunction,
+		LiveSourceArnFunction,
+		PlaybackConfigurationArnFunction,
+		ParseArnFunction,
+		HlsManifestUrlFunction,
+		SessionInitializationUrlFunction,
+		AnalyzeManifestFunction,
+		Scte35DecodeFunction,
+		Scte35EncodeFunction,
+	}
+}
+
+func (p *awsmtProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		ListResourceChannel,
+		ListResourceSourceLocation,
+		ListResourcePlaybackConfiguration,
+		ListResourceLiveSource,
+		ListResourceVodSource,
 	}
 }
 
@@ -1,16 +1,390 @@
 package awsmt
 
 import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"os"
+	"strings"
+	"terraform-provider-mediatailor/awsmt/fake"
+	"testing"
+	"time"
 )
 
+var _ mediaTailorClient = &fake.Client{}
+
+// fakeClient is shared by every test of the package when TF_AWSMT_FAKE=1, so that a data source can read the objects
+// created by the resources of the same configuration.
+var fakeClient = fake.NewClient()
+
 var (
 	testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-		"awsmt": providerserver.NewProtocol6WithError(New()),
+		"awsmt": providerserver.NewProtocol6WithError(testProvider()),
 	}
 )
 
-/* func TestMain(m *testing.M) {
+// isFakeMode reports whether the tests run against the in-memory fake instead of AWS.
+func isFakeMode() bool {
+	return os.Getenv("TF_AWSMT_FAKE") == "1"
+}
+
+func testProvider() provider.Provider {
+	if isFakeMode() {
+		return &awsmtProvider{client: fakeClient}
+	}
+	return New()
+}
+
+func TestMain(m *testing.M) {
+	if isFakeMode() {
+		// the fake does not need credentials, so the acceptance tests can always run
+		_ = os.Setenv("TF_ACC", "1")
+	}
 	resource.TestMain(m)
-} */
+}
+
+// callFunction calls a provider function through the protocol server, so that the parameter validators run as they do
+// in Terraform.
+func callFunction(t *testing.T, name string, returnType tftypes.Type, arguments ...tftypes.Value) (tftypes.Value, *tfprotov6.FunctionError) {
+	t.Helper()
+	server := providerserver.NewProtocol6(New())()
+
+	var dynamicArguments []*tfprotov6.DynamicValue
+	for _, argument := range arguments {
+		value, err := tfprotov6.NewDynamicValue(argument.Type(), argument)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dynamicArguments = append(dynamicArguments, &value)
+	}
+
+	resp, err := server.CallFunction(context.Background(), &tfprotov6.CallFunctionRequest{Name: name, Arguments: dynamicArguments})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Error != nil {
+		return tftypes.Value{}, resp.Error
+	}
+	result, err := resp.Result.Unmarshal(returnType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result, nil
+}
+
+// objectValue returns an object of the given type with the given attributes, the other attributes being null.
+func objectValue(objectType tftypes.Object, attributes map[string]tftypes.Value) tftypes.Value {
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+		if value, ok := attributes[name]; ok {
+			values[name] = value
+		}
+	}
+	return tftypes.NewValue(objectType, values)
+}
+
+// validateResourceConfig validates a resource configuration through the protocol server, so that the schema validators
+// and the ValidateConfig method of the resource run as they do in Terraform. The attributes missing from the
+// configuration are null.
+func validateResourceConfig(t *testing.T, r fwresource.Resource, config map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	t.Helper()
+	ctx := context.Background()
+	server := providerserver.NewProtocol6(New())()
+
+	var metadata fwresource.MetadataResponse
+	r.Metadata(ctx, fwresource.MetadataRequest{ProviderTypeName: "awsmt"}, &metadata)
+	var schema fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schema)
+
+	configType := schema.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	dynamicConfig, err := tfprotov6.NewDynamicValue(configType, objectValue(configType, config))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := server.ValidateResourceConfig(ctx, &tfprotov6.ValidateResourceConfigRequest{TypeName: metadata.TypeName, Config: &dynamicConfig})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.Diagnostics
+}
+
+// providerConfigType returns the type of the configuration of the provider.
+func providerConfigType() tftypes.Object {
+	ctx := context.Background()
+	var schema provider.SchemaResponse
+	New().Schema(ctx, provider.SchemaRequest{}, &schema)
+	return schema.Schema.Type().TerraformType(ctx).(tftypes.Object)
+}
+
+// resourceConfigType returns the type of the configuration of a resource.
+func resourceConfigType(r fwresource.Resource) tftypes.Object {
+	ctx := context.Background()
+	var schema fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schema)
+	return schema.Schema.Type().TerraformType(ctx).(tftypes.Object)
+}
+
+// configureTestServer returns a protocol server of the provider using the given client, configured with the given
+// provider configuration.
+func configureTestServer(t *testing.T, client mediaTailorClient, config tftypes.Value) tfprotov6.ProviderServer {
+	t.Helper()
+	server := providerserver.NewProtocol6(&awsmtProvider{client: client})()
+	dynamicConfig, err := tfprotov6.NewDynamicValue(providerConfigType(), config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.ConfigureProvider(context.Background(), &tfprotov6.ConfigureProviderRequest{Config: &dynamicConfig})
+	if err != nil || len(resp.Diagnostics) > 0 {
+		t.Fatalf("could not configure the provider: %v %v", err, resp.Diagnostics)
+	}
+	return server
+}
+
+// createResource plans and applies the creation of a resource through the protocol server, like Terraform does, and
+// returns the attributes of the planned and of the applied states.
+func createResource(t *testing.T, server tfprotov6.ProviderServer, r fwresource.Resource, config tftypes.Value) (map[string]tftypes.Value, map[string]tftypes.Value) {
+	t.Helper()
+	ctx := context.Background()
+	var metadata fwresource.MetadataResponse
+	r.Metadata(ctx, fwresource.MetadataRequest{ProviderTypeName: "awsmt"}, &metadata)
+	configType := resourceConfigType(r)
+
+	dynamicConfig, err := tfprotov6.NewDynamicValue(configType, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	priorState, err := tfprotov6.NewDynamicValue(configType, tftypes.NewValue(configType, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         metadata.TypeName,
+		PriorState:       &priorState,
+		ProposedNewState: &dynamicConfig,
+		Config:           &dynamicConfig,
+	})
+	if err != nil || len(plan.Diagnostics) > 0 {
+		t.Fatalf("could not plan: %v %v", err, plan.Diagnostics)
+	}
+	apply, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:     metadata.TypeName,
+		PriorState:   &priorState,
+		PlannedState: plan.PlannedState,
+		Config:       &dynamicConfig,
+	})
+	if err != nil || len(apply.Diagnostics) > 0 {
+		t.Fatalf("could not apply: %v %v", err, apply.Diagnostics)
+	}
+	return stateAttributes(t, plan.PlannedState, configType), stateAttributes(t, apply.NewState, configType)
+}
+
+// readDataSource reads a data source through the protocol server, like Terraform does, and returns the attributes of
+// its state.
+func readDataSource(t *testing.T, server tfprotov6.ProviderServer, d datasource.DataSource, config map[string]tftypes.Value) map[string]tftypes.Value {
+	t.Helper()
+	ctx := context.Background()
+	var metadata datasource.MetadataResponse
+	d.Metadata(ctx, datasource.MetadataRequest{ProviderTypeName: "awsmt"}, &metadata)
+	var schema datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schema)
+
+	configType := schema.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	dynamicConfig, err := tfprotov6.NewDynamicValue(configType, objectValue(configType, config))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.ReadDataSource(ctx, &tfprotov6.ReadDataSourceRequest{TypeName: metadata.TypeName, Config: &dynamicConfig})
+	if err != nil || len(resp.Diagnostics) > 0 {
+		t.Fatalf("could not read: %v %v", err, resp.Diagnostics)
+	}
+	return stateAttributes(t, resp.State, configType)
+}
+
+// stateAttributes returns the attributes of a state returned by the protocol server.
+func stateAttributes(t *testing.T, state *tfprotov6.DynamicValue, stateType tftypes.Object) map[string]tftypes.Value {
+	t.Helper()
+	value, err := state.Unmarshal(stateType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var attributes map[string]tftypes.Value
+	if err := value.As(&attributes); err != nil {
+		t.Fatal(err)
+	}
+	return attributes
+}
+
+// validateDataSourceConfig validates a data source configuration through the protocol server, like
+// validateResourceConfig.
+func validateDataSourceConfig(t *testing.T, d datasource.DataSource, config map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	t.Helper()
+	ctx := context.Background()
+	server := providerserver.NewProtocol6(New())()
+
+	var metadata datasource.MetadataResponse
+	d.Metadata(ctx, datasource.MetadataRequest{ProviderTypeName: "awsmt"}, &metadata)
+	var schema datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schema)
+
+	configType := schema.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	dynamicConfig, err := tfprotov6.NewDynamicValue(configType, objectValue(configType, config))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := server.ValidateDataResourceConfig(ctx, &tfprotov6.ValidateDataResourceConfigRequest{TypeName: metadata.TypeName, Config: &dynamicConfig})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.Diagnostics
+}
+
+// upgradeResourceState upgrades the JSON state of a resource from the given schema version through the protocol server,
+// as Terraform does when it reads a state written by an older version of the provider.
+func upgradeResourceState(t *testing.T, r fwresource.Resource, version int64, state string) tftypes.Value {
+	t.Helper()
+	ctx := context.Background()
+	server := providerserver.NewProtocol6(New())()
+
+	var metadata fwresource.MetadataResponse
+	r.Metadata(ctx, fwresource.MetadataRequest{ProviderTypeName: "awsmt"}, &metadata)
+	var schema fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schema)
+
+	resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: metadata.TypeName,
+		Version:  version,
+		RawState: &tfprotov6.RawState{JSON: []byte(state)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, diag := range resp.Diagnostics {
+		if diag.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("%s: %s", diag.Summary, diag.Detail)
+		}
+	}
+
+	upgraded, err := resp.UpgradedState.Unmarshal(schema.Schema.Type().TerraformType(ctx))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return upgraded
+}
+
+func TestEnvFallback(t *testing.T) {
+	t.Setenv("AWS_ENDPOINT_URL_MEDIATAILOR", "http://localhost:4566")
+	t.Setenv("AWS_USE_FIPS_ENDPOINT", "true")
+
+	if v := stringWithEnvFallback(types.StringNull(), "AWS_ENDPOINT_URL_MEDIATAILOR"); v != "http://localhost:4566" {
+		t.Errorf("expected the environment variable to be used, got %q", v)
+	}
+	if v := stringWithEnvFallback(types.StringValue("https://mediatailor.example.com"), "AWS_ENDPOINT_URL_MEDIATAILOR"); v != "https://mediatailor.example.com" {
+		t.Errorf("expected the configured value to take precedence, got %q", v)
+	}
+	if !boolWithEnvFallback(types.BoolNull(), "AWS_USE_FIPS_ENDPOINT") {
+		t.Error("expected the environment variable to be used")
+	}
+	if boolWithEnvFallback(types.BoolValue(false), "AWS_USE_FIPS_ENDPOINT") {
+		t.Error("expected the configured value to take precedence")
+	}
+}
+
+func TestAssumeRoleSettings(t *testing.T) {
+	m := &assumeRoleModel{
+		RoleArn:  types.StringValue("arn:aws:iam::123456789012:role/deploy"),
+		Duration: types.StringValue("30m"),
+		Tags:     types.MapValueMust(types.StringType, map[string]attr.Value{"pipeline": types.StringValue("prod")}),
+	}
+	settings, err := m.settings(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings.duration != 30*time.Minute {
+		t.Errorf("expected a duration of 30 minutes, got %s", settings.duration)
+	}
+	if settings.tags["pipeline"] != "prod" {
+		t.Errorf("unexpected tags %v", settings.tags)
+	}
+
+	m.Duration = types.StringValue("one hour")
+	if _, err := m.settings(context.Background()); err == nil {
+		t.Error("expected an error for an invalid duration")
+	}
+
+	if _, err := (&assumeRoleModel{}).settings(context.Background()); err == nil {
+		t.Error("expected an error when role_arn is missing")
+	}
+	if _, err := (&assumeRoleWithWebIdentityModel{RoleArn: types.StringValue("arn:aws:iam::123456789012:role/ci")}).settings(); err == nil {
+		t.Error("expected an error when web_identity_token_file is missing")
+	}
+}
+
+func TestProviderConfigRequiresRoleArn(t *testing.T) {
+	ctx := context.Background()
+	server := providerserver.NewProtocol6(New())()
+	configType := providerConfigType()
+
+	for name, tc := range map[string]struct {
+		config map[string]tftypes.Value
+		err    string
+	}{
+		"assume role": {
+			config: map[string]tftypes.Value{
+				"assume_role": objectValue(configType.AttributeTypes["assume_role"].(tftypes.Object), map[string]tftypes.Value{
+					"role_arn": tftypes.NewValue(tftypes.String, "arn:aws:iam::123456789012:role/deploy"),
+				}),
+			},
+		},
+		"assume role without role_arn": {
+			config: map[string]tftypes.Value{
+				"assume_role": objectValue(configType.AttributeTypes["assume_role"].(tftypes.Object), map[string]tftypes.Value{
+					"session_name": tftypes.NewValue(tftypes.String, "terraform"),
+				}),
+			},
+			err: "assume_role.role_arn",
+		},
+		"web identity without web_identity_token_file": {
+			config: map[string]tftypes.Value{
+				"assume_role_with_web_identity": objectValue(configType.AttributeTypes["assume_role_with_web_identity"].(tftypes.Object), map[string]tftypes.Value{
+					"role_arn": tftypes.NewValue(tftypes.String, "arn:aws:iam::123456789012:role/ci"),
+				}),
+			},
+			err: "assume_role_with_web_identity.web_identity_token_file",
+		},
+		"no block": {},
+	} {
+		t.Run(name, func(t *testing.T) {
+			dynamicConfig, err := tfprotov6.NewDynamicValue(configType, objectValue(configType, tc.config))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := server.ValidateProviderConfig(ctx, &tfprotov6.ValidateProviderConfigRequest{Config: &dynamicConfig})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var errs []string
+			for _, diag := range resp.Diagnostics {
+				if diag.Severity == tfprotov6.DiagnosticSeverityError {
+					errs = append(errs, diag.Summary+": "+diag.Detail)
+				}
+			}
+			if tc.err == "" && len(errs) > 0 {
+				t.Errorf("expected no error, got %v", errs)
+			}
+			if tc.err != "" && (len(errs) != 1 || !strings.Contains(errs[0], tc.err)) {
+				t.Errorf("expected one error containing %q, got %v", tc.err, errs)
+			}
+		})
+	}
+}
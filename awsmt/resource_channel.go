@@ -2,25 +2,37 @@ package awsmt
 
 import (
 	"context"
+	"fmt"
 	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"maps"
+	"slices"
 	"strings"
 	"terraform-provider-mediatailor/awsmt/models"
 )
 
 var (
-	_ resource.Resource                = &resourceChannel{}
-	_ resource.ResourceWithConfigure   = &resourceChannel{}
-	_ resource.ResourceWithImportState = &resourceChannel{}
+	_ resource.Resource                   = &resourceChannel{}
+	_ resource.ResourceWithConfigure      = &resourceChannel{}
+	_ resource.ResourceWithImportState    = &resourceChannel{}
+	_ resource.ResourceWithModifyPlan     = &resourceChannel{}
+	_ resource.ResourceWithIdentity       = &resourceChannel{}
+	_ resource.ResourceWithValidateConfig = &resourceChannel{}
+	_ resource.ResourceWithUpgradeState   = &resourceChannel{}
 )
 
 func ResourceChannel() resource.Resource {
@@ -28,7 +40,9 @@ func ResourceChannel() resource.Resource {
 }
 
 type resourceChannel struct {
-	client *mediatailor.Client
+	client mediaTailorClient
+	region string
+	tags   tagsConfig
 }
 
 func (r *resourceChannel) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -37,11 +51,57 @@ func (r *resourceChannel) Metadata(_ context.Context, req resource.MetadataReque
 
 func (r *resourceChannel) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 2,
 		Attributes: map[string]schema.Attribute{
 			"id":   computedString,
 			"arn":  computedString,
 			"name": requiredStringWithRequiresReplace,
 			// @ADR
+			// Context: Writing the channel policy requires the ARN of the channel, which is not known before the channel is
+			// created, and MediaTailor returns the policy in a form that can differ from the configured JSON.
+			// Decision: We decided to add an access_policy attribute describing who can get the manifests of the channel,
+			// which the provider renders to a policy using the ARN returned by CreateChannel.
+			// Consequences: The rendered policy is not stored in the policy attribute, and drift is detected by comparing
+			// the remote policy to the rendered one semantically.
+			"access_policy": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"allow_anonymous_get_manifest": schema.BoolAttribute{
+						Optional: true,
+					},
+					"allowed_principals": schema.SetAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+						Validators: []validator.Set{
+							setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+						},
+					},
+					"source_ip_cidrs": schema.SetAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"conditions": schema.ListNestedAttribute{
+						Optional: true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"test":     requiredString,
+								"variable": requiredString,
+								"values": schema.SetAttribute{
+									Required:    true,
+									ElementType: types.StringType,
+									Validators: []validator.Set{
+										setvalidator.SizeAtLeast(1),
+									},
+								},
+							},
+						},
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.ConflictsWith(path.MatchRoot("policy")),
+				},
+			},
+			// @ADR
 			// Context: We cannot test the deletion of a running channel if we cannot set the channel_state property
 			// through the provider
 			// Decision: We decided to turn the channel_state property into an optional string and call the SDK to
@@ -54,7 +114,7 @@ func (r *resourceChannel) Schema(_ context.Context, _ resource.SchemaRequest, re
 					stringvalidator.OneOf("RUNNING", "STOPPED"),
 				},
 			},
-			"creation_time":      computedString,
+			"creation_time":      computedRFC3339,
 			"enable_as_run_logs": optionalComputedBool,
 			"filler_slate": schema.SingleNestedAttribute{
 				Optional: true,
@@ -63,31 +123,11 @@ func (r *resourceChannel) Schema(_ context.Context, _ resource.SchemaRequest, re
 					"vod_source_name":      optionalString,
 				},
 			},
-			"last_modified_time": computedString,
-			"outputs": schema.ListNestedAttribute{
+			"last_modified_time": computedRFC3339,
+			"outputs": schema.MapNestedAttribute{
 				Required: true,
 				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"dash_playlist_settings": schema.SingleNestedAttribute{
-							Optional: true,
-							Attributes: map[string]schema.Attribute{
-								"manifest_window_seconds":              optionalInt64,
-								"min_buffer_time_seconds":              optionalInt64,
-								"min_update_period_seconds":            optionalInt64,
-								"suggested_presentation_delay_seconds": optionalInt64,
-							},
-						},
-						"hls_playlist_settings": schema.SingleNestedAttribute{
-							Optional: true,
-							Attributes: map[string]schema.Attribute{
-								"ad_markup_type":          optionalUnknownList,
-								"manifest_window_seconds": optionalUnknownInt64,
-							},
-						},
-						"manifest_name": requiredString,
-						"playback_url":  computedString,
-						"source_group":  requiredString,
-					},
+					Attributes: channelOutputAttributes(),
 				},
 			},
 			"playback_mode": schema.StringAttribute{
@@ -108,7 +148,8 @@ func (r *resourceChannel) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Optional:   true,
 				CustomType: jsontypes.NormalizedType{},
 			},
-			"tags": optionalMap,
+			"tags":     optionalMap,
+			"tags_all": computedMap,
 			"tier": schema.StringAttribute{
 				Optional: true,
 				Computed: true,
@@ -124,16 +165,177 @@ func (r *resourceChannel) Schema(_ context.Context, _ resource.SchemaRequest, re
 	}
 }
 
+// channelOutputAttributes returns the attributes of an output of the channel resource, whose manifest name is the key
+// of the output.
+func channelOutputAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"dash_playlist_settings": schema.SingleNestedAttribute{
+			Optional: true,
+			Attributes: map[string]schema.Attribute{
+				"manifest_window_seconds":              optionalInt64,
+				"min_buffer_time_seconds":              optionalInt64,
+				"min_update_period_seconds":            optionalInt64,
+				"suggested_presentation_delay_seconds": optionalInt64,
+			},
+		},
+		"hls_playlist_settings": schema.SingleNestedAttribute{
+			Optional: true,
+			Attributes: map[string]schema.Attribute{
+				"ad_markup_type": schema.ListAttribute{
+					Optional:    true,
+					ElementType: types.StringType,
+					PlanModifiers: []planmodifier.List{
+						listplanmodifier.UseStateForUnknown(),
+					},
+					Validators: []validator.List{
+						listvalidator.ValueStringsAre(stringvalidator.OneOf("DATERANGE", "SCTE35_ENHANCED")),
+					},
+				},
+				"manifest_window_seconds": optionalUnknownInt64,
+			},
+		},
+		"playback_url": computedString,
+		"source_group": requiredString,
+	}
+}
+
+func (r *resourceChannel) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = nameIdentitySchema
+}
+
 func (r *resourceChannel) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	r.client = req.ProviderData.(*mediatailor.Client)
+	data := req.ProviderData.(*providerData)
+	r.client = data.client
+	r.region = data.region
+	r.tags = data.tags
+}
+
+func (r *resourceChannel) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	r.validateFillerSlate(ctx, req, resp)
+	r.validateAccessPolicy(ctx, req, resp)
+}
+
+// @ADR
+// Context: MediaTailor rejects channels with a filler slate when they use the LOOP playback mode or the BASIC tier,
+// but only when the channel is created or updated, after the other resources of the configuration are applied.
+// Decision: We decided to validate the combinations of filler_slate, playback_mode and tier in the configuration.
+// Consequences: Invalid channels are reported by terraform validate and plan. The rules need to follow the API if it
+// starts accepting more combinations. The live sources, which MediaTailor only plays on STANDARD channels, are not
+// validated: a channel plays them through its programs, which the provider does not manage, so the configuration of a
+// channel does not reference them.
+func (r *resourceChannel) validateFillerSlate(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var fillerSlate types.Object
+	var playbackMode, tier types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("filler_slate"), &fillerSlate)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("playback_mode"), &playbackMode)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("tier"), &tier)...)
+	if resp.Diagnostics.HasError() || fillerSlate.IsNull() || fillerSlate.IsUnknown() {
+		return
+	}
+
+	if playbackMode.ValueString() == "LOOP" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("filler_slate"),
+			"Invalid filler slate",
+			"filler_slate cannot be set on channels with the LOOP playback mode.",
+		)
+	}
+	// the tier of the channel defaults to BASIC when it is not configured
+	if !tier.IsUnknown() && (tier.IsNull() || tier.ValueString() == "BASIC") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("filler_slate"),
+			"Invalid filler slate",
+			"filler_slate can only be set on channels with the STANDARD tier.",
+		)
+	}
+}
+
+func (r *resourceChannel) validateAccessPolicy(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var accessPolicy types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("access_policy"), &accessPolicy)...)
+	if resp.Diagnostics.HasError() || accessPolicy.IsNull() || accessPolicy.IsUnknown() {
+		return
+	}
+
+	// the access policy cannot be rendered until all of its values are known
+	var model models.AccessPolicyModel
+	if diags := accessPolicy.As(ctx, &model, basetypes.ObjectAsOptions{}); diags.HasError() {
+		return
+	}
+	if _, err := renderAccessPolicy(&model, "arn:aws:mediatailor:us-east-1:123456789012:channel/example"); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("access_policy"), "Invalid access policy", err.Error())
+	}
+}
+
+func (r *resourceChannel) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planTagsAll(ctx, r.tags, req, resp)
+	r.validateOutputsAgainstFillerSlate(ctx, req, resp)
+}
+
+// @ADR
+// Context: MediaTailor accepts channels whose outputs cannot be served by the package configurations of the filler
+// slate, and only reports the mismatch with alerts once the channel plays the slate.
+// Decision: We decided to describe the filler slate while planning, and to fail the plan when the source group and the
+// manifest type of an output have no matching package configuration on the slate.
+// Consequences: Planning a channel with a known filler slate makes one more API call. The slate is not validated when
+// it is created in the same apply, as it cannot be described yet.
+func (r *resourceChannel) validateOutputsAgainstFillerSlate(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// the resource is being destroyed, or the provider is not configured yet
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var fillerSlate types.Object
+	var outputs types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("filler_slate"), &fillerSlate)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("outputs"), &outputs)...)
+	if resp.Diagnostics.HasError() || fillerSlate.IsNull() || fillerSlate.IsUnknown() || outputs.IsUnknown() {
+		return
+	}
+
+	// the filler slate and the outputs cannot be validated until their values are known
+	var slate models.FillerSlateModel
+	if diags := fillerSlate.As(ctx, &slate, basetypes.ObjectAsOptions{}); diags.HasError() || slate.SourceLocationName == nil || slate.VodSourceName == nil {
+		return
+	}
+	var outputModels map[string]models.ChannelOutputModel
+	if diags := outputs.ElementsAs(ctx, &outputModels, false); diags.HasError() {
+		return
+	}
+
+	vodSource, err := r.client.DescribeVodSource(ctx, &mediatailor.DescribeVodSourceInput{
+		SourceLocationName: slate.SourceLocationName,
+		VodSourceName:      slate.VodSourceName,
+	})
+	if err != nil {
+		if !strings.Contains(err.Error(), "NotFound") {
+			resp.Diagnostics.AddWarning(
+				"Error while describing the filler slate "+*slate.SourceLocationName+","+*slate.VodSourceName+" "+err.Error(),
+				"The outputs of the channel could not be validated against the package configurations of the filler slate: "+err.Error(),
+			)
+		}
+		return
+	}
+
+	unmatched := unmatchedOutputs(outputModels, vodSource.HttpPackageConfigurations)
+	for _, manifestName := range slices.Sorted(maps.Keys(unmatched)) {
+		manifestType := unmatched[manifestName]
+		resp.Diagnostics.AddAttributeError(
+			path.Root("outputs").AtMapKey(manifestName).AtName("source_group"),
+			"Output does not match the filler slate",
+			"The filler slate "+*slate.SourceLocationName+","+*slate.VodSourceName+" has no "+string(manifestType)+
+				" package configuration for the source group "+*outputModels[manifestName].SourceGroup+" of output "+
+				manifestName+".",
+		)
+	}
 }
 
 func (r *resourceChannel) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan models.ChannelModel
+	var plan models.ChannelResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -156,24 +358,33 @@ func (r *resourceChannel) Create(ctx context.Context, req resource.CreateRequest
 		}
 	}
 
-	if !plan.Policy.IsNull() {
-		policy := plan.Policy.ValueString()
-		if err := createChannelPolicy(plan.Name, &policy, r.client); err != nil {
+	policy, err := desiredChannelPolicy(plan, *channel.Arn)
+	if err != nil {
+		resp.Diagnostics.AddError("Error while rendering the access policy for channel "+*channel.ChannelName, err.Error())
+		return
+	}
+
+	if !policy.IsNull() {
+		if err := createChannelPolicy(plan.Name, policy.ValueStringPointer(), r.client); err != nil {
 			resp.Diagnostics.AddError("Error while creating the channel policy for channel "+*channel.ChannelName, err.Error())
 			return
 		}
 	}
 
 	if plan.EnableAsRunLogs != types.BoolValue(false) {
-		logConfigInput := getConfigureLogsForChannelInput(plan)
+		logConfigInput := getConfigureLogsForChannelInput(plan.ChannelModel)
 		if _, err := r.client.ConfigureLogsForChannel(ctx, logConfigInput); err != nil {
 			resp.Diagnostics.AddError("Error while setting channel logs "+*channel.ChannelName, err.Error())
 			return
 		}
 	}
 
-	newPlan := writeChannelToPlan(plan, *channel)
+	newPlan := plan
+	newPlan.ChannelModel = writeChannelToPlan(plan.ChannelModel, *channel)
+	newPlan.Outputs = readOutputsByManifestName(plan.Outputs, channel.Outputs)
+	newPlan.Tags, newPlan.TagsAll = r.tags.readTags(channel.Tags, plan.Tags)
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, newPlan.Arn)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, newPlan)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -182,7 +393,7 @@ func (r *resourceChannel) Create(ctx context.Context, req resource.CreateRequest
 }
 
 func (r *resourceChannel) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state models.ChannelModel
+	var state models.ChannelResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -212,13 +423,22 @@ func (r *resourceChannel) Read(ctx context.Context, req resource.ReadRequest, re
 		state.Policy = jsontypes.NewNormalizedNull()
 	}
 
-	state = writeChannelToState(state, *channel)
+	if state.AccessPolicy != nil {
+		state.AccessPolicy = readAccessPolicy(state.AccessPolicy, state.Policy, *channel.Arn)
+		state.Policy = jsontypes.NewNormalizedNull()
+	}
+
+	tags := state.Tags
+	state.ChannelModel = writeChannelToState(state.ChannelModel, *channel)
+	state.Outputs = readOutputsByManifestName(state.Outputs, channel.Outputs)
+	state.Tags, state.TagsAll = r.tags.readTags(channel.Tags, tags)
 
 	if state.ChannelState != nil {
 		channelState := string(channel.ChannelState)
 		state.ChannelState = &channelState
 	}
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, state.Arn)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -227,8 +447,9 @@ func (r *resourceChannel) Read(ctx context.Context, req resource.ReadRequest, re
 }
 
 func (r *resourceChannel) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan models.ChannelModel
+	var plan, state models.ChannelResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -244,71 +465,30 @@ func (r *resourceChannel) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	err = UpdatesTags(r.client, channel.Tags, plan.Tags, *channel.Arn)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error while updating channel tags"+err.Error(),
-			err.Error(),
-		)
-		return
-	}
-
-	previousState := channel.ChannelState
-	newState := plan.ChannelState
-
-	err = stopChannel(previousState, channelName, r.client)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error while stopping to run channel "+*channelName+" "+err.Error(),
-			err.Error(),
-		)
-		return
-	}
-
-	if err := handlePolicyUpdate(ctx, r.client, plan); err != nil {
-		resp.Diagnostics.AddError(
-			"Error while updating channel policy "+err.Error(),
-			err.Error(),
-		)
-		return
-	}
-
-	updatedChannel, err := r.client.UpdateChannel(ctx, getUpdateChannelInput(plan))
-	if err != nil {
+	update := newChannelUpdate(r.client, r.tags, plan, state, channel)
+	if step, err := update.apply(ctx); err != nil {
+		// restored first, so that the applied steps leave out the stop it undid
+		restored := update.restore(ctx)
+		detail := "The update failed while " + step + ": " + err.Error() + "\n\n" + update.appliedSteps()
+		if restored != "" {
+			detail += " " + restored
+		}
 		resp.Diagnostics.AddError(
-			"Error while updating channel "+*channel.ChannelName+" "+err.Error(),
-			err.Error(),
+			"Error while updating channel "+*channelName+" "+err.Error(),
+			detail,
 		)
+		resp.Diagnostics.Append(resp.State.Set(ctx, update.state)...)
 		return
 	}
 
-	if shouldStartChannel(previousState, newState) {
-		_, err := r.client.StartChannel(ctx, &mediatailor.StartChannelInput{ChannelName: channelName})
-		if err != nil {
-			resp.Diagnostics.AddError("Error while starting the channel "+*channelName, err.Error())
-			return
-		}
-	}
-
-	if shouldUpdateChannelLogging(channel.LogConfiguration.LogTypes, plan) {
-		logConfigInput := getConfigureLogsForChannelInput(plan)
-		if _, err := r.client.ConfigureLogsForChannel(ctx, logConfigInput); err != nil {
-			resp.Diagnostics.AddError("Error while setting channel logs "+*channel.ChannelName, err.Error())
-			return
-		}
-	}
-
-	plan.ChannelState = newState
-	plan = writeChannelToPlan(plan, mediatailor.CreateChannelOutput(*updatedChannel))
-
-	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, update.state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 }
 
 func (r *resourceChannel) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state models.ChannelModel
+	var state models.ChannelResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -339,6 +519,35 @@ func (r *resourceChannel) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 }
 
+func (r *resourceChannel) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(ctx, r, upgradeChannelOutputsToMap, upgradeTimestampsToRFC3339)
+}
+
+// upgradeChannelOutputsToMap upgrades version 0 of the schema, whose outputs were a list holding the manifest names, to
+// the outputs keyed by manifest name.
+func upgradeChannelOutputsToMap(state map[string]any) error {
+	outputs, ok := state["outputs"].([]any)
+	if !ok {
+		return nil
+	}
+
+	byManifestName := map[string]any{}
+	for _, o := range outputs {
+		output, ok := o.(map[string]any)
+		if !ok {
+			return fmt.Errorf("unexpected output %v", o)
+		}
+		manifestName, ok := output["manifest_name"].(string)
+		if !ok {
+			return fmt.Errorf("output without manifest name %v", o)
+		}
+		delete(output, "manifest_name")
+		byManifestName[manifestName] = output
+	}
+	state["outputs"] = byManifestName
+	return nil
+}
+
 func (r *resourceChannel) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	importStateByName(ctx, arnResourceChannel, r.region, req, resp)
 }
@@ -1,14 +1,22 @@
 package awsmt
 
 import (
+	"context"
 	"fmt"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"os"
 	"regexp"
+	"strings"
 	"testing"
 )
 
 func testAccPreCheck(t *testing.T) {
+	if isFakeMode() {
+		return
+	}
 	if a, b, c := os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY"), os.Getenv("AWS_PROFILE"); (a == "" || b == "") && c == "" {
 		t.Fatal("Either AWS_PROFILE or both AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set for acceptance tests")
 	}
@@ -16,7 +24,7 @@ func testAccPreCheck(t *testing.T) {
 
 func TestAccChannelResourceBasic(t *testing.T) {
 	resourceName := "awsmt_channel.test"
-	name := "test"
+	name := "tf-acc-channel"
 	stateStopped := "STOPPED"
 	stateRunning := "RUNNING"
 	manifestWindowSeconds := "30"
@@ -40,22 +48,22 @@ func TestAccChannelResourceBasic(t *testing.T) {
 			{
 				Config: basicChannel(name, stateStopped, manifestWindowSeconds, minBufferTimeSeconds, minUpdatePeriodSeconds, PresentationDelaySeconds, k1, v1, k2, v2),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "id", "test"),
+					resource.TestCheckResourceAttr(resourceName, "id", "tf-acc-channel"),
 					resource.TestMatchResourceAttr(resourceName, "arn", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:channel\/.*$`)),
-					resource.TestCheckResourceAttr(resourceName, "name", "test"),
-					resource.TestMatchResourceAttr(resourceName, "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
-					resource.TestMatchResourceAttr(resourceName, "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-acc-channel"),
+					resource.TestMatchResourceAttr(resourceName, "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`)),
+					resource.TestMatchResourceAttr(resourceName, "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`)),
 					resource.TestCheckResourceAttr(resourceName, "channel_state", "STOPPED"),
 					resource.TestCheckResourceAttr(resourceName, "playback_mode", "LOOP"),
 					resource.TestMatchResourceAttr(resourceName, "policy", regexp.MustCompile(`mediatailor:GetManifest`)),
 					resource.TestCheckResourceAttr(resourceName, "tier", "BASIC"),
 					resource.TestCheckResourceAttr(resourceName, "tags.Environment", "dev"),
-					resource.TestCheckResourceAttr(resourceName, "outputs.0.manifest_name", "default"),
-					resource.TestCheckResourceAttr(resourceName, "outputs.0.source_group", "default"),
-					resource.TestCheckResourceAttr(resourceName, "outputs.0.dash_playlist_settings.manifest_window_seconds", "30"),
-					resource.TestCheckResourceAttr(resourceName, "outputs.0.dash_playlist_settings.min_buffer_time_seconds", "2"),
-					resource.TestCheckResourceAttr(resourceName, "outputs.0.dash_playlist_settings.min_update_period_seconds", "2"),
-					resource.TestCheckResourceAttr(resourceName, "outputs.0.dash_playlist_settings.suggested_presentation_delay_seconds", "2"),
+					resource.TestCheckResourceAttr(resourceName, "outputs.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "outputs.default.source_group", "default"),
+					resource.TestCheckResourceAttr(resourceName, "outputs.default.dash_playlist_settings.manifest_window_seconds", "30"),
+					resource.TestCheckResourceAttr(resourceName, "outputs.default.dash_playlist_settings.min_buffer_time_seconds", "2"),
+					resource.TestCheckResourceAttr(resourceName, "outputs.default.dash_playlist_settings.min_update_period_seconds", "2"),
+					resource.TestCheckResourceAttr(resourceName, "outputs.default.dash_playlist_settings.suggested_presentation_delay_seconds", "2"),
 				),
 			},
 			// ImportState testing
@@ -63,26 +71,32 @@ func TestAccChannelResourceBasic(t *testing.T) {
 				ResourceName: resourceName,
 				ImportState:  true,
 			},
+			// Import resource by arn
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: importStateIdFromArn(resourceName),
+			},
 			// Update and Read testing
 			{
 				Config: basicChannel(name, stateRunning, manifestWindowSeconds2, minBufferTimeSeconds2, minUpdatePeriodSeconds2, presentationDelaySeconds2, k3, v3, k2, v2),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "id", "test"),
+					resource.TestCheckResourceAttr(resourceName, "id", "tf-acc-channel"),
 					resource.TestMatchResourceAttr(resourceName, "arn", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:channel\/.*$`)),
-					resource.TestCheckResourceAttr(resourceName, "name", "test"),
-					resource.TestMatchResourceAttr(resourceName, "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
-					resource.TestMatchResourceAttr(resourceName, "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-acc-channel"),
+					resource.TestMatchResourceAttr(resourceName, "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`)),
+					resource.TestMatchResourceAttr(resourceName, "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`)),
 					resource.TestCheckResourceAttr(resourceName, "channel_state", "RUNNING"),
 					resource.TestCheckResourceAttr(resourceName, "playback_mode", "LOOP"),
 					resource.TestCheckResourceAttr(resourceName, "tier", "BASIC"),
 					resource.TestCheckResourceAttr(resourceName, "tags.Environment", "prod"),
 					resource.TestCheckResourceAttr(resourceName, "tags.Testing", "pass"),
-					resource.TestCheckResourceAttr(resourceName, "outputs.0.manifest_name", "default"),
-					resource.TestCheckResourceAttr(resourceName, "outputs.0.source_group", "default"),
-					resource.TestCheckResourceAttr(resourceName, "outputs.0.dash_playlist_settings.manifest_window_seconds", "40"),
-					resource.TestCheckResourceAttr(resourceName, "outputs.0.dash_playlist_settings.min_buffer_time_seconds", "3"),
-					resource.TestCheckResourceAttr(resourceName, "outputs.0.dash_playlist_settings.min_update_period_seconds", "3"),
-					resource.TestCheckResourceAttr(resourceName, "outputs.0.dash_playlist_settings.suggested_presentation_delay_seconds", "3"),
+					resource.TestCheckResourceAttr(resourceName, "outputs.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "outputs.default.source_group", "default"),
+					resource.TestCheckResourceAttr(resourceName, "outputs.default.dash_playlist_settings.manifest_window_seconds", "40"),
+					resource.TestCheckResourceAttr(resourceName, "outputs.default.dash_playlist_settings.min_buffer_time_seconds", "3"),
+					resource.TestCheckResourceAttr(resourceName, "outputs.default.dash_playlist_settings.min_update_period_seconds", "3"),
+					resource.TestCheckResourceAttr(resourceName, "outputs.default.dash_playlist_settings.suggested_presentation_delay_seconds", "3"),
 				),
 			},
 		},
@@ -102,23 +116,224 @@ func TestAccChannelResourceErrors(t *testing.T) {
 	})
 }
 
+func TestAccChannelAccessPolicy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: accessPolicyChannel(`allow_anonymous_get_manifest = true`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("awsmt_channel.test", "access_policy.allow_anonymous_get_manifest", "true"),
+					resource.TestCheckNoResourceAttr("awsmt_channel.test", "policy"),
+					resource.TestMatchResourceAttr("data.awsmt_channel.test", "policy", regexp.MustCompile(`"Principal":"\*"`)),
+				),
+			},
+			{
+				Config: accessPolicyChannel(`
+					allowed_principals = ["arn:aws:iam::123456789012:root"]
+					source_ip_cidrs = ["10.0.0.0/8"]
+				`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("awsmt_channel.test", "access_policy.allowed_principals.#", "1"),
+					resource.TestMatchResourceAttr("data.awsmt_channel.test", "policy", regexp.MustCompile(`"aws:SourceIp"`)),
+				),
+			},
+			{
+				Config:      accessPolicyChannel(`allow_anonymous_get_manifest = false`),
+				ExpectError: regexp.MustCompile("must allow anonymous access or at least one principal"),
+			},
+		},
+	})
+}
+
+func TestAccChannelFillerSlateMismatch(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fillerSlateChannel(""),
+			},
+			{
+				Config: fillerSlateChannel(`
+				resource "awsmt_channel" "test"  {
+					name = "tf-acc-channel"
+					outputs = { default = {
+						source_group  = "default"
+						dash_playlist_settings = {
+							manifest_window_seconds = 30
+						}
+					}}
+					playback_mode = "LINEAR"
+					filler_slate = {
+						source_location_name = awsmt_source_location.test.name
+						vod_source_name = awsmt_vod_source.test.name
+					}
+					tier = "STANDARD"
+				}`),
+				ExpectError: regexp.MustCompile("has no DASH package configuration for the source group default"),
+			},
+		},
+	})
+}
+
+// channelConfig returns a channel configuration with an HLS output using the given ad markup types, and the given
+// attributes.
+func channelConfig(adMarkupTypes []string, attributes map[string]tftypes.Value) map[string]tftypes.Value {
+	var schema fwresource.SchemaResponse
+	ResourceChannel().Schema(context.Background(), fwresource.SchemaRequest{}, &schema)
+	channelType := schema.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
+	outputsType := channelType.AttributeTypes["outputs"].(tftypes.Map)
+	outputType := outputsType.ElementType.(tftypes.Object)
+	hlsType := outputType.AttributeTypes["hls_playlist_settings"].(tftypes.Object)
+
+	var markupTypes []tftypes.Value
+	for _, markupType := range adMarkupTypes {
+		markupTypes = append(markupTypes, tftypes.NewValue(tftypes.String, markupType))
+	}
+	output := objectValue(outputType, map[string]tftypes.Value{
+		"source_group": tftypes.NewValue(tftypes.String, "default"),
+		"hls_playlist_settings": objectValue(hlsType, map[string]tftypes.Value{
+			"ad_markup_type": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, markupTypes),
+		}),
+	})
+
+	config := map[string]tftypes.Value{
+		"name":          tftypes.NewValue(tftypes.String, "test"),
+		"playback_mode": tftypes.NewValue(tftypes.String, "LINEAR"),
+		"outputs":       tftypes.NewValue(outputsType, map[string]tftypes.Value{"default": output}),
+	}
+	for name, value := range attributes {
+		config[name] = value
+	}
+	return config
+}
+
+func TestChannelConfigValidation(t *testing.T) {
+	fillerSlateType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"source_location_name": tftypes.String, "vod_source_name": tftypes.String}}
+	fillerSlate := tftypes.NewValue(fillerSlateType, map[string]tftypes.Value{
+		"source_location_name": tftypes.NewValue(tftypes.String, "slate_location"),
+		"vod_source_name":      tftypes.NewValue(tftypes.String, "slate"),
+	})
+
+	for name, tc := range map[string]struct {
+		adMarkupTypes []string
+		attributes    map[string]tftypes.Value
+		err           string
+	}{
+		"valid channel": {
+			adMarkupTypes: []string{"DATERANGE", "SCTE35_ENHANCED"},
+		},
+		"filler slate on a standard linear channel": {
+			attributes: map[string]tftypes.Value{"filler_slate": fillerSlate, "tier": tftypes.NewValue(tftypes.String, "STANDARD")},
+		},
+		"filler slate on a loop channel": {
+			attributes: map[string]tftypes.Value{
+				"filler_slate":  fillerSlate,
+				"tier":          tftypes.NewValue(tftypes.String, "STANDARD"),
+				"playback_mode": tftypes.NewValue(tftypes.String, "LOOP"),
+			},
+			err: "filler_slate cannot be set on channels with the LOOP playback mode",
+		},
+		"filler slate on a basic channel": {
+			attributes: map[string]tftypes.Value{"filler_slate": fillerSlate, "tier": tftypes.NewValue(tftypes.String, "BASIC")},
+			err:        "filler_slate can only be set on channels with the STANDARD tier",
+		},
+		"filler slate on the default tier": {
+			attributes: map[string]tftypes.Value{"filler_slate": fillerSlate},
+			err:        "filler_slate can only be set on channels with the STANDARD tier",
+		},
+		"unknown tier": {
+			attributes: map[string]tftypes.Value{"filler_slate": fillerSlate, "tier": tftypes.NewValue(tftypes.String, tftypes.UnknownValue)},
+		},
+		"unknown ad markup type": {
+			adMarkupTypes: []string{"SCTE35"},
+			err:           "value must be one of",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			diags := validateResourceConfig(t, ResourceChannel(), channelConfig(tc.adMarkupTypes, tc.attributes))
+			var errs []string
+			for _, diag := range diags {
+				if diag.Severity == tfprotov6.DiagnosticSeverityError {
+					errs = append(errs, diag.Summary+": "+diag.Detail)
+				}
+			}
+			if tc.err == "" && len(errs) > 0 {
+				t.Errorf("expected no error, got %v", errs)
+			}
+			if tc.err != "" && (len(errs) != 1 || !strings.Contains(errs[0], tc.err)) {
+				t.Errorf("expected one error containing %q, got %v", tc.err, errs)
+			}
+		})
+	}
+}
+
+func TestUpgradeChannelStateV0(t *testing.T) {
+	upgraded := upgradeResourceState(t, ResourceChannel(), 0, `{
+		"id": "test",
+		"name": "test",
+		"playback_mode": "LOOP",
+		"tier": "BASIC",
+		"creation_time": "2024-05-13 09:12:45.123 +0000 UTC",
+		"outputs": [
+			{"manifest_name": "hls", "source_group": "default", "playback_url": "https://example.com/hls.m3u8", "hls_playlist_settings": {"ad_markup_type": ["DATERANGE"], "manifest_window_seconds": 30}},
+			{"manifest_name": "dash", "source_group": "default", "playback_url": "https://example.com/dash.mpd", "dash_playlist_settings": {"manifest_window_seconds": 30}}
+		]
+	}`)
+
+	var state map[string]tftypes.Value
+	if err := upgraded.As(&state); err != nil {
+		t.Fatal(err)
+	}
+	var outputs map[string]tftypes.Value
+	if err := state["outputs"].As(&outputs); err != nil {
+		t.Fatal(err)
+	}
+	if len(outputs) != 2 {
+		t.Fatalf("expected 2 outputs, got %v", outputs)
+	}
+
+	var hls map[string]tftypes.Value
+	if err := outputs["hls"].As(&hls); err != nil {
+		t.Fatal(err)
+	}
+	var playbackUrl, sourceGroup string
+	_ = hls["playback_url"].As(&playbackUrl)
+	_ = hls["source_group"].As(&sourceGroup)
+	if playbackUrl != "https://example.com/hls.m3u8" || sourceGroup != "default" || hls["hls_playlist_settings"].IsNull() {
+		t.Errorf("unexpected hls output %v", hls)
+	}
+	var name string
+	_ = state["name"].As(&name)
+	if name != "test" {
+		t.Errorf("expected the other attributes to be kept, got %v", state)
+	}
+	var creationTime string
+	_ = state["creation_time"].As(&creationTime)
+	if creationTime != "2024-05-13T09:12:45Z" {
+		t.Errorf("expected the creation time to be upgraded to RFC3339, got %q", creationTime)
+	}
+}
+
 func TestAccChannelRename(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: minimalChannel("foo"),
+				Config: minimalChannel("tf-acc-foo"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("awsmt_channel.test", "name", "foo"),
-					resource.TestCheckResourceAttr("data.awsmt_channel.test", "name", "foo"),
+					resource.TestCheckResourceAttr("awsmt_channel.test", "name", "tf-acc-foo"),
+					resource.TestCheckResourceAttr("data.awsmt_channel.test", "name", "tf-acc-foo"),
 				),
 			},
 			{
-				Config: minimalChannel("bar"),
+				Config: minimalChannel("tf-acc-bar"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("awsmt_channel.test", "name", "bar"),
-					resource.TestCheckResourceAttr("data.awsmt_channel.test", "name", "bar"),
+					resource.TestCheckResourceAttr("awsmt_channel.test", "name", "tf-acc-bar"),
+					resource.TestCheckResourceAttr("data.awsmt_channel.test", "name", "tf-acc-bar"),
 				),
 			},
 		},
@@ -128,15 +343,14 @@ func TestAccChannelRename(t *testing.T) {
 func TestAccChannelResourceNoState(t *testing.T) {
 	noStateChannel := `
 resource "awsmt_channel" "test"  {
-	name = "test"
-	outputs = [{
-		manifest_name                = "default"
+	name = "tf-acc-channel"
+	outputs = { default = {
 		source_group                 = "default"
 		hls_playlist_settings = {
 			ad_markup_type = ["DATERANGE"]
 			manifest_window_seconds = "30"
 		}
-	}]
+	}}
 	playback_mode = "LOOP"
 	tier = "BASIC"
 	tags = {"Environment": "dev"}
@@ -150,7 +364,7 @@ resource "awsmt_channel" "test"  {
 			{
 				Config: noStateChannel,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("awsmt_channel.test", "name", "test"),
+					resource.TestCheckResourceAttr("awsmt_channel.test", "name", "tf-acc-channel"),
 				),
 			},
 		},
@@ -248,8 +462,7 @@ func basicChannel(name, state, manifestWindowSeconds, minBufferTimeSeconds, minU
 				resource "awsmt_channel" "test"  {
   					name = "%[1]s"
   					channel_state = "%[2]s"
-  					outputs = [{
-    					manifest_name                = "default"
+  					outputs = { default = {
 						source_group                 = "default"
     					dash_playlist_settings = {
 							manifest_window_seconds = "%[3]s"
@@ -257,9 +470,9 @@ func basicChannel(name, state, manifestWindowSeconds, minBufferTimeSeconds, minU
 							min_update_period_seconds = "%[5]s"
 							suggested_presentation_delay_seconds = "%[6]s"
 						}
-  					}]
+  					}}
   					playback_mode = "LOOP"
-  					policy = "{\"Version\": \"2012-10-17\", \"Statement\": [{\"Sid\": \"AllowAnonymous\", \"Effect\": \"Allow\", \"Principal\": \"*\", \"Action\": \"mediatailor:GetManifest\", \"Resource\": \"arn:aws:mediatailor:eu-central-1:985600762523:channel/test\"}]}"
+  					policy = "{\"Version\": \"2012-10-17\", \"Statement\": [{\"Sid\": \"AllowAnonymous\", \"Effect\": \"Allow\", \"Principal\": \"*\", \"Action\": \"mediatailor:GetManifest\", \"Resource\": \"arn:aws:mediatailor:eu-central-1:985600762523:channel/tf-acc-channel\"}]}"
   					tier = "BASIC"
 					tags = {
    		 						"%[7]s": "%[8]s",
@@ -283,8 +496,7 @@ func minimalChannel(name string) string {
 				resource "awsmt_channel" "test"  {
   					name = "%[1]s"
 					playback_mode = "LOOP"
-  					outputs = [{
-    					manifest_name                = "default"
+  					outputs = { default = {
 						source_group                 = "default"
     					dash_playlist_settings = {
 							manifest_window_seconds = "30"
@@ -292,7 +504,7 @@ func minimalChannel(name string) string {
 							min_update_period_seconds = "10"
 							suggested_presentation_delay_seconds = "10"
 						}
-  					}]
+  					}}
 				}
 
 				data "awsmt_channel" "test" {
@@ -305,21 +517,67 @@ func minimalChannel(name string) string {
 	)
 }
 
+func accessPolicyChannel(accessPolicy string) string {
+	return fmt.Sprintf(
+		`
+				resource "awsmt_channel" "test"  {
+  					name = "tf-acc-channel"
+					playback_mode = "LOOP"
+  					outputs = { default = {
+						source_group                 = "default"
+    					hls_playlist_settings = {
+							ad_markup_type = ["DATERANGE"]
+						}
+  					}}
+					access_policy = {
+						%[1]s
+					}
+				}
+
+				data "awsmt_channel" "test" {
+  					name = awsmt_channel.test.name
+				}
+				`, accessPolicy,
+	)
+}
+
+func fillerSlateChannel(channel string) string {
+	return fmt.Sprintf(`
+			resource "awsmt_source_location" "test" {
+				name = "tf-acc-filler-slate-location"
+				http_configuration = {
+					base_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com/"
+				}
+			}
+
+			resource "awsmt_vod_source" "test" {
+				source_location_name = awsmt_source_location.test.name
+				name = "tf-acc-slate"
+				http_package_configurations = [{
+					path = "/"
+					source_group = "default"
+					type = "HLS"
+				}]
+			}
+			%s
+			`, channel,
+	)
+}
+
 func errorChannel() string {
 	return `
 				resource "awsmt_channel" "test"  {
-  					name = "test"
+  					name = "tf-acc-channel"
   					channel_state = "RUNNING"
-  					outputs = [{
-    					manifest_name                = "default"
+  					outputs = { default = {
 						source_group                 = "default"
     					hls_playlist_settings = {
 							ad_markup_type = ["DATERANGE"]
 							manifest_window_seconds = 30
 						}
-  					}]
+  					}}
   					playback_mode = "LINEAR"
-  					policy = "{\"Version\": \"2012-10-17\", \"Statement\": [{\"Sid\": \"AllowAnonymous\", \"Effect\": \"Allow\", \"Principal\": \"*\", \"Action\": \"mediatailor:GetManifest\", \"Resource\": \"arn:aws:mediatailor:eu-central-1:985600762523:channel/test\"}]}"
+  					policy = "{\"Version\": \"2012-10-17\", \"Statement\": [{\"Sid\": \"AllowAnonymous\", \"Effect\": \"Allow\", \"Principal\": \"*\", \"Action\": \"mediatailor:GetManifest\", \"Resource\": \"arn:aws:mediatailor:eu-central-1:985600762523:channel/tf-acc-channel\"}]}"
   					tier = "BASIC"
 					tags = {"Environment": "dev"}
 					}
@@ -336,15 +594,14 @@ func errorChannel() string {
 func hlsChannelNoManifestWindowSeconds() string {
 	return `
 				resource "awsmt_channel" "test"  {
-  					name = "test"
+  					name = "tf-acc-channel"
   					channel_state = "STOPPED"
-  					outputs = [{
-    					manifest_name                = "default"
+  					outputs = { default = {
 						source_group                 = "default"
     					hls_playlist_settings = {
 							ad_markup_type = ["DATERANGE"]
 						}
-  					}]
+  					}}
   					playback_mode = "LOOP"
   					tier = "BASIC"
 					tags = {"Environment": "dev"}
@@ -362,16 +619,15 @@ func hlsChannelNoManifestWindowSeconds() string {
 func hlsChannel(mw_s string) string {
 	return fmt.Sprintf(`
 				resource "awsmt_channel" "test"  {
-  					name = "test"
+  					name = "tf-acc-channel"
   					channel_state = "RUNNING"
-  					outputs = [{
-    					manifest_name                = "default"
+  					outputs = { default = {
 						source_group                 = "default"
     					hls_playlist_settings = {
 							ad_markup_type = ["DATERANGE"]
 							manifest_window_seconds = "%[1]s"
 						}
-  					}]
+  					}}
   					playback_mode = "LOOP"
   					tier = "BASIC"
 					tags = {"Environment": "dev"}
@@ -389,17 +645,16 @@ func hlsChannel(mw_s string) string {
 func logConfigChannel(enable bool) string {
 	return fmt.Sprintf(`
 				resource "awsmt_channel" "test"  {
-  					name = "test"
+  					name = "tf-acc-channel"
   					channel_state = "RUNNING"
 					enable_as_run_logs = %[1]v
-  					outputs = [{
-    					manifest_name                = "default"
+  					outputs = { default = {
 						source_group                 = "default"
     					hls_playlist_settings = {
 							ad_markup_type = ["DATERANGE"]
 							manifest_window_seconds = "60"
 						}
-  					}]
+  					}}
   					playback_mode = "LOOP"
   					tier = "BASIC"
 					tags = {"Environment": "dev"}
@@ -415,6 +670,15 @@ func logConfigChannel(enable bool) string {
 }
 
 func standardTierChannel(t string) string {
+	// only the channels of the STANDARD tier can have a filler slate, the others use the LOOP playback mode
+	playbackMode := `playback_mode = "LOOP"`
+	if t == "STANDARD" {
+		playbackMode = `playback_mode = "LINEAR"
+			filler_slate = {
+				source_location_name = awsmt_source_location.test_source_location.name
+				vod_source_name = awsmt_vod_source.test.name
+			}`
+	}
 	return fmt.Sprintf(`resource "awsmt_vod_source" "test" {
 				http_package_configurations = [{
 					path = "/"
@@ -422,7 +686,7 @@ func standardTierChannel(t string) string {
 					type = "HLS"
 				}]
 				source_location_name = awsmt_source_location.test_source_location.name
-				name = "vod_source_example"
+				name = "tf-acc-vod-source-example"
 				tags = {"Environment": "dev"}
 				}
 
@@ -436,7 +700,7 @@ func standardTierChannel(t string) string {
 			}
 
 			resource "awsmt_source_location" "test_source_location"{
-				name = "test_source_location"
+				name = "tf-acc-source-location"
 				http_configuration = {
 					base_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com/"
 				}
@@ -454,23 +718,18 @@ func standardTierChannel(t string) string {
 			}
 	
 			resource "awsmt_channel" "test"  {
-			name = "test"
+			name = "tf-acc-channel"
 			channel_state = "STOPPED"
-			outputs = [{
-				manifest_name                = "default"
+			outputs = { default = {
 				source_group                 = "default"
 				hls_playlist_settings = {
 					ad_markup_type = ["DATERANGE"]
 					manifest_window_seconds = 30
 				}
-			}]
-			playback_mode = "LINEAR"
-			filler_slate = {
-				source_location_name = awsmt_source_location.test_source_location.name
-				vod_source_name = awsmt_vod_source.test.name
-			}
-			policy = "{\"Version\": \"2012-10-17\", \"Statement\": [{\"Sid\": \"AllowAnonymous\", \"Effect\": \"Allow\", \"Principal\": \"*\", \"Action\": \"mediatailor:GetManifest\", \"Resource\": \"arn:aws:mediatailor:eu-central-1:985600762523:channel/test\"}]}"
-			tier = "%s"
+			}}
+			%[2]s
+			policy = "{\"Version\": \"2012-10-17\", \"Statement\": [{\"Sid\": \"AllowAnonymous\", \"Effect\": \"Allow\", \"Principal\": \"*\", \"Action\": \"mediatailor:GetManifest\", \"Resource\": \"arn:aws:mediatailor:eu-central-1:985600762523:channel/tf-acc-channel\"}]}"
+			tier = "%[1]s"
 			tags = {"Environment": "dev"}
 			}
 
@@ -481,5 +740,5 @@ func standardTierChannel(t string) string {
 			output "channel_out" {
 				value = data.awsmt_channel.test
 			}
-`, t)
+`, t, playbackMode)
 }
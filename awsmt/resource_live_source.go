@@ -10,9 +10,12 @@ import (
 )
 
 var (
-	_ resource.Resource                = &resourceLiveSource{}
-	_ resource.ResourceWithConfigure   = &resourceLiveSource{}
-	_ resource.ResourceWithImportState = &resourceLiveSource{}
+	_ resource.Resource                 = &resourceLiveSource{}
+	_ resource.ResourceWithConfigure    = &resourceLiveSource{}
+	_ resource.ResourceWithImportState  = &resourceLiveSource{}
+	_ resource.ResourceWithModifyPlan   = &resourceLiveSource{}
+	_ resource.ResourceWithIdentity     = &resourceLiveSource{}
+	_ resource.ResourceWithUpgradeState = &resourceLiveSource{}
 )
 
 func ResourceLiveSource() resource.Resource {
@@ -20,7 +23,11 @@ func ResourceLiveSource() resource.Resource {
 }
 
 type resourceLiveSource struct {
-	client *mediatailor.Client
+	client mediaTailorClient
+	// cache serves the reads of the live sources when the read cache is enabled, it is nil otherwise
+	cache  *readCache
+	region string
+	tags   tagsConfig
 }
 
 func (r *resourceLiveSource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -29,25 +36,39 @@ func (r *resourceLiveSource) Metadata(_ context.Context, req resource.MetadataRe
 
 func (r *resourceLiveSource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"id":                          computedStringWithStateForUnknown,
 			"arn":                         computedStringWithStateForUnknown,
-			"creation_time":               computedStringWithStateForUnknown,
+			"creation_time":               computedRFC3339WithStateForUnknown,
 			"http_package_configurations": httpPackageConfigurationsResourceSchema,
-			"last_modified_time":          computedString,
+			"last_modified_time":          computedRFC3339,
 			"source_location_name":        requiredString,
 			"tags":                        optionalMap,
+			"tags_all":                    computedMap,
 			"name":                        requiredStringWithRequiresReplace,
 		},
 	}
 }
 
+func (r *resourceLiveSource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = contentSourceIdentitySchema
+}
+
 func (r *resourceLiveSource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	r.client = req.ProviderData.(*mediatailor.Client)
+	data := req.ProviderData.(*providerData)
+	r.client = data.client
+	r.cache = data.cache
+	r.region = data.region
+	r.tags = data.tags
+}
+
+func (r *resourceLiveSource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planTagsAll(ctx, r.tags, req, resp)
 }
 
 func (r *resourceLiveSource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -64,8 +85,11 @@ func (r *resourceLiveSource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	tags := plan.Tags
 	plan = readLiveSource(plan, *liveSource)
+	plan.Tags, plan.TagsAll = r.tags.readTags(liveSource.Tags, tags)
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, plan.Arn)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -94,14 +118,17 @@ func (r *resourceLiveSource) Read(ctx context.Context, req resource.ReadRequest,
 		SourceLocationName: &sourceLocationName,
 	}
 
-	liveSource, err := r.client.DescribeLiveSource(ctx, input)
+	liveSource, err := r.cache.describeLiveSource(ctx, r.client, input)
 	if err != nil {
 		resp.Diagnostics.AddError("Error while describing live source", err.Error())
 		return
 	}
 
+	tags := state.Tags
 	state = readLiveSource(state, mediatailor.CreateLiveSourceOutput(*liveSource))
+	state.Tags, state.TagsAll = r.tags.readTags(liveSource.Tags, tags)
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, state.Arn)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -128,7 +155,7 @@ func (r *resourceLiveSource) Update(ctx context.Context, req resource.UpdateRequ
 	}
 
 	// Update tags
-	err = UpdatesTags(r.client, liveSource.Tags, plan.Tags, *liveSource.Arn)
+	err = UpdatesTags(r.client, r.tags.withoutIgnored(liveSource.Tags), tagsAllToMap(plan.TagsAll), *liveSource.Arn)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error while updating live source tags"+err.Error(),
@@ -146,7 +173,9 @@ func (r *resourceLiveSource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	tags := plan.Tags
 	plan = readLiveSource(plan, mediatailor.CreateLiveSourceOutput(*updatedLiveSource))
+	plan.Tags, plan.TagsAll = r.tags.readTags(updatedLiveSource.Tags, tags)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 	if resp.Diagnostics.HasError() {
@@ -177,6 +206,10 @@ func (r *resourceLiveSource) Delete(ctx context.Context, req resource.DeleteRequ
 	}
 }
 
+func (r *resourceLiveSource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(ctx, r, upgradeTimestampsToRFC3339)
+}
+
 func (r *resourceLiveSource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	importStateForContentSources(ctx, req, resp)
+	importStateForContentSources(ctx, arnResourceLiveSource, r.region, req, resp)
 }
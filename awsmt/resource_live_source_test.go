@@ -9,7 +9,7 @@ import (
 
 func TestAccLiveSourceResourceBasic(t *testing.T) {
 	terraformResourceName := "awsmt_live_source.live_source_acc_test"
-	name := "live_source_example"
+	name := "tf-acc-live-source-example"
 	path := "/"
 	path2 := "/test"
 	k1 := "Environment"
@@ -25,15 +25,15 @@ func TestAccLiveSourceResourceBasic(t *testing.T) {
 			{
 				Config: basicLiveSourceWithSourceLocation(name, path, k1, v1, k2, v2),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr(terraformResourceName, "id", "test_source_location,live_source_example"),
+					resource.TestCheckResourceAttr(terraformResourceName, "id", "tf-acc-source-location,tf-acc-live-source-example"),
 					resource.TestMatchResourceAttr(terraformResourceName, "arn", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:liveSource\/.*$`)),
-					resource.TestMatchResourceAttr(terraformResourceName, "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
+					resource.TestMatchResourceAttr(terraformResourceName, "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`)),
 					resource.TestCheckResourceAttr(terraformResourceName, "http_package_configurations.0.path", "/"),
 					resource.TestCheckResourceAttr(terraformResourceName, "http_package_configurations.0.source_group", "default"),
 					resource.TestCheckResourceAttr(terraformResourceName, "http_package_configurations.0.type", "HLS"),
-					resource.TestMatchResourceAttr(terraformResourceName, "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
-					resource.TestCheckResourceAttr(terraformResourceName, "name", "live_source_example"),
-					resource.TestCheckResourceAttr(terraformResourceName, "source_location_name", "test_source_location"),
+					resource.TestMatchResourceAttr(terraformResourceName, "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`)),
+					resource.TestCheckResourceAttr(terraformResourceName, "name", "tf-acc-live-source-example"),
+					resource.TestCheckResourceAttr(terraformResourceName, "source_location_name", "tf-acc-source-location"),
 					resource.TestCheckResourceAttr(terraformResourceName, "tags.Environment", "dev"),
 					resource.TestCheckResourceAttr(terraformResourceName, "tags.Testing", "pass"),
 				),
@@ -41,15 +41,15 @@ func TestAccLiveSourceResourceBasic(t *testing.T) {
 			{
 				Config: basicLiveSourceWithSourceLocation(name, path2, k3, v3, k2, v2),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr(terraformResourceName, "id", "test_source_location,live_source_example"),
+					resource.TestCheckResourceAttr(terraformResourceName, "id", "tf-acc-source-location,tf-acc-live-source-example"),
 					resource.TestMatchResourceAttr(terraformResourceName, "arn", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:liveSource\/.*$`)),
-					resource.TestMatchResourceAttr(terraformResourceName, "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
+					resource.TestMatchResourceAttr(terraformResourceName, "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`)),
 					resource.TestCheckResourceAttr(terraformResourceName, "http_package_configurations.0.path", "/test"),
 					resource.TestCheckResourceAttr(terraformResourceName, "http_package_configurations.0.source_group", "default"),
 					resource.TestCheckResourceAttr(terraformResourceName, "http_package_configurations.0.type", "HLS"),
-					resource.TestMatchResourceAttr(terraformResourceName, "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
-					resource.TestCheckResourceAttr(terraformResourceName, "name", "live_source_example"),
-					resource.TestCheckResourceAttr(terraformResourceName, "source_location_name", "test_source_location"),
+					resource.TestMatchResourceAttr(terraformResourceName, "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`)),
+					resource.TestCheckResourceAttr(terraformResourceName, "name", "tf-acc-live-source-example"),
+					resource.TestCheckResourceAttr(terraformResourceName, "source_location_name", "tf-acc-source-location"),
 					resource.TestCheckResourceAttr(terraformResourceName, "tags.Environment", "prod"),
 					resource.TestCheckResourceAttr(terraformResourceName, "tags.Testing", "pass"),
 				),
@@ -59,7 +59,7 @@ func TestAccLiveSourceResourceBasic(t *testing.T) {
 }
 
 func TestAccLiveSourceResourceCreationFailure(t *testing.T) {
-	name := "live_source_failing"
+	name := "tf-acc-live-source-failing"
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
@@ -96,7 +96,7 @@ func basicLiveSourceWithSourceLocation(name, path, k1, v1, k2, v2 string) string
 			value = data.awsmt_live_source.data_test
 		}
 		resource "awsmt_source_location" "test_source_location" {
-			name = "test_source_location"
+			name = "tf-acc-source-location"
 			http_configuration = {
 				base_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com/"
 			}
@@ -115,7 +115,7 @@ func liveSourceWithoutSourceLocation(name string) string {
 				source_group = "default"
 				type = "HLS"
 			}]
-			source_location_name = "unexisting_source_location"
+			source_location_name = "tf-acc-unexisting-source-location"
 			name = "%[1]s"
 		}`, name)
 }
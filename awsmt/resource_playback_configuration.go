@@ -5,7 +5,6 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
@@ -18,9 +17,12 @@ import (
 )
 
 var (
-	_ resource.Resource                = &resourcePlaybackConfiguration{}
-	_ resource.ResourceWithConfigure   = &resourcePlaybackConfiguration{}
-	_ resource.ResourceWithImportState = &resourcePlaybackConfiguration{}
+	_ resource.Resource                 = &resourcePlaybackConfiguration{}
+	_ resource.ResourceWithConfigure    = &resourcePlaybackConfiguration{}
+	_ resource.ResourceWithImportState  = &resourcePlaybackConfiguration{}
+	_ resource.ResourceWithModifyPlan   = &resourcePlaybackConfiguration{}
+	_ resource.ResourceWithIdentity     = &resourcePlaybackConfiguration{}
+	_ resource.ResourceWithUpgradeState = &resourcePlaybackConfiguration{}
 )
 
 func ResourcePlaybackConfiguration() resource.Resource {
@@ -28,7 +30,9 @@ func ResourcePlaybackConfiguration() resource.Resource {
 }
 
 type resourcePlaybackConfiguration struct {
-	client *mediatailor.Client
+	client mediaTailorClient
+	region string
+	tags   tagsConfig
 }
 
 func (r *resourcePlaybackConfiguration) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -37,6 +41,7 @@ func (r *resourcePlaybackConfiguration) Metadata(_ context.Context, req resource
 
 func (r *resourcePlaybackConfiguration) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"id":                     computedStringWithStateForUnknown,
 			"ad_decision_server_url": requiredString,
@@ -96,7 +101,15 @@ func (r *resourcePlaybackConfiguration) Schema(_ context.Context, _ resource.Sch
 					},
 				},
 			},
-			"hls_configuration_manifest_endpoint_prefix": computedStringWithStateForUnknown,
+			"hls_configuration": schema.SingleNestedAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"manifest_endpoint_prefix": computedString,
+				},
+			},
 			"log_configuration_percent_enabled": schema.Int64Attribute{
 				Optional: true,
 				Validators: []validator.Int64{
@@ -145,18 +158,30 @@ func (r *resourcePlaybackConfiguration) Schema(_ context.Context, _ resource.Sch
 			"session_initialization_endpoint_prefix": computedStringWithStateForUnknown,
 			"slate_ad_url":                           optionalString,
 			"tags":                                   optionalMap,
+			"tags_all":                               computedMap,
 			"transcode_profile_name":                 optionalString,
 			"video_content_source_url":               requiredString,
 		},
 	}
 }
 
+func (r *resourcePlaybackConfiguration) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = nameIdentitySchema
+}
+
 func (r *resourcePlaybackConfiguration) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	r.client = req.ProviderData.(*mediatailor.Client)
+	data := req.ProviderData.(*providerData)
+	r.client = data.client
+	r.region = data.region
+	r.tags = data.tags
+}
+
+func (r *resourcePlaybackConfiguration) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planTagsAll(ctx, r.tags, req, resp)
 }
 
 func (r *resourcePlaybackConfiguration) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -188,13 +213,17 @@ func (r *resourcePlaybackConfiguration) Create(ctx context.Context, req resource
         return
     }
 
+    tags := plan.Tags
     m := putPlaybackConfigurationModelbuilder{
         model:      &plan,
         output:     mediatailor.PutPlaybackConfigurationOutput(*finalPlaybackConfiguration),
         isResource: true,
     }
+    model := m.getModel()
+    model.Tags, model.TagsAll = r.tags.readTags(finalPlaybackConfiguration.Tags, tags)
 
-    resp.Diagnostics.Append(resp.State.Set(ctx, m.getModel())...)
+    resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, model.PlaybackConfigurationArn)...)
+    resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
     if resp.Diagnostics.HasError() {
         return
     }
@@ -220,10 +249,15 @@ func (r *resourcePlaybackConfiguration) Read(ctx context.Context, req resource.R
 		return
 	}
 
+	tags := state.Tags
 	m := putPlaybackConfigurationModelbuilder{model: &state, output: mediatailor.PutPlaybackConfigurationOutput(*playbackConfiguration), isResource: true}
+	model := m.getModel()
+	model.Tags, model.TagsAll = r.tags.readTags(playbackConfiguration.Tags, tags)
+
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, model.PlaybackConfigurationArn)...)
 
 	// Set refreshed state
-	resp.Diagnostics.Append(resp.State.Set(ctx, m.getModel())...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -256,7 +290,7 @@ func (r *resourcePlaybackConfiguration) Update(ctx context.Context, req resource
 	// the PutPlaybackConfiguration method to add and update tags. We use this approach for every resource in the provider.
 	// Consequences: The Update function logic is now more complicated, but tag removal is supported.
 
-	err = UpdatesTags(r.client, playbackConfiguration.Tags, plan.Tags, *playbackConfiguration.PlaybackConfigurationArn)
+	err = UpdatesTags(r.client, r.tags.withoutIgnored(playbackConfiguration.Tags), tagsAllToMap(plan.TagsAll), *playbackConfiguration.PlaybackConfigurationArn)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error while updating playback configuration tags "+err.Error(),
@@ -287,13 +321,16 @@ func (r *resourcePlaybackConfiguration) Update(ctx context.Context, req resource
         return
     }
 
+    tags := plan.Tags
     m := putPlaybackConfigurationModelbuilder{
         model:      &plan,
         output:     mediatailor.PutPlaybackConfigurationOutput(*finalPlaybackConfiguration),
         isResource: true,
     }
+    model := m.getModel()
+    model.Tags, model.TagsAll = r.tags.readTags(finalPlaybackConfiguration.Tags, tags)
 
-    resp.Diagnostics.Append(resp.State.Set(ctx, m.getModel())...)
+    resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
     if resp.Diagnostics.HasError() {
         return
     }
@@ -318,6 +355,24 @@ func (r *resourcePlaybackConfiguration) Delete(ctx context.Context, req resource
 
 }
 
+func (r *resourcePlaybackConfiguration) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(ctx, r, upgradePlaybackConfigurationHlsConfiguration)
+}
+
+// upgradePlaybackConfigurationHlsConfiguration upgrades version 0 of the schema, whose hls configuration was flattened
+// into the hls_configuration_manifest_endpoint_prefix attribute, to the nested hls_configuration attribute.
+func upgradePlaybackConfigurationHlsConfiguration(state map[string]any) error {
+	prefix, ok := state["hls_configuration_manifest_endpoint_prefix"]
+	if !ok {
+		return nil
+	}
+	delete(state, "hls_configuration_manifest_endpoint_prefix")
+	if prefix != nil {
+		state["hls_configuration"] = map[string]any{"manifest_endpoint_prefix": prefix}
+	}
+	return nil
+}
+
 func (r *resourcePlaybackConfiguration) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	importStateByName(ctx, arnResourcePlaybackConfiguration, r.region, req, resp)
 }
@@ -3,6 +3,7 @@ package awsmt
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"regexp"
 	"testing"
@@ -10,7 +11,7 @@ import (
 
 func TestAccPlaybackConfigurationMinimal(t *testing.T) {
 	resourceName := "awsmt_playback_configuration.r2"
-	name := "test-acc-playback-configuration-minimal"
+	name := "tf-acc-playback-configuration-minimal"
 	adUrl := "https://www.foo.de/"
 	videoSourceUrl := "https://www.bar.at"
 	adUrl2 := "https://www.biz.ch"
@@ -43,7 +44,7 @@ func TestAccPlaybackConfigurationMinimal(t *testing.T) {
 
 func TestAccPlaybackConfigurationConfigurationAliases(t *testing.T) {
 	resourceName := "awsmt_playback_configuration.r4"
-	name := "test-acc-playback-configuration-minimal"
+	name := "tf-acc-playback-configuration-minimal"
 	adUrl := "https://www.foo.de/"
 	videoSourceUrl := "https://www.bar.at"
 	aliases := map[string]map[string]string{
@@ -92,7 +93,7 @@ func TestAccPlaybackConfigurationConfigurationAliases(t *testing.T) {
 
 func TestAccPlaybackConfigurationLogPercentage(t *testing.T) {
 	resourceName := "awsmt_playback_configuration.r3"
-	name := "test-acc-playback-configuration-log-percentage"
+	name := "tf-acc-playback-configuration-log-percentage"
 	adUrl := "https://www.foo.de/"
 	videoSourceUrl := "https://www.bar.at"
 	p1 := 5
@@ -123,7 +124,7 @@ func TestAccPlaybackConfigurationLogPercentage(t *testing.T) {
 
 func TestAccPlaybackConfigurationLoggingStrategies(t *testing.T) {
 	resourceName := "awsmt_playback_configuration.r3"
-	name := "test-acc-playback-configuration-enabled-logging-strategies"
+	name := "tf-acc-playback-configuration-enabled-logging-strategies"
 	adUrl := "https://www.foo.de/"
 	videoSourceUrl := "https://www.bar.at"
 	p1 := `["LEGACY_CLOUDWATCH"]`
@@ -156,7 +157,7 @@ func TestAccPlaybackConfigurationLoggingStrategies(t *testing.T) {
 }
 
 func TestAccPlaybackConfigurationCreationFail(t *testing.T) {
-	name := "test-acc-playback-configuration-delete"
+	name := "tf-acc-playback-configuration-delete"
 	adUrl := "invalid"
 	videoSourceUrl := "https://www.bar.at"
 	resource.Test(t, resource.TestCase{
@@ -173,7 +174,7 @@ func TestAccPlaybackConfigurationCreationFail(t *testing.T) {
 
 func TestAccPlaybackConfigurationUpdateFail(t *testing.T) {
 	resourceName := "awsmt_playback_configuration.r2"
-	name := "test-acc-playback-configuration-delete"
+	name := "tf-acc-playback-configuration-delete"
 	adUrl := "https://www.buzz.com"
 	adUrl2 := "invalid"
 	videoSourceUrl := "https://www.bar.at"
@@ -198,7 +199,7 @@ func TestAccPlaybackConfigurationUpdateFail(t *testing.T) {
 }
 
 func TestAccPlaybackConfigurationResource(t *testing.T) {
-	name := "example-playback-configuration-awsmt"
+	name := "tf-acc-playback-configuration"
 	adUrl := "https://exampleurl.com/"
 	adUrl2 := "https://exampleurl2.com/"
 	bumperE := "https://wxample.com/endbumper"
@@ -255,7 +256,7 @@ func TestAccPlaybackConfigurationResource(t *testing.T) {
 			{
 				Config: completePlaybackConfiguration(name, adUrl2, bumperE2, bumperS2, cdnUrl2, maxD2, pS2, k3, v3, k2, v2),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("awsmt_playback_configuration.r1", "name", "example-playback-configuration-awsmt"),
+					resource.TestCheckResourceAttr("awsmt_playback_configuration.r1", "name", "tf-acc-playback-configuration"),
 					resource.TestCheckResourceAttr("awsmt_playback_configuration.r1", "personalization_threshold_seconds", "3"),
 					resource.TestCheckResourceAttr("awsmt_playback_configuration.r1", "ad_decision_server_url", "https://exampleurl2.com/"),
 					resource.TestCheckResourceAttr("awsmt_playback_configuration.r1", "bumper.end_url", "https://wxample.com/endbumper2"),
@@ -357,4 +358,35 @@ func completePlaybackConfiguration(name, adUrl, bumperE, bumperS, cdnUrl, maxD,
 		}
 		`, name, adUrl, bumperE, bumperS, cdnUrl, maxD, pS, k1, v1, k2, v2,
 	)
-}
\ No newline at end of file
+}
+func TestUpgradePlaybackConfigurationStateV0(t *testing.T) {
+	upgraded := upgradeResourceState(t, ResourcePlaybackConfiguration(), 0, `{
+		"id": "test",
+		"name": "test",
+		"ad_decision_server_url": "https://example.com/ads",
+		"video_content_source_url": "https://example.com/content",
+		"hls_configuration_manifest_endpoint_prefix": "https://example.com/v1/master/test/"
+	}`)
+
+	var state map[string]tftypes.Value
+	if err := upgraded.As(&state); err != nil {
+		t.Fatal(err)
+	}
+	var hlsConfiguration map[string]tftypes.Value
+	if err := state["hls_configuration"].As(&hlsConfiguration); err != nil {
+		t.Fatal(err)
+	}
+	var prefix string
+	_ = hlsConfiguration["manifest_endpoint_prefix"].As(&prefix)
+	if prefix != "https://example.com/v1/master/test/" {
+		t.Errorf("expected the manifest endpoint prefix to be moved to hls_configuration, got %v", state["hls_configuration"])
+	}
+
+	upgraded = upgradeResourceState(t, ResourcePlaybackConfiguration(), 0, `{"id": "test", "hls_configuration_manifest_endpoint_prefix": null}`)
+	if err := upgraded.As(&state); err != nil {
+		t.Fatal(err)
+	}
+	if !state["hls_configuration"].IsNull() {
+		t.Errorf("expected a null hls_configuration, got %v", state["hls_configuration"])
+	}
+}
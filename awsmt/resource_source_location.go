@@ -4,18 +4,19 @@ import (
 	"context"
 	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
-	"strings"
 	"terraform-provider-mediatailor/awsmt/models"
 )
 
 var (
-	_ resource.Resource                = &resourceSourceLocation{}
-	_ resource.ResourceWithConfigure   = &resourceSourceLocation{}
-	_ resource.ResourceWithImportState = &resourceSourceLocation{}
+	_ resource.Resource                 = &resourceSourceLocation{}
+	_ resource.ResourceWithConfigure    = &resourceSourceLocation{}
+	_ resource.ResourceWithImportState  = &resourceSourceLocation{}
+	_ resource.ResourceWithModifyPlan   = &resourceSourceLocation{}
+	_ resource.ResourceWithIdentity     = &resourceSourceLocation{}
+	_ resource.ResourceWithUpgradeState = &resourceSourceLocation{}
 )
 
 func ResourceSourceLocation() resource.Resource {
@@ -23,7 +24,9 @@ func ResourceSourceLocation() resource.Resource {
 }
 
 type resourceSourceLocation struct {
-	client *mediatailor.Client
+	client mediaTailorClient
+	region string
+	tags   tagsConfig
 }
 
 func (r *resourceSourceLocation) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -32,6 +35,7 @@ func (r *resourceSourceLocation) Metadata(_ context.Context, req resource.Metada
 
 func (r *resourceSourceLocation) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"id": computedString,
 			"access_configuration": schema.SingleNestedAttribute{
@@ -54,7 +58,7 @@ func (r *resourceSourceLocation) Schema(_ context.Context, _ resource.SchemaRequ
 				},
 			},
 			"arn":           computedString,
-			"creation_time": computedString,
+			"creation_time": computedRFC3339,
 			"default_segment_delivery_configuration": schema.SingleNestedAttribute{
 				Optional: true,
 				Attributes: map[string]schema.Attribute{
@@ -67,7 +71,7 @@ func (r *resourceSourceLocation) Schema(_ context.Context, _ resource.SchemaRequ
 					"base_url": requiredString,
 				},
 			},
-			"last_modified_time": computedString,
+			"last_modified_time": computedRFC3339,
 			"segment_delivery_configurations": schema.ListNestedAttribute{
 				Optional: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -77,18 +81,30 @@ func (r *resourceSourceLocation) Schema(_ context.Context, _ resource.SchemaRequ
 					},
 				},
 			},
-			"name": requiredStringWithRequiresReplace,
-			"tags": optionalMap,
+			"name":     requiredStringWithRequiresReplace,
+			"tags":     optionalMap,
+			"tags_all": computedMap,
 		},
 	}
 }
 
+func (r *resourceSourceLocation) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = nameIdentitySchema
+}
+
 func (r *resourceSourceLocation) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	r.client = req.ProviderData.(*mediatailor.Client)
+	data := req.ProviderData.(*providerData)
+	r.client = data.client
+	r.region = data.region
+	r.tags = data.tags
+}
+
+func (r *resourceSourceLocation) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planTagsAll(ctx, r.tags, req, resp)
 }
 
 func (r *resourceSourceLocation) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -109,8 +125,11 @@ func (r *resourceSourceLocation) Create(ctx context.Context, req resource.Create
 		return
 	}
 
+	tags := plan.Tags
 	plan = writeSourceLocationToPlan(plan, *sourceLocation)
+	plan.Tags, plan.TagsAll = r.tags.readTags(sourceLocation.Tags, tags)
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, plan.Arn)...)
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -134,8 +153,11 @@ func (r *resourceSourceLocation) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
+	tags := state.Tags
 	state = writeSourceLocationToPlan(state, mediatailor.CreateSourceLocationOutput(*sourceLocation))
+	state.Tags, state.TagsAll = r.tags.readTags(sourceLocation.Tags, tags)
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, state.Arn)...)
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -164,7 +186,7 @@ func (r *resourceSourceLocation) Update(ctx context.Context, req resource.Update
 		return
 	}
 
-	err = UpdatesTags(r.client, sourceLocation.Tags, plan.Tags, *sourceLocation.Arn)
+	err = UpdatesTags(r.client, r.tags.withoutIgnored(sourceLocation.Tags), tagsAllToMap(plan.TagsAll), *sourceLocation.Arn)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error while updating playback configuration tags"+err.Error(),
@@ -172,14 +194,16 @@ func (r *resourceSourceLocation) Update(ctx context.Context, req resource.Update
 		)
 		return
 	}
+	tags := plan.Tags
+	var remoteTags map[string]string
 	if !currentState.AccessConfiguration.Equal(plan.AccessConfiguration) {
-		updatedSourceLocation, err := recreateSourceLocation(r.client, plan)
+		recreatedSourceLocation, err := recreateSourceLocation(r.client, plan)
 		if err != nil {
 			resp.Diagnostics.AddError("Error while recreating source location "+err.Error(), err.Error())
 			return
 		}
-		plan = *updatedSourceLocation
-
+		plan = writeSourceLocationToPlan(plan, *recreatedSourceLocation)
+		remoteTags = recreatedSourceLocation.Tags
 	} else {
 		params := getUpdateSourceLocationInput(plan)
 		sourceLocationUpdated, err := r.client.UpdateSourceLocation(ctx, &params)
@@ -191,7 +215,9 @@ func (r *resourceSourceLocation) Update(ctx context.Context, req resource.Update
 			return
 		}
 		plan = writeSourceLocationToPlan(plan, mediatailor.CreateSourceLocationOutput(*sourceLocationUpdated))
+		remoteTags = sourceLocationUpdated.Tags
 	}
+	plan.Tags, plan.TagsAll = r.tags.readTags(remoteTags, tags)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 	if resp.Diagnostics.HasError() {
@@ -218,30 +244,10 @@ func (r *resourceSourceLocation) Delete(ctx context.Context, req resource.Delete
 	}
 }
 
-func (r *resourceSourceLocation) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Split the import ID to support various import formats
-	idParts := strings.Split(req.ID, "/")
-
-	if len(idParts) == 1 {
-		resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
-		return
-	}
-
-	// Support ARN import format
-	if strings.HasPrefix(req.ID, "arn:aws:mediatailor:") {
-		arnParts := strings.Split(req.ID, ":")
-		if len(arnParts) >= 6 {
-			resourcePath := arnParts[5]
-			resourceParts := strings.Split(resourcePath, "/")
-			if len(resourceParts) >= 2 && resourceParts[0] == "sourceLocation" {
-				resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), resourceParts[1])...)
-				return
-			}
-		}
-	}
+func (r *resourceSourceLocation) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(ctx, r, upgradeTimestampsToRFC3339)
+}
 
-	resp.Diagnostics.AddError(
-		"Invalid import ID",
-		"Expected import ID to be either the source location name or the full ARN",
-	)
+func (r *resourceSourceLocation) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateByName(ctx, arnResourceSourceLocation, r.region, req, resp)
 }
@@ -9,7 +9,7 @@ import (
 
 func TestAccSourceLocationResourceMinimal(t *testing.T) {
 	resourceName := "awsmt_source_location.test_source_location"
-	name := "minimalSourceLocation"
+	name := "tf-acc-minimal-source-location"
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
@@ -27,7 +27,7 @@ func TestAccSourceLocationResourceMinimal(t *testing.T) {
 
 func TestAccSourceLocationResourceBasic(t *testing.T) {
 	resourceName := "awsmt_source_location.test_source_location"
-	name := "test_source_location"
+	name := "tf-acc-source-location"
 	baseUrl := "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"
 	baseUrl2 := "https://example.com/"
 	k1 := "Environment"
@@ -44,14 +44,14 @@ func TestAccSourceLocationResourceBasic(t *testing.T) {
 			{
 				Config: basicSourceLocation(name, baseUrl, k1, v1, k2, v2),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "id", "test_source_location"),
+					resource.TestCheckResourceAttr(resourceName, "id", "tf-acc-source-location"),
 					resource.TestMatchResourceAttr(resourceName, "arn", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:sourceLocation\/.*$`)),
-					resource.TestMatchResourceAttr(resourceName, "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
+					resource.TestMatchResourceAttr(resourceName, "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`)),
 					resource.TestCheckResourceAttr(resourceName, "default_segment_delivery_configuration.base_url", "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"),
 					resource.TestCheckResourceAttr(resourceName, "http_configuration.base_url", "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"),
-					resource.TestMatchResourceAttr(resourceName, "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
+					resource.TestMatchResourceAttr(resourceName, "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`)),
 					resource.TestCheckResourceAttr(resourceName, "segment_delivery_configurations.0.base_url", "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"),
-					resource.TestCheckResourceAttr(resourceName, "name", "test_source_location"),
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-acc-source-location"),
 					resource.TestCheckResourceAttr(resourceName, "tags.Testing", "pass"),
 					resource.TestCheckResourceAttr(resourceName, "tags.Environment", "dev"),
 				),
@@ -65,13 +65,13 @@ func TestAccSourceLocationResourceBasic(t *testing.T) {
 			{
 				Config: basicSourceLocation(name, baseUrl2, k3, v3, k2, v2),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "id", "test_source_location"),
+					resource.TestCheckResourceAttr(resourceName, "id", "tf-acc-source-location"),
 					resource.TestMatchResourceAttr(resourceName, "arn", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:sourceLocation\/.*$`)),
-					resource.TestMatchResourceAttr(resourceName, "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
+					resource.TestMatchResourceAttr(resourceName, "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`)),
 					resource.TestCheckResourceAttr(resourceName, "default_segment_delivery_configuration.base_url", baseUrl2),
-					resource.TestMatchResourceAttr(resourceName, "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
+					resource.TestMatchResourceAttr(resourceName, "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`)),
 					resource.TestCheckResourceAttr(resourceName, "segment_delivery_configurations.0.base_url", baseUrl2),
-					resource.TestCheckResourceAttr(resourceName, "name", "test_source_location"),
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-acc-source-location"),
 					resource.TestCheckResourceAttr(resourceName, "tags.Testing", "pass"),
 					resource.TestCheckResourceAttr(resourceName, "tags.Environment", "prod"),
 				),
@@ -83,7 +83,7 @@ func TestAccSourceLocationResourceBasic(t *testing.T) {
 
 func TestAccSourceLocationResourceUpdateAccessControl(t *testing.T) {
 	resourceName := "awsmt_source_location.test_source_location"
-	name := "test_source_location"
+	name := "tf-acc-source-location"
 	baseUrl := "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"
 	baseUrl2 := "https://example.com/"
 	k1 := "Environment"
@@ -100,14 +100,14 @@ func TestAccSourceLocationResourceUpdateAccessControl(t *testing.T) {
 			{
 				Config: basicSourceLocation(name, baseUrl, k1, v1, k2, v2),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "id", "test_source_location"),
+					resource.TestCheckResourceAttr(resourceName, "id", "tf-acc-source-location"),
 					resource.TestMatchResourceAttr(resourceName, "arn", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:sourceLocation\/.*$`)),
-					resource.TestMatchResourceAttr(resourceName, "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
+					resource.TestMatchResourceAttr(resourceName, "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`)),
 					resource.TestCheckResourceAttr(resourceName, "default_segment_delivery_configuration.base_url", "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"),
 					resource.TestCheckResourceAttr(resourceName, "http_configuration.base_url", "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"),
-					resource.TestMatchResourceAttr(resourceName, "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
+					resource.TestMatchResourceAttr(resourceName, "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`)),
 					resource.TestCheckResourceAttr(resourceName, "segment_delivery_configurations.0.base_url", "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"),
-					resource.TestCheckResourceAttr(resourceName, "name", "test_source_location"),
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-acc-source-location"),
 					resource.TestCheckResourceAttr(resourceName, "tags.Testing", "pass"),
 					resource.TestCheckResourceAttr(resourceName, "tags.Environment", "dev"),
 				),
@@ -122,8 +122,8 @@ func TestAccSourceLocationResourceUpdateAccessControl(t *testing.T) {
 			{
 				Config: basicSourceLocationWithAccessConfig(name, baseUrl2, k3, v3, k2, v2),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "id", "test_source_location"),
-					resource.TestCheckResourceAttr(resourceName, "name", "test_source_location"),
+					resource.TestCheckResourceAttr(resourceName, "id", "tf-acc-source-location"),
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-acc-source-location"),
 					resource.TestCheckResourceAttr(resourceName, "access_configuration.access_type", "S3_SIGV4"),
 					resource.TestCheckResourceAttr(resourceName, "http_configuration.base_url", "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"),
 					resource.TestCheckResourceAttr(resourceName, "default_segment_delivery_configuration.base_url", "https://example.com/"),
@@ -177,8 +177,37 @@ func TestAccSourceLocationDeleteVodResource(t *testing.T) {
 			{
 				Config: basicSourceLocationWithVodSource(),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "id", "test_source_location"),
-					resource.TestCheckResourceAttr(resourceName, "name", "test_source_location"),
+					resource.TestCheckResourceAttr(resourceName, "id", "tf-acc-source-location"),
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-acc-source-location"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSourceLocationResourceDefaultTags(t *testing.T) {
+	resourceName := "awsmt_source_location.test_source_location"
+	name := "tf-acc-source-location-default-tags"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: sourceLocationWithDefaultTags(name, "dev"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.Environment", "dev"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.CostCenter", "ott"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.Environment", "dev"),
+				),
+			},
+			{
+				Config: sourceLocationWithDefaultTags(name, "prod"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tags.Environment", "prod"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.CostCenter", "ott"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.Environment", "prod"),
 				),
 			},
 		},
@@ -266,7 +295,7 @@ func basicSourceLocationWithAccessConfig(name, baseUrl, k1, v1, k2, v2 string) s
 func basicSourceLocationWithVodSource() string {
 	return `
 		resource "awsmt_source_location" "test_source_location"{
-			name = "test_source_location"
+			name = "tf-acc-source-location"
 			http_configuration = {
 				base_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"
 			}
@@ -293,7 +322,7 @@ func basicSourceLocationWithVodSource() string {
 				type = "HLS"
 			}]
 			source_location_name = awsmt_source_location.test_source_location.name
-			name = "vod_source_example"
+			name = "tf-acc-vod-source-example"
 		}
 `
 }
@@ -301,7 +330,7 @@ func basicSourceLocationWithVodSource() string {
 func sourceLocationWithSMATC(secretArn, secretStringKey, headerName string) string {
 	return fmt.Sprintf(`
 		resource "awsmt_source_location" "test_source_location_smatc"{
-			name = "sl-smatc"
+			name = "tf-acc-smatc"
 			http_configuration = {
 				base_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"
 			}
@@ -323,3 +352,24 @@ func sourceLocationWithSMATC(secretArn, secretStringKey, headerName string) stri
 
 `, headerName, secretArn, secretStringKey)
 }
+
+func sourceLocationWithDefaultTags(name, environment string) string {
+	return fmt.Sprintf(`
+		provider "awsmt" {
+			default_tags {
+				tags = {
+					"CostCenter": "ott"
+				}
+			}
+		}
+		resource "awsmt_source_location" "test_source_location"{
+			name = "%[1]s"
+			http_configuration = {
+				base_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com"
+			}
+			tags = {
+				"Environment": "%[2]s"
+			}
+		}
+		`, name, environment)
+}
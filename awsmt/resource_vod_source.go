@@ -13,9 +13,12 @@ import (
 )
 
 var (
-	_ resource.Resource                = &resourceVodSource{}
-	_ resource.ResourceWithConfigure   = &resourceVodSource{}
-	_ resource.ResourceWithImportState = &resourceVodSource{}
+	_ resource.Resource                 = &resourceVodSource{}
+	_ resource.ResourceWithConfigure    = &resourceVodSource{}
+	_ resource.ResourceWithImportState  = &resourceVodSource{}
+	_ resource.ResourceWithModifyPlan   = &resourceVodSource{}
+	_ resource.ResourceWithIdentity     = &resourceVodSource{}
+	_ resource.ResourceWithUpgradeState = &resourceVodSource{}
 )
 
 func ResourceVodSource() resource.Resource {
@@ -23,7 +26,11 @@ func ResourceVodSource() resource.Resource {
 }
 
 type resourceVodSource struct {
-	client *mediatailor.Client
+	client mediaTailorClient
+	// cache serves the reads of the vod sources when the read cache is enabled, it is nil otherwise
+	cache  *readCache
+	region string
+	tags   tagsConfig
 }
 
 func (r *resourceVodSource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -32,13 +39,15 @@ func (r *resourceVodSource) Metadata(_ context.Context, req resource.MetadataReq
 
 func (r *resourceVodSource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"id":                          computedStringWithStateForUnknown,
 			"source_location_name":        requiredString,
 			"http_package_configurations": httpPackageConfigurationsResourceSchema,
-			"creation_time":               computedStringWithStateForUnknown,
+			"creation_time":               computedRFC3339WithStateForUnknown,
 			"tags":                        optionalMap,
-			"last_modified_time":          computedString,
+			"tags_all":                    computedMap,
+			"last_modified_time":          computedRFC3339,
 			"arn":                         computedStringWithStateForUnknown,
 			"name":                        requiredStringWithRequiresReplace,
 			"ad_break_opportunities_offset_millis": schema.ListAttribute{
@@ -53,12 +62,24 @@ func (r *resourceVodSource) Schema(_ context.Context, _ resource.SchemaRequest,
 	}
 }
 
+func (r *resourceVodSource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = contentSourceIdentitySchema
+}
+
 func (r *resourceVodSource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	r.client = req.ProviderData.(*mediatailor.Client)
+	data := req.ProviderData.(*providerData)
+	r.client = data.client
+	r.cache = data.cache
+	r.region = data.region
+	r.tags = data.tags
+}
+
+func (r *resourceVodSource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planTagsAll(ctx, r.tags, req, resp)
 }
 
 func (r *resourceVodSource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -75,8 +96,11 @@ func (r *resourceVodSource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	tags := plan.Tags
 	plan = readVodSourceToPlan(plan, *vodSource)
+	plan.Tags, plan.TagsAll = r.tags.readTags(vodSource.Tags, tags)
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, plan.Arn)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -103,14 +127,26 @@ func (r *resourceVodSource) Read(ctx context.Context, req resource.ReadRequest,
 		SourceLocationName: &sourceLocationName,
 	}
 
-	vodSource, err := r.client.DescribeVodSource(ctx, input)
+	cache := r.cache
+	if state.AdBreakOpportunitiesOffsetMillis.IsNull() {
+		// the cache does not hold the ad break opportunities, which are described when they are not in the state yet
+		cache = nil
+	}
+	vodSource, cached, err := cache.describeVodSource(ctx, r.client, input)
 	if err != nil {
 		resp.Diagnostics.AddError("Error while describing vod source", "Could not describe the vod source: "+*input.SourceLocationName+":"+*input.VodSourceName+". "+err.Error())
 		return
 	}
 
+	tags := state.Tags
+	adBreakOpportunities := state.AdBreakOpportunitiesOffsetMillis
 	state = readVodSourceToState(state, *vodSource)
+	if cached {
+		state.AdBreakOpportunitiesOffsetMillis = adBreakOpportunities
+	}
+	state.Tags, state.TagsAll = r.tags.readTags(vodSource.Tags, tags)
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, state.Arn)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -137,7 +173,7 @@ func (r *resourceVodSource) Update(ctx context.Context, req resource.UpdateReque
 	}
 
 	// Update tags
-	err = UpdatesTags(r.client, vodSource.Tags, plan.Tags, *vodSource.Arn)
+	err = UpdatesTags(r.client, r.tags.withoutIgnored(vodSource.Tags), tagsAllToMap(plan.TagsAll), *vodSource.Arn)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error while updating vod source tags"+err.Error(),
@@ -156,7 +192,9 @@ func (r *resourceVodSource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	tags := plan.Tags
 	plan = readVodSourceToPlan(plan, mediatailor.CreateVodSourceOutput(*updatedVodSource))
+	plan.Tags, plan.TagsAll = r.tags.readTags(updatedVodSource.Tags, tags)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 	if resp.Diagnostics.HasError() {
@@ -187,6 +225,10 @@ func (r *resourceVodSource) Delete(ctx context.Context, req resource.DeleteReque
 	}
 }
 
+func (r *resourceVodSource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(ctx, r, upgradeTimestampsToRFC3339)
+}
+
 func (r *resourceVodSource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	importStateForContentSources(ctx, req, resp)
+	importStateForContentSources(ctx, arnResourceVodSource, r.region, req, resp)
 }
@@ -0,0 +1,208 @@
+package awsmt
+
+import (
+	"context"
+	"errors"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-mediatailor/awsmt/models"
+)
+
+var (
+	_ resource.Resource                 = &resourceVodSourceSet{}
+	_ resource.ResourceWithConfigure    = &resourceVodSourceSet{}
+	_ resource.ResourceWithModifyPlan   = &resourceVodSourceSet{}
+	_ resource.ResourceWithUpgradeState = &resourceVodSourceSet{}
+)
+
+func ResourceVodSourceSet() resource.Resource {
+	return &resourceVodSourceSet{}
+}
+
+type resourceVodSourceSet struct {
+	client mediaTailorClient
+	tags   tagsConfig
+}
+
+func (r *resourceVodSourceSet) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vod_source_set"
+}
+
+func (r *resourceVodSourceSet) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":                   computedStringWithStateForUnknown,
+			"source_location_name": requiredStringWithRequiresReplace,
+			"vod_sources": schema.MapNestedAttribute{
+				Optional: true,
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"http_package_configurations": httpPackageConfigurationsResourceSchema,
+					},
+				},
+			},
+			"file": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("vod_sources")),
+				},
+			},
+			"parallelism": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(10),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"tags":     optionalMap,
+			"tags_all": computedMap,
+		},
+	}
+}
+
+func (r *resourceVodSourceSet) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data := req.ProviderData.(*providerData)
+	r.client = data.client
+	r.tags = data.tags
+}
+
+// ModifyPlan plans the tags_all attribute and the vod sources of the file, so that the changes of the file show in the
+// plan.
+func (r *resourceVodSourceSet) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	planTagsAll(ctx, r.tags, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var file types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("file"), &file)...)
+	if resp.Diagnostics.HasError() || file.IsNull() || file.IsUnknown() {
+		return
+	}
+
+	vodSources, err := loadVodSourceSetFile(file.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("file"), "Error while loading the vod sources "+err.Error(), err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("vod_sources"), vodSources)...)
+}
+
+func (r *resourceVodSourceSet) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan models.VodSourceSetModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(*plan.SourceLocationName)
+	r.apply(ctx, plan, models.VodSourceSetModel{TagsAll: types.MapNull(types.StringType)}, &resp.State, &resp.Diagnostics)
+}
+
+func (r *resourceVodSourceSet) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state models.VodSourceSetModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	listed, err := listVodSourceSet(ctx, r.client, r.tags, state.SourceLocationName)
+	if err != nil {
+		resp.Diagnostics.AddError("Error while listing the vod sources of "+*state.SourceLocationName+" "+err.Error(), err.Error())
+		return
+	}
+	state.VodSources = managedVodSources(listed, state.VodSources)
+	// a vod source whose tags changed outside Terraform shows as a change of the tags of the set
+	if tags, drifted := driftedVodSourceSetTags(listed, state.VodSources, tagsAllToMap(state.TagsAll)); drifted {
+		state.Tags, state.TagsAll = r.tags.readTags(tags, state.Tags)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *resourceVodSourceSet) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state models.VodSourceSetModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, plan, state, &resp.State, &resp.Diagnostics)
+}
+
+func (r *resourceVodSourceSet) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state models.VodSourceSetModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan := state
+	plan.VodSources = nil
+	r.apply(ctx, plan, state, &resp.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *resourceVodSourceSet) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(ctx, r)
+}
+
+// apply creates, updates, tags and deletes the vod sources of the set to match the plan, and saves the vod sources of
+// the changes that succeeded to the state. The tags of the set are saved once every change succeeded, so that the vod
+// sources left with other tags are tagged again by the next apply.
+func (r *resourceVodSourceSet) apply(ctx context.Context, plan models.VodSourceSetModel, prior models.VodSourceSetModel, state *tfsdk.State, diags *diag.Diagnostics) {
+	listed, err := listVodSourceSet(ctx, r.client, r.tags, plan.SourceLocationName)
+	if err != nil {
+		diags.AddError("Error while listing the vod sources of "+*plan.SourceLocationName+" "+err.Error(), err.Error())
+		return
+	}
+
+	current := managedVodSources(listed, prior.VodSources)
+	changes, unmanaged := vodSourceSetChanges(current, listed, plan.VodSources, tagsAllToMap(plan.TagsAll))
+	for _, name := range unmanaged {
+		diags.AddError(
+			"Error while creating vod source "+name+" the vod source already exists",
+			"The vod source "+name+" of "+*plan.SourceLocationName+" already exists and is not managed by the set. Delete it, or remove it from the set.",
+		)
+	}
+	errs := applyVodSourceSetChanges(ctx, r.client, plan.SourceLocationName, changes, int(plan.Parallelism.ValueInt64()))
+	for i, change := range changes {
+		if errs[i] != nil {
+			diags.AddError("Error while "+string(change.operation)+" vod source "+change.name+" "+errs[i].Error(), errs[i].Error())
+		}
+	}
+
+	plan.VodSources = recordVodSourceSetChanges(current, changes, errs)
+	if errors.Join(errs...) == nil {
+		plan.Tags, plan.TagsAll = r.tags.readTags(tagsAllToMap(plan.TagsAll), plan.Tags)
+	} else {
+		plan.Tags, plan.TagsAll = prior.Tags, prior.TagsAll
+	}
+	diags.Append(state.Set(ctx, &plan)...)
+}
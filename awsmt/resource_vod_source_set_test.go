@@ -0,0 +1,165 @@
+package awsmt
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	sdkresource "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"maps"
+	"strings"
+	"terraform-provider-mediatailor/awsmt/models"
+	"testing"
+)
+
+func TestAccVodSourceSetResource(t *testing.T) {
+	terraformResourceName := "awsmt_vod_source_set.test"
+	file := writeVodSourceSetFile(t, "assets.csv", "name,path,source_group,type\nmovie,/movie/v2,default,HLS\nextra,/extra,default,DASH\n")
+	sdkresource.Test(t, sdkresource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []sdkresource.TestStep{
+			{
+				Config: vodSourceSet(`vod_sources = {
+					movie = { http_package_configurations = [{ path = "/movie", source_group = "default", type = "HLS" }] }
+					trailer = { http_package_configurations = [{ path = "/trailer", source_group = "default", type = "HLS" }] }
+				}
+				tags = { Team = "video" }`),
+				Check: sdkresource.ComposeAggregateTestCheckFunc(
+					sdkresource.TestCheckResourceAttr(terraformResourceName, "id", "tf-acc-source-location-set"),
+					sdkresource.TestCheckResourceAttr(terraformResourceName, "vod_sources.%", "2"),
+					sdkresource.TestCheckResourceAttr(terraformResourceName, "vod_sources.movie.http_package_configurations.0.path", "/movie"),
+					sdkresource.TestCheckResourceAttr(terraformResourceName, "parallelism", "10"),
+					sdkresource.TestCheckResourceAttr(terraformResourceName, "tags_all.Team", "video"),
+				),
+			},
+			{
+				Config: vodSourceSet(fmt.Sprintf(`file = %q`, file)),
+				Check: sdkresource.ComposeAggregateTestCheckFunc(
+					sdkresource.TestCheckResourceAttr(terraformResourceName, "vod_sources.%", "2"),
+					sdkresource.TestCheckResourceAttr(terraformResourceName, "vod_sources.movie.http_package_configurations.0.path", "/movie/v2"),
+					sdkresource.TestCheckResourceAttr(terraformResourceName, "vod_sources.extra.http_package_configurations.0.type", "DASH"),
+					sdkresource.TestCheckNoResourceAttr(terraformResourceName, "vod_sources.trailer.http_package_configurations.0.path"),
+				),
+			},
+		},
+	})
+}
+
+func TestVodSourceSetConfigValidation(t *testing.T) {
+	var schema resource.SchemaResponse
+	ResourceVodSourceSet().Schema(context.Background(), resource.SchemaRequest{}, &schema)
+	vodSourcesType := schema.Schema.Type().TerraformType(context.Background()).(tftypes.Object).AttributeTypes["vod_sources"]
+
+	for name, tc := range map[string]struct {
+		attributes map[string]tftypes.Value
+		err        string
+	}{
+		"vod sources": {
+			attributes: map[string]tftypes.Value{"vod_sources": tftypes.NewValue(vodSourcesType, map[string]tftypes.Value{})},
+		},
+		"file": {
+			attributes: map[string]tftypes.Value{"file": tftypes.NewValue(tftypes.String, "assets.json")},
+		},
+		"vod sources and file": {
+			attributes: map[string]tftypes.Value{
+				"vod_sources": tftypes.NewValue(vodSourcesType, map[string]tftypes.Value{}),
+				"file":        tftypes.NewValue(tftypes.String, "assets.json"),
+			},
+			err: "2 attributes specified when one (and only one)",
+		},
+		"neither": {
+			attributes: map[string]tftypes.Value{},
+			err:        "No attribute specified",
+		},
+		"parallelism": {
+			attributes: map[string]tftypes.Value{
+				"file":        tftypes.NewValue(tftypes.String, "assets.json"),
+				"parallelism": tftypes.NewValue(tftypes.Number, 0),
+			},
+			err: "must be at least 1",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tc.attributes["source_location_name"] = tftypes.NewValue(tftypes.String, "test")
+			var errs []string
+			for _, diag := range validateResourceConfig(t, ResourceVodSourceSet(), tc.attributes) {
+				if diag.Severity == tfprotov6.DiagnosticSeverityError {
+					errs = append(errs, diag.Summary+": "+diag.Detail)
+				}
+			}
+			if tc.err == "" && len(errs) > 0 {
+				t.Errorf("expected no error, got %v", errs)
+			}
+			if tc.err != "" && (len(errs) != 1 || !strings.Contains(errs[0], tc.err)) {
+				t.Errorf("expected one error containing %q, got %v", tc.err, errs)
+			}
+		})
+	}
+}
+
+func TestVodSourceSetTags(t *testing.T) {
+	client := newListTestClient(t)
+	providerType := providerConfigType()
+	server := configureTestServer(t, client, objectValue(providerType, map[string]tftypes.Value{
+		"default_tags": objectValue(providerType.AttributeTypes["default_tags"].(tftypes.Object), map[string]tftypes.Value{
+			"tags": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{"CostCenter": tftypes.NewValue(tftypes.String, "ott")}),
+		}),
+	}))
+
+	r := ResourceVodSourceSet()
+	configType := resourceConfigType(r)
+	vodSourcesType := configType.AttributeTypes["vod_sources"].(tftypes.Map)
+	packageConfigurationsType := vodSourcesType.ElementType.(tftypes.Object).AttributeTypes["http_package_configurations"].(tftypes.List)
+	planned, applied := createResource(t, server, r, objectValue(configType, map[string]tftypes.Value{
+		"source_location_name": tftypes.NewValue(tftypes.String, "test_a"),
+		"vod_sources": tftypes.NewValue(vodSourcesType, map[string]tftypes.Value{
+			"movie": objectValue(vodSourcesType.ElementType.(tftypes.Object), map[string]tftypes.Value{
+				"http_package_configurations": tftypes.NewValue(packageConfigurationsType, []tftypes.Value{
+					objectValue(packageConfigurationsType.ElementType.(tftypes.Object), map[string]tftypes.Value{
+						"path":         tftypes.NewValue(tftypes.String, "/movie"),
+						"source_group": tftypes.NewValue(tftypes.String, "default"),
+						"type":         tftypes.NewValue(tftypes.String, "HLS"),
+					}),
+				}),
+			}),
+		}),
+		"tags": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{"Team": tftypes.NewValue(tftypes.String, "video")}),
+	}))
+
+	for _, name := range []string{"tags", "tags_all", "vod_sources"} {
+		if !planned[name].IsFullyKnown() || !planned[name].Equal(applied[name]) {
+			t.Errorf("expected the applied %s to be %s, got %s", name, planned[name], applied[name])
+		}
+	}
+	listed, err := listVodSourceSet(context.Background(), client, tagsConfig{}, aws.String("test_a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tags := listed["movie"].tags; !maps.Equal(tags, map[string]string{"CostCenter": "ott", "Team": "video"}) {
+		t.Errorf("expected the vod source to have the tags of the set and the default tags, got %v", tags)
+	}
+	if tags := listed["vod"].tags; len(tags) != 0 {
+		t.Errorf("expected the vod source missing from the set to be left untouched, got %v", tags)
+	}
+
+	if tags, drifted := driftedVodSourceSetTags(listed, map[string]models.VodSourceSetItemModel{"movie": {}}, map[string]string{"CostCenter": "ott"}); !drifted || tags["Team"] != "video" {
+		t.Errorf("expected the tags of the vod source to be reported as drifted, got %v", tags)
+	}
+}
+
+func vodSourceSet(vodSources string) string {
+	return fmt.Sprintf(`
+		resource "awsmt_source_location" "test" {
+			name = "tf-acc-source-location-set"
+			http_configuration = {
+				base_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com/"
+			}
+		}
+		resource "awsmt_vod_source_set" "test" {
+			source_location_name = awsmt_source_location.test.name
+			%[1]s
+		}`, vodSources)
+}
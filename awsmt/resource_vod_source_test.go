@@ -2,6 +2,7 @@ package awsmt
 
 import (
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"regexp"
 	"testing"
@@ -9,7 +10,7 @@ import (
 
 func TestAccVodSourceResourceBasic(t *testing.T) {
 	terraformResourceName := "awsmt_vod_source.vod_source_acc_test"
-	name := "vod_source_example"
+	name := "tf-acc-vod-source-example"
 	path := "/"
 	path2 := "/test"
 	k1 := "Environment"
@@ -25,30 +26,43 @@ func TestAccVodSourceResourceBasic(t *testing.T) {
 			{
 				Config: basicVodSourceWithSourceLocation(name, path, k1, v1, k2, v2),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr(terraformResourceName, "id", "test_source_location,vod_source_example"),
+					resource.TestCheckResourceAttr(terraformResourceName, "id", "tf-acc-source-location,tf-acc-vod-source-example"),
 					resource.TestMatchResourceAttr(terraformResourceName, "arn", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:vodSource\/.*$`)),
-					resource.TestMatchResourceAttr(terraformResourceName, "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
+					resource.TestMatchResourceAttr(terraformResourceName, "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`)),
 					resource.TestCheckResourceAttr(terraformResourceName, "http_package_configurations.0.path", "/"),
 					resource.TestCheckResourceAttr(terraformResourceName, "http_package_configurations.0.source_group", "default"),
 					resource.TestCheckResourceAttr(terraformResourceName, "http_package_configurations.0.type", "HLS"),
-					resource.TestMatchResourceAttr(terraformResourceName, "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
-					resource.TestCheckResourceAttr(terraformResourceName, "name", "vod_source_example"),
-					resource.TestCheckResourceAttr(terraformResourceName, "source_location_name", "test_source_location"),
+					resource.TestMatchResourceAttr(terraformResourceName, "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`)),
+					resource.TestCheckResourceAttr(terraformResourceName, "name", "tf-acc-vod-source-example"),
+					resource.TestCheckResourceAttr(terraformResourceName, "source_location_name", "tf-acc-source-location"),
 					resource.TestCheckResourceAttr(terraformResourceName, "tags.Environment", "dev"),
 				),
 			},
+			// Import resource by arn
+			{
+				ResourceName:      terraformResourceName,
+				ImportState:       true,
+				ImportStateIdFunc: importStateIdFromArn(terraformResourceName),
+			},
+			// Import resource with a mismatched arn
+			{
+				ResourceName:  terraformResourceName,
+				ImportState:   true,
+				ImportStateId: "arn:aws:mediatailor:eu-central-1:985600762523:liveSource/tf-acc-source-location/" + name,
+				ExpectError:   regexp.MustCompile(`expected the ARN of a vodSource, got the ARN of a liveSource`),
+			},
 			{
 				Config: basicVodSourceWithSourceLocation(name, path2, k3, v3, k2, v2),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr(terraformResourceName, "id", "test_source_location,vod_source_example"),
+					resource.TestCheckResourceAttr(terraformResourceName, "id", "tf-acc-source-location,tf-acc-vod-source-example"),
 					resource.TestMatchResourceAttr(terraformResourceName, "arn", regexp.MustCompile(`^arn:aws:mediatailor:[\w-]+:\d+:vodSource\/.*$`)),
-					resource.TestMatchResourceAttr(terraformResourceName, "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
+					resource.TestMatchResourceAttr(terraformResourceName, "creation_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`)),
 					resource.TestCheckResourceAttr(terraformResourceName, "http_package_configurations.0.path", "/test"),
 					resource.TestCheckResourceAttr(terraformResourceName, "http_package_configurations.0.source_group", "default"),
 					resource.TestCheckResourceAttr(terraformResourceName, "http_package_configurations.0.type", "HLS"),
-					resource.TestMatchResourceAttr(terraformResourceName, "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,3})? \+\d{4} \w+$`)),
-					resource.TestCheckResourceAttr(terraformResourceName, "name", "vod_source_example"),
-					resource.TestCheckResourceAttr(terraformResourceName, "source_location_name", "test_source_location"),
+					resource.TestMatchResourceAttr(terraformResourceName, "last_modified_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`)),
+					resource.TestCheckResourceAttr(terraformResourceName, "name", "tf-acc-vod-source-example"),
+					resource.TestCheckResourceAttr(terraformResourceName, "source_location_name", "tf-acc-source-location"),
 					resource.TestCheckResourceAttr(terraformResourceName, "tags.Environment", "prod"),
 					resource.TestCheckResourceAttr(terraformResourceName, "tags.Testing", "pass"),
 				),
@@ -57,8 +71,32 @@ func TestAccVodSourceResourceBasic(t *testing.T) {
 	})
 }
 
+func TestAccVodSourceResourceReadCache(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: vodSourcesWithReadCache("/"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("awsmt_vod_source.cached.0", "http_package_configurations.0.path", "/"),
+					resource.TestCheckResourceAttr("awsmt_vod_source.cached.2", "http_package_configurations.0.path", "/"),
+					resource.TestCheckResourceAttr("awsmt_vod_source.cached.2", "ad_break_opportunities_offset_millis.#", "0"),
+				),
+			},
+			{
+				Config: vodSourcesWithReadCache("/updated"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("awsmt_vod_source.cached.0", "http_package_configurations.0.path", "/updated"),
+					resource.TestCheckResourceAttr("awsmt_vod_source.cached.1", "http_package_configurations.0.path", "/updated"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccVodSourceResourceCreationFailure(t *testing.T) {
-	name := "vod_source_failing"
+	name := "tf-acc-vod-source-failing"
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
@@ -74,7 +112,7 @@ func TestAccVodSourceResourceCreationFailure(t *testing.T) {
 func basicVodSourceWithSourceLocation(name, path, k1, v1, k2, v2 string) string {
 	return fmt.Sprintf(`
 		resource "awsmt_source_location" "test_source_location"{
-			name = "test_source_location"
+			name = "tf-acc-source-location"
 			http_configuration = {
 				base_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com/"
 			}
@@ -105,6 +143,29 @@ func basicVodSourceWithSourceLocation(name, path, k1, v1, k2, v2 string) string
 		}`, name, path, k1, v1, k2, v2)
 }
 
+func vodSourcesWithReadCache(path string) string {
+	return fmt.Sprintf(`
+		provider "awsmt" {
+			read_cache = true
+		}
+		resource "awsmt_source_location" "cached" {
+			name = "tf-acc-source-location-read-cache"
+			http_configuration = {
+				base_url = "https://ott-mediatailor-test.s3.eu-central-1.amazonaws.com/"
+			}
+		}
+		resource "awsmt_vod_source" "cached" {
+			count = 3
+			http_package_configurations = [{
+				path = "%[1]s"
+				source_group = "default"
+				type = "HLS"
+			}]
+			source_location_name = awsmt_source_location.cached.name
+			name = "tf-acc-vod-source-cached-${count.index}"
+		}`, path)
+}
+
 func vodSourceWithoutSourceLocation(name string) string {
 	return fmt.Sprintf(`
 		resource "awsmt_vod_source" "vod_source_acc_test" {
@@ -113,7 +174,28 @@ func vodSourceWithoutSourceLocation(name string) string {
 				source_group = "default"
 				type = "HLS"
 			}]
-			source_location_name = "unexisting_source_location"
+			source_location_name = "tf-acc-unexisting-source-location"
 			name = "%[1]s"
 		}`, name)
 }
+
+func TestUpgradeVodSourceStateV0(t *testing.T) {
+	upgraded := upgradeResourceState(t, ResourceVodSource(), 0, `{
+		"id": "test",
+		"name": "test",
+		"source_location_name": "test",
+		"creation_time": "2024-05-13 09:12:45.123 +0000 UTC",
+		"last_modified_time": "2024-05-14 11:00:00 +0200 CEST"
+	}`)
+
+	var state map[string]tftypes.Value
+	if err := upgraded.As(&state); err != nil {
+		t.Fatal(err)
+	}
+	var creationTime, lastModifiedTime string
+	_ = state["creation_time"].As(&creationTime)
+	_ = state["last_modified_time"].As(&lastModifiedTime)
+	if creationTime != "2024-05-13T09:12:45Z" || lastModifiedTime != "2024-05-14T11:00:00+02:00" {
+		t.Errorf("expected the timestamps to be upgraded to RFC3339, got %q and %q", creationTime, lastModifiedTime)
+	}
+}
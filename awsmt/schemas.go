@@ -1,11 +1,15 @@
 package awsmt
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -20,6 +24,26 @@ var computedString = schema.StringAttribute{
 	Computed: true,
 }
 
+// @ADR
+// Context: The timestamps of the resources were stored in the format of Go's time.Time.String, which the time
+// functions of Terraform, like timecmp and timeadd, cannot parse.
+// Decision: We decided to store every timestamp in the RFC3339 format, with the RFC3339 type of the framework, and to
+// upgrade the timestamps of the existing states.
+// Consequences: The timestamps lose their sub-second precision, and the schema version of the resources with
+// timestamps is bumped.
+var computedRFC3339 = schema.StringAttribute{
+	Computed:   true,
+	CustomType: timetypes.RFC3339Type{},
+}
+
+var computedRFC3339WithStateForUnknown = schema.StringAttribute{
+	Computed:   true,
+	CustomType: timetypes.RFC3339Type{},
+	PlanModifiers: []planmodifier.String{
+		stringplanmodifier.UseStateForUnknown(),
+	},
+}
+
 var computedInt64 = schema.Int64Attribute{
 	Computed: true,
 }
@@ -69,14 +93,6 @@ var optionalMap = schema.MapAttribute{
 	ElementType: types.StringType,
 }
 
-var optionalUnknownList = schema.ListAttribute{
-	Optional:    true,
-	ElementType: types.StringType,
-	PlanModifiers: []planmodifier.List{
-		listplanmodifier.UseStateForUnknown(),
-	},
-}
-
 var computedStringWithStateForUnknown = schema.StringAttribute{
 	Computed: true,
 	PlanModifiers: []planmodifier.String{
@@ -122,3 +138,98 @@ var httpPackageConfigurationsDataSourceSchema = schema.ListNestedAttribute{
 		},
 	},
 }
+
+// dataSourceLookupName returns the name attribute of a data source, which is exclusive with the ARN and the tags the
+// object of the data source can also be looked up by.
+func dataSourceLookupName(arnAttribute string, validators ...validator.String) schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional: true,
+		Computed: true,
+		Validators: append([]validator.String{
+			stringvalidator.ExactlyOneOf(path.MatchRoot(arnAttribute), path.MatchRoot("tags")),
+		}, validators...),
+	}
+}
+
+var dataSourceLookupArn = schema.StringAttribute{
+	Optional: true,
+	Computed: true,
+}
+
+var dataSourceLookupTags = schema.MapAttribute{
+	Optional:    true,
+	Computed:    true,
+	ElementType: types.StringType,
+	Validators: []validator.Map{
+		mapvalidator.SizeAtLeast(1),
+	},
+}
+
+// dataSourceLookupSourceLocationName is the source location name of the vod and live source data sources, which is
+// read from the ARN when the source is looked up by ARN, and restricts the sources looked up by tags otherwise.
+var dataSourceLookupSourceLocationName = schema.StringAttribute{
+	Optional: true,
+	Computed: true,
+	Validators: []validator.String{
+		stringvalidator.ConflictsWith(path.MatchRoot("arn")),
+	},
+}
+
+var nameIdentitySchema = identityschema.Schema{
+	Attributes: map[string]identityschema.Attribute{
+		"region": identityschema.StringAttribute{
+			OptionalForImport: true,
+			Description:       "Region of the resource, defaults to the region of the provider.",
+		},
+		"name": identityschema.StringAttribute{
+			RequiredForImport: true,
+			Description:       "Name of the resource.",
+		},
+	},
+}
+
+var contentSourceIdentitySchema = identityschema.Schema{
+	Attributes: map[string]identityschema.Attribute{
+		"region": identityschema.StringAttribute{
+			OptionalForImport: true,
+			Description:       "Region of the resource, defaults to the region of the provider.",
+		},
+		"source_location_name": identityschema.StringAttribute{
+			RequiredForImport: true,
+			Description:       "Name of the source location of the resource.",
+		},
+		"name": identityschema.StringAttribute{
+			RequiredForImport: true,
+			Description:       "Name of the resource.",
+		},
+	},
+}
+
+var listNamePrefix = listschema.StringAttribute{
+	Optional:    true,
+	Description: "Only list the resources whose name starts with this prefix.",
+}
+
+var listTags = listschema.MapAttribute{
+	Optional:    true,
+	ElementType: types.StringType,
+	Description: "Only list the resources having all these tags.",
+}
+
+var listFilterSchema = listschema.Schema{
+	Attributes: map[string]listschema.Attribute{
+		"name_prefix": listNamePrefix,
+		"tags":        listTags,
+	},
+}
+
+var contentSourceListFilterSchema = listschema.Schema{
+	Attributes: map[string]listschema.Attribute{
+		"source_location_name": listschema.StringAttribute{
+			Optional:    true,
+			Description: "Only list the sources of this source location. The sources of every source location are listed by default.",
+		},
+		"name_prefix": listNamePrefix,
+		"tags":        listTags,
+	},
+}
@@ -0,0 +1,328 @@
+package awsmt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/service/mediatailor"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"log"
+	"os"
+	"strings"
+)
+
+// accTestNamePrefix starts the name of every object created by the acceptance tests. Only objects whose name starts
+// with it are removed by the sweepers, so that manually created objects in the development account are left untouched.
+const accTestNamePrefix = "tf-acc-"
+
+func init() {
+	resource.AddTestSweepers("awsmt_program", &resource.Sweeper{
+		Name: "awsmt_program",
+		F:    sweepPrograms,
+	})
+
+	resource.AddTestSweepers("awsmt_channel", &resource.Sweeper{
+		Name:         "awsmt_channel",
+		Dependencies: []string{"awsmt_program"},
+		F:            sweepChannels,
+	})
+
+	resource.AddTestSweepers("awsmt_vod_source", &resource.Sweeper{
+		Name:         "awsmt_vod_source",
+		Dependencies: []string{"awsmt_channel"},
+		F:            sweepVodSources,
+	})
+
+	resource.AddTestSweepers("awsmt_live_source", &resource.Sweeper{
+		Name:         "awsmt_live_source",
+		Dependencies: []string{"awsmt_channel"},
+		F:            sweepLiveSources,
+	})
+
+	resource.AddTestSweepers("awsmt_source_location", &resource.Sweeper{
+		Name:         "awsmt_source_location",
+		Dependencies: []string{"awsmt_vod_source", "awsmt_live_source"},
+		F:            sweepSourceLocations,
+	})
+
+	resource.AddTestSweepers("awsmt_prefetch_schedule", &resource.Sweeper{
+		Name: "awsmt_prefetch_schedule",
+		F:    sweepPrefetchSchedules,
+	})
+
+	resource.AddTestSweepers("awsmt_playback_configuration", &resource.Sweeper{
+		Name:         "awsmt_playback_configuration",
+		Dependencies: []string{"awsmt_prefetch_schedule"},
+		F:            sweepPlaybackConfigurations,
+	})
+}
+
+func sharedClientForRegion(region string) (*mediatailor.Client, error) {
+	settings := clientSettings{
+		region:               region,
+		profile:              os.Getenv("AWS_PROFILE"),
+		maxAttempts:          10,
+		mediaTailorEndpoint:  os.Getenv("AWS_ENDPOINT_URL_MEDIATAILOR"),
+		stsEndpoint:          os.Getenv("AWS_ENDPOINT_URL_STS"),
+		useFipsEndpoint:      strings.EqualFold(os.Getenv("AWS_USE_FIPS_ENDPOINT"), "true"),
+		useDualstackEndpoint: strings.EqualFold(os.Getenv("AWS_USE_DUALSTACK_ENDPOINT"), "true"),
+	}
+	cfg, err := (&awsmtProvider{}).getClientConfig(context.Background(), settings)
+	if err != nil {
+		return nil, fmt.Errorf("error getting client config for region %s: %w", region, err)
+	}
+	return newMediaTailorClient(cfg, settings), nil
+}
+
+func isSweepable(name *string) bool {
+	if name == nil {
+		return false
+	}
+	return strings.HasPrefix(*name, accTestNamePrefix)
+}
+
+func sweepPrograms(region string) error {
+	ctx := context.Background()
+	client, err := sharedClientForRegion(region)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	channels := mediatailor.NewListChannelsPaginator(client, &mediatailor.ListChannelsInput{})
+	for channels.HasMorePages() {
+		page, err := channels.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("error listing channels: %w", err)
+		}
+		for _, channel := range page.Items {
+			if !isSweepable(channel.ChannelName) {
+				continue
+			}
+			schedule := mediatailor.NewGetChannelSchedulePaginator(client, &mediatailor.GetChannelScheduleInput{ChannelName: channel.ChannelName})
+			for schedule.HasMorePages() {
+				entries, err := schedule.NextPage(ctx)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("error getting schedule of channel %s: %w", *channel.ChannelName, err))
+					break
+				}
+				for _, entry := range entries.Items {
+					log.Printf("[INFO] Deleting program %s of channel %s", *entry.ProgramName, *channel.ChannelName)
+					if _, err := client.DeleteProgram(ctx, &mediatailor.DeleteProgramInput{ChannelName: channel.ChannelName, ProgramName: entry.ProgramName}); err != nil {
+						errs = append(errs, fmt.Errorf("error deleting program %s: %w", *entry.ProgramName, err))
+					}
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func sweepChannels(region string) error {
+	ctx := context.Background()
+	client, err := sharedClientForRegion(region)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	channels := mediatailor.NewListChannelsPaginator(client, &mediatailor.ListChannelsInput{})
+	for channels.HasMorePages() {
+		page, err := channels.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("error listing channels: %w", err)
+		}
+		for _, channel := range page.Items {
+			if !isSweepable(channel.ChannelName) {
+				continue
+			}
+			log.Printf("[INFO] Deleting channel %s", *channel.ChannelName)
+			if _, err := client.StopChannel(ctx, &mediatailor.StopChannelInput{ChannelName: channel.ChannelName}); err != nil {
+				errs = append(errs, fmt.Errorf("error stopping channel %s: %w", *channel.ChannelName, err))
+				continue
+			}
+			if _, err := client.DeleteChannelPolicy(ctx, &mediatailor.DeleteChannelPolicyInput{ChannelName: channel.ChannelName}); err != nil && !strings.Contains(err.Error(), "NotFound") {
+				errs = append(errs, fmt.Errorf("error deleting policy of channel %s: %w", *channel.ChannelName, err))
+				continue
+			}
+			if _, err := client.DeleteChannel(ctx, &mediatailor.DeleteChannelInput{ChannelName: channel.ChannelName}); err != nil {
+				errs = append(errs, fmt.Errorf("error deleting channel %s: %w", *channel.ChannelName, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func sweepVodSources(region string) error {
+	ctx := context.Background()
+	client, err := sharedClientForRegion(region)
+	if err != nil {
+		return err
+	}
+
+	names, err := sweepableSourceLocationNames(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, sourceLocationName := range names {
+		vodSources := mediatailor.NewListVodSourcesPaginator(client, &mediatailor.ListVodSourcesInput{SourceLocationName: &sourceLocationName})
+		for vodSources.HasMorePages() {
+			page, err := vodSources.NextPage(ctx)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("error listing vod sources of source location %s: %w", sourceLocationName, err))
+				break
+			}
+			for _, vodSource := range page.Items {
+				log.Printf("[INFO] Deleting vod source %s of source location %s", *vodSource.VodSourceName, sourceLocationName)
+				if _, err := client.DeleteVodSource(ctx, &mediatailor.DeleteVodSourceInput{SourceLocationName: &sourceLocationName, VodSourceName: vodSource.VodSourceName}); err != nil {
+					errs = append(errs, fmt.Errorf("error deleting vod source %s: %w", *vodSource.VodSourceName, err))
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func sweepLiveSources(region string) error {
+	ctx := context.Background()
+	client, err := sharedClientForRegion(region)
+	if err != nil {
+		return err
+	}
+
+	names, err := sweepableSourceLocationNames(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, sourceLocationName := range names {
+		liveSources := mediatailor.NewListLiveSourcesPaginator(client, &mediatailor.ListLiveSourcesInput{SourceLocationName: &sourceLocationName})
+		for liveSources.HasMorePages() {
+			page, err := liveSources.NextPage(ctx)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("error listing live sources of source location %s: %w", sourceLocationName, err))
+				break
+			}
+			for _, liveSource := range page.Items {
+				log.Printf("[INFO] Deleting live source %s of source location %s", *liveSource.LiveSourceName, sourceLocationName)
+				if _, err := client.DeleteLiveSource(ctx, &mediatailor.DeleteLiveSourceInput{SourceLocationName: &sourceLocationName, LiveSourceName: liveSource.LiveSourceName}); err != nil {
+					errs = append(errs, fmt.Errorf("error deleting live source %s: %w", *liveSource.LiveSourceName, err))
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func sweepSourceLocations(region string) error {
+	ctx := context.Background()
+	client, err := sharedClientForRegion(region)
+	if err != nil {
+		return err
+	}
+
+	names, err := sweepableSourceLocationNames(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, name := range names {
+		log.Printf("[INFO] Deleting source location %s", name)
+		if _, err := client.DeleteSourceLocation(ctx, &mediatailor.DeleteSourceLocationInput{SourceLocationName: &name}); err != nil {
+			errs = append(errs, fmt.Errorf("error deleting source location %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func sweepableSourceLocationNames(ctx context.Context, client *mediatailor.Client) ([]string, error) {
+	var names []string
+	sourceLocations := mediatailor.NewListSourceLocationsPaginator(client, &mediatailor.ListSourceLocationsInput{})
+	for sourceLocations.HasMorePages() {
+		page, err := sourceLocations.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error listing source locations: %w", err)
+		}
+		for _, sourceLocation := range page.Items {
+			if isSweepable(sourceLocation.SourceLocationName) {
+				names = append(names, *sourceLocation.SourceLocationName)
+			}
+		}
+	}
+	return names, nil
+}
+
+func sweepPrefetchSchedules(region string) error {
+	ctx := context.Background()
+	client, err := sharedClientForRegion(region)
+	if err != nil {
+		return err
+	}
+
+	names, err := sweepablePlaybackConfigurationNames(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, playbackConfigurationName := range names {
+		schedules := mediatailor.NewListPrefetchSchedulesPaginator(client, &mediatailor.ListPrefetchSchedulesInput{PlaybackConfigurationName: &playbackConfigurationName})
+		for schedules.HasMorePages() {
+			page, err := schedules.NextPage(ctx)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("error listing prefetch schedules of playback configuration %s: %w", playbackConfigurationName, err))
+				break
+			}
+			for _, schedule := range page.Items {
+				log.Printf("[INFO] Deleting prefetch schedule %s of playback configuration %s", *schedule.Name, playbackConfigurationName)
+				if _, err := client.DeletePrefetchSchedule(ctx, &mediatailor.DeletePrefetchScheduleInput{PlaybackConfigurationName: &playbackConfigurationName, Name: schedule.Name}); err != nil {
+					errs = append(errs, fmt.Errorf("error deleting prefetch schedule %s: %w", *schedule.Name, err))
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func sweepPlaybackConfigurations(region string) error {
+	ctx := context.Background()
+	client, err := sharedClientForRegion(region)
+	if err != nil {
+		return err
+	}
+
+	names, err := sweepablePlaybackConfigurationNames(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, name := range names {
+		log.Printf("[INFO] Deleting playback configuration %s", name)
+		if _, err := client.DeletePlaybackConfiguration(ctx, &mediatailor.DeletePlaybackConfigurationInput{Name: &name}); err != nil {
+			errs = append(errs, fmt.Errorf("error deleting playback configuration %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func sweepablePlaybackConfigurationNames(ctx context.Context, client *mediatailor.Client) ([]string, error) {
+	var names []string
+	playbackConfigurations := mediatailor.NewListPlaybackConfigurationsPaginator(client, &mediatailor.ListPlaybackConfigurationsInput{})
+	for playbackConfigurations.HasMorePages() {
+		page, err := playbackConfigurations.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error listing playback configurations: %w", err)
+		}
+		for _, playbackConfiguration := range page.Items {
+			if isSweepable(playbackConfiguration.Name) {
+				names = append(names, *playbackConfiguration.Name)
+			}
+		}
+	}
+	return names, nil
+}
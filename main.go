@@ -2,12 +2,21 @@ package main
 
 import (
 	"context"
+	"flag"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"log"
+	"os"
 	"terraform-provider-mediatailor/awsmt"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		if err := export(os.Args[2:]); err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+
 	err := providerserver.Serve(context.Background(), awsmt.New, providerserver.ServeOpts{
 
 		Address: "registry.terraform.io/spring-media/awsmt",
@@ -16,3 +25,17 @@ func main() {
 		log.Fatal(err.Error())
 	}
 }
+
+// export runs the export command, which writes the configuration of the MediaTailor resources of a region, for
+// example `terraform-provider-awsmt export --region eu-central-1 --out dir/`.
+func export(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	var options awsmt.ExportOptions
+	flags.StringVar(&options.Region, "region", "eu-central-1", "AWS region of the exported resources")
+	flags.StringVar(&options.Profile, "profile", os.Getenv("AWS_PROFILE"), "AWS profile used to list the resources")
+	flags.StringVar(&options.Out, "out", ".", "directory the configuration files are written to")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	return awsmt.Export(context.Background(), options)
+}